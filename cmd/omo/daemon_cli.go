@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"omo/internal/host"
+	"omo/pkg/pluginapi"
+	"omo/pkg/secrets"
+)
+
+const daemonCLIUsage = `omo daemon – poll dashboards and alert rules with the TUI closed
+
+Usage:
+  omo daemon [run] [--interval D]   run in the foreground until SIGINT/SIGTERM
+  omo daemon status                 show the running daemon's tiles and alerts
+  omo daemon stop                   ask the running daemon to exit
+
+The daemon watches every tile of every layout in ~/.omo/dashboards.yaml and
+one tile per rule in ~/.omo/alerts.yaml, records metric history and runs the
+alert hooks. It listens on ~/.omo/daemon.sock; an omo TUI that finds the
+socket shows its dashboard from the daemon's warm plugin sessions.
+
+SIGHUP reloads the KeePass database. Configs are re-read on every poll.
+
+Flags for 'run':
+  --interval  duration   poll interval (default: refresh in dashboards.yaml, 30s)
+
+Run it under systemd, launchd or tmux to keep it across logins, e.g.
+  nohup omo daemon >/dev/null 2>&1 &
+`
+
+// runDaemonCLI is the entrypoint for the `omo daemon` subcommand.
+func runDaemonCLI(args []string) {
+	cmd, rest := "run", args
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		cmd, rest = args[0], args[1:]
+	}
+	switch cmd {
+	case "run":
+		runDaemonRun(rest)
+	case "status":
+		runDaemonStatus()
+	case "stop":
+		runDaemonStop()
+	case "help", "--help", "-h":
+		fmt.Print(daemonCLIUsage)
+	default:
+		fmt.Fprintf(os.Stderr, "omo daemon: unknown command %q\n\n%s", cmd, daemonCLIUsage)
+		os.Exit(1)
+	}
+}
+
+func runDaemonRun(args []string) {
+	fs := flag.NewFlagSet("daemon", flag.ExitOnError)
+	interval := fs.Duration("interval", 0, "poll interval")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	logger, err := pluginapi.NewLogger("daemon")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "omo daemon: failed to initialise logger: %v\n", err)
+	}
+	if logger != nil {
+		defer logger.Close()
+	}
+	logFn := func(format string, a ...interface{}) {
+		if logger != nil {
+			logger.Info(format, a...)
+		}
+	}
+
+	p, err := secrets.New()
+	if err != nil {
+		daemonFatalf("open secrets database: %v", err)
+	}
+	defer p.Close()
+	pluginapi.SetSecretsProvider(secrets.NewAdapter(p))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+
+	socket := pluginapi.DaemonSocketPath()
+	fmt.Fprintf(os.Stderr, "omo daemon: starting on %s (log: %s)\n", socket, pluginapi.LogsDir())
+	if err := host.NewDaemon(*interval, logFn).Run(ctx, socket, reload); err != nil {
+		daemonFatalf("%v", err)
+	}
+}
+
+func dialDaemon() *host.DaemonClient {
+	client, err := host.DialDaemon(pluginapi.DaemonSocketPath())
+	if err != nil {
+		daemonFatalf("no daemon running (%s)", pluginapi.DaemonSocketPath())
+	}
+	return client
+}
+
+func runDaemonStatus() {
+	client := dialDaemon()
+	defer client.Close()
+	st, err := client.Status()
+	if err != nil {
+		daemonFatalf("status: %v", err)
+	}
+	fmt.Printf("pid %d · up %s · polling every %s · %d firing\n",
+		st.PID, time.Since(st.Started).Round(time.Second), st.Interval, st.Firing)
+	for _, t := range st.Tiles {
+		at := "-"
+		if !t.At.IsZero() {
+			at = t.At.Format("15:04:05")
+		}
+		fmt.Printf("  %-40s %-16s %s\n", t.Label, t.Status, at)
+	}
+	notes, err := client.Notifications()
+	if err != nil {
+		daemonFatalf("notifications: %v", err)
+	}
+	for _, n := range notes {
+		state := "resolved"
+		if n.ResolvedAt.IsZero() {
+			state = "FIRING"
+		}
+		fmt.Printf("%s %-8s %-8s %s · %s · %s\n", n.FiredAt.Format("01-02 15:04"), state, n.Severity, n.Rule, n.Tile, n.Message)
+	}
+}
+
+func runDaemonStop() {
+	client := dialDaemon()
+	defer client.Close()
+	if err := client.Stop(); err != nil {
+		daemonFatalf("stop: %v", err)
+	}
+	fmt.Println("daemon stopping")
+}
+
+func daemonFatalf(format string, a ...interface{}) {
+	fmt.Fprintf(os.Stderr, "omo daemon: "+format+"\n", a...)
+	os.Exit(1)
+}
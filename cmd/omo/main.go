@@ -22,6 +22,10 @@ func main() {
 		runSecretsCLI(os.Args[2:])
 		return
 	}
+	if len(os.Args) > 1 && os.Args[1] == "daemon" {
+		runDaemonCLI(os.Args[2:])
+		return
+	}
 
 	// App logger: ~/.omo/logs/omo.log
 	logger, err := pluginapi.NewLogger("omo")
@@ -48,6 +52,7 @@ func main() {
 	pluginapi.SetSecretsProvider(secrets.NewAdapter(secretsProvider))
 
 	app := tview.NewApplication()
+	app.EnableMouse(true) // header clicks sort plugin tables
 	app.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
 		screen.Fill(' ', tcell.StyleDefault.Background(ui.ColorAppBg))
 		return false
@@ -101,18 +106,15 @@ func main() {
 	// Tab cycles: plugins list → main content → plugins list
 	// Shift+Tab cycles in reverse
 	// While a modal is open, Tab/Shift+Tab stay inside that modal (fields/buttons).
-	// Ctrl+t opens target/instance selector for the active RPC plugin
+	// Host shortcuts (Ctrl+t target, Ctrl+e export, D/p/t/… on the plugins
+	// list) come from the keymap, ~/.omo/keymap.yaml when present.
 	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if omoHost.SplashVisible() {
 			omoHost.DismissSplash()
 			return nil
 		}
 
-		if event.Key() == tcell.KeyCtrlT {
-			if modalOpen() {
-				return event
-			}
-			omoHost.SelectTarget()
+		if !modalOpen() && omoHost.HandleKey(event, pluginsFocused()) {
 			return nil
 		}
 
@@ -140,27 +142,6 @@ func main() {
 			return nil
 		}
 
-		// Host sidebar shortcuts (plugins list must be focused).
-		if pluginsFocused() && !modalOpen() {
-			switch event.Rune() {
-			case 'r', 'R':
-				omoHost.RefreshPlugins()
-				return nil
-			case 'p', 'P':
-				omoHost.OpenPackageManager()
-				return nil
-			case 'i', 'I', 's', 'S':
-				omoHost.OpenSettings()
-				return nil
-			case 'D':
-				omoHost.OpenDashboard()
-				return nil
-			case 't', 'T':
-				omoHost.OpenThemes()
-				return nil
-			}
-		}
-
 		return event
 	})
 
@@ -1,14 +1,18 @@
 package main
 
 import (
+	"bufio"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"omo/pkg/pluginapi"
 	"omo/pkg/secrets"
+	"omo/pkg/secrets/importer"
 )
 
 const secretsCLIUsage = `omo secrets – CRUD operations on the KeePass database (~/.omo/secrets/omo.kdbx)
@@ -18,16 +22,27 @@ Paths follow the convention:  pluginName/environment/entryName
 
 Usage:
   omo secrets list   [prefix]
-  omo secrets get    <path>
+  omo secrets get    <path>  [--resolved]
   omo secrets put    <path>  [flags]
   omo secrets delete <path>
+  omo secrets import <source> [file]  [--env E] [--plugin P] [--dry-run]
+  omo secrets export --prefix P --recipient KEY  [--strip-passwords] [--out FILE]
+  omo secrets import-bundle [file]  [--identity FILE] [--prefix P] [--on-conflict MODE] [--dry-run]
+  omo secrets age-key                  (print this machine's bundle public key)
   omo secrets reset  [--yes]  (deletes ~/.omo/secrets/omo.kdbx; recreates on next open)
 
 Commands:
   list    List all entry paths, optionally filtered by prefix
-  get     Print all fields of an entry as JSON
+  get     Print all fields of an entry as JSON (--resolved applies
+          extends and ref:// values, as the TUI does before Configure)
   put     Create or update an entry (only supplied flags are written)
   delete  Remove an entry
+  import  Create entries from existing tool configs (see sources below)
+  export  Write entries under a prefix as an age-encrypted bundle
+  import-bundle
+          Decrypt a bundle and merge it into the vault
+  age-key Print (creating on first use) the age public key teammates
+          should pass to --recipient when sharing a bundle with you
   reset   Delete the KeePass database file (use --yes). Key file is kept.
 
 Environment:
@@ -40,13 +55,65 @@ Flags for 'put':
   --notes     string
   --attr      key=value   (repeatable – custom attributes)
 
+Shared values:
+  An attribute value ref://plugin/env/name#field copies one field (username,
+  password, url, notes or a custom attribute) from another entry; without
+  #field the attribute's own name is used. The attribute extends=<path>[,<path>]
+  inherits every non-empty field of the listed entries. Both are resolved when
+  omo configures a plugin; cycles are reported as errors.
+
+Sources for 'import' (file defaults in parentheses):
+  ssh     Host blocks              (~/.ssh/config)         → ssh/<env>/<alias>
+  kube    kubeconfig contexts      (~/.kube/config)        → k8sportforward/<env>/<context>
+  aws     AWS profiles             (~/.aws/credentials)    → s3/<env>/<profile>
+  pgpass  PostgreSQL passwords     (~/.pgpass)             → postgres/<env>/<host>-<db>
+  docker  Docker CLI contexts      (~/.docker/contexts)    → docker/<env>/<context>
+  env     Connection variables     (./.env)                → <plugin>/<env>/<dir name>
+
+Flags for 'import':
+  --env      string   environment segment (default "imported")
+  --plugin   string   override the target plugin (e.g. k8suser for kube)
+  --dry-run           print what would be created without writing
+
+  Entries whose path already exists, or that point at the same endpoint as an
+  existing entry of the same plugin, are skipped.
+
+Flags for 'export':
+  --prefix           string   path prefix to export (e.g. redis/staging)
+  --recipient        key      age1… or ssh-ed25519 public key (repeatable)
+  --strip-passwords           omit passwords, tokens and private keys
+  --out              file     write the bundle here instead of stdout
+
+Flags for 'import-bundle' (file defaults to stdin):
+  --identity     file   age identity or ssh private key (repeatable;
+                        default ~/.omo/keys/age.txt)
+  --prefix       path   only import entries under this prefix (required
+                        unless you confirm the prefix the bundle declares)
+  --on-conflict  mode   prompt (default), skip, overwrite or merge
+  --allow-credential-helpers
+                        keep credential_helper commands (dropped by default)
+  --allow-references    keep ref:// values and extends (dropped by default)
+  --dry-run             show what would change without writing
+
+  Bundles are not signed. Entries outside the import prefix are rejected,
+  and credential_helper commands, ref:// values and extends are dropped
+  unless you allow them.
+  Empty fields in the bundle never erase local values, so importing a
+  --strip-passwords bundle keeps the passwords you already have.
+
 Examples:
   omo secrets list
   omo secrets list redis
   omo secrets get  redis/production/cache
   omo secrets put  redis/production/cache --username admin --password s3cr3t --url redis://localhost:6379
   omo secrets put  redis/production/cache --attr tls_cert="-----BEGIN CERT-----..."
+  omo secrets put  ssh/production/web --attr jump_key=ref://ssh/shared/bastion#private_key
+  omo secrets put  ssh/production/api --attr extends=ssh/shared/defaults
   omo secrets delete redis/production/cache
+  omo secrets import ssh --dry-run
+  omo secrets import env ./app/.env --env staging
+  omo secrets export --prefix redis/staging --recipient age1… --strip-passwords --out staging.age
+  omo secrets import-bundle staging.age --prefix redis/staging
   omo secrets reset --yes
 `
 
@@ -65,6 +132,10 @@ func runSecretsCLI(args []string) {
 		runSecretsReset(rest)
 		return
 	}
+	if cmd == "age-key" {
+		runSecretsAgeKeyCmd()
+		return
+	}
 
 	p, err := secrets.New()
 	if err != nil {
@@ -81,6 +152,12 @@ func runSecretsCLI(args []string) {
 		runSecretsPutCmd(p, rest)
 	case "delete", "rm":
 		runSecretsDeleteCmd(p, rest)
+	case "import":
+		runSecretsImportCmd(p, rest)
+	case "export":
+		runSecretsExportCmd(p, rest)
+	case "import-bundle":
+		runSecretsImportBundleCmd(p, rest)
 	case "help", "--help", "-h":
 		fmt.Print(secretsCLIUsage)
 	default:
@@ -117,10 +194,38 @@ func runSecretsGetCmd(p secrets.Provider, args []string) {
 		fatalf("get: path argument required\nUsage: omo secrets get <pluginName/environment/entryName>")
 	}
 
+	fs := flag.NewFlagSet("get", flag.ExitOnError)
+	resolved := fs.Bool("resolved", false, "apply extends and ref:// values")
+	if err := fs.Parse(args[1:]); err != nil {
+		os.Exit(1)
+	}
+
 	entry, err := p.Get(args[0])
 	if err != nil {
 		fatalf("get %s: %v", args[0], err)
 	}
+	if *resolved {
+		vault := secrets.NewAdapter(p)
+		r, err := pluginapi.ResolveSecretEntry(vault, args[0], &pluginapi.SecretEntry{
+			Title:            entry.Title,
+			UserName:         entry.UserName,
+			Password:         entry.Password,
+			URL:              entry.URL,
+			Notes:            entry.Notes,
+			CustomAttributes: entry.CustomAttributes,
+		})
+		if err != nil {
+			fatalf("get %s: %v", args[0], err)
+		}
+		entry = &secrets.Entry{
+			Title:            r.Title,
+			UserName:         r.UserName,
+			Password:         r.Password,
+			URL:              r.URL,
+			Notes:            r.Notes,
+			CustomAttributes: r.CustomAttributes,
+		}
+	}
 
 	out := map[string]interface{}{
 		"path":     args[0],
@@ -257,6 +362,359 @@ func runSecretsDeleteCmd(p secrets.Provider, args []string) {
 	fmt.Printf("deleted: %s\n", path)
 }
 
+// ── import ───────────────────────────────────────────────────────────────────
+
+func runSecretsImportCmd(p secrets.Provider, args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	env := fs.String("env", importer.DefaultEnv, "environment segment for created paths")
+	plugin := fs.String("plugin", "", "override the target plugin")
+	dryRun := fs.Bool("dry-run", false, "preview without writing")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: omo secrets import <%s> [file] [--env E] [--plugin P] [--dry-run]\n",
+			strings.Join(importer.SourceNames(), "|"))
+	}
+
+	if len(args) == 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	source := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		os.Exit(1)
+	}
+	file := ""
+	if fs.NArg() > 0 {
+		file = fs.Arg(0)
+		// Allow flags after the file argument too.
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			os.Exit(1)
+		}
+	}
+
+	cands, err := importer.Load(source, file, importer.Options{Env: *env, Plugin: *plugin})
+	if err != nil {
+		fatalf("import %s: %v", source, err)
+	}
+	if len(cands) == 0 {
+		fmt.Fprintln(os.Stderr, "(nothing to import)")
+		return
+	}
+
+	vault := secrets.NewAdapter(p)
+	plan, err := importer.Plan(vault, cands)
+	if err != nil {
+		fatalf("import %s: %v", source, err)
+	}
+	for _, item := range plan {
+		switch item.Status {
+		case importer.StatusNew:
+			fmt.Printf("+ %-40s %s\n", item.Path, importer.Describe(item.Entry))
+		case importer.StatusExists:
+			fmt.Printf("= %-40s already exists\n", item.Path)
+		case importer.StatusDuplicate:
+			fmt.Printf("~ %-40s same endpoint as %s\n", item.Path, item.Existing)
+		}
+	}
+	fmt.Println(importer.Summary(plan))
+
+	if *dryRun {
+		fmt.Println("dry run: nothing written")
+		return
+	}
+	n, err := importer.Apply(vault, plan)
+	if err != nil {
+		fatalf("import %s: %v", source, err)
+	}
+	fmt.Printf("imported: %d entries\n", n)
+}
+
+// ── export / import-bundle ────────────────────────────────────────────────────
+
+func runSecretsExportCmd(p secrets.Provider, args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	prefix := fs.String("prefix", "", "path prefix to export")
+	strip := fs.Bool("strip-passwords", false, "omit passwords, tokens and private keys")
+	out := fs.String("out", "", "output file (default stdout)")
+	var recipients []string
+	fs.Func("recipient", "age or ssh public key (repeatable)", func(v string) error {
+		recipients = append(recipients, v)
+		return nil
+	})
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: omo secrets export --prefix P --recipient KEY [--recipient KEY ...] [--strip-passwords] [--out FILE]\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+	if *prefix == "" || len(recipients) == 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	bundle, err := secrets.BuildBundle(p, *prefix, *strip)
+	if err != nil {
+		fatalf("export: %v", err)
+	}
+	if len(bundle.Entries) == 0 {
+		fatalf("export: no entries under %q", *prefix)
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		f, err := os.OpenFile(*out, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			fatalf("export: %v", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := secrets.EncryptBundle(w, bundle, recipients); err != nil {
+		fatalf("export: %v", err)
+	}
+	note := ""
+	if *strip {
+		note = " (passwords stripped)"
+	}
+	fmt.Fprintf(os.Stderr, "exported: %d entries under %s%s\n", len(bundle.Entries), *prefix, note)
+}
+
+func runSecretsImportBundleCmd(p secrets.Provider, args []string) {
+	fs := flag.NewFlagSet("import-bundle", flag.ExitOnError)
+	onConflict := fs.String("on-conflict", "prompt", "prompt, skip, overwrite or merge")
+	dryRun := fs.Bool("dry-run", false, "preview without writing")
+	importPrefix := fs.String("prefix", "", "only import entries under this path prefix (e.g. redis/staging)")
+	allowHelpers := fs.Bool("allow-credential-helpers", false, "keep credential_helper commands from the bundle")
+	allowRefs := fs.Bool("allow-references", false, "keep ref:// values and extends from the bundle")
+	var identities []string
+	fs.Func("identity", "age identity or ssh private key file (repeatable)", func(v string) error {
+		identities = append(identities, v)
+		return nil
+	})
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: omo secrets import-bundle [file] [--identity FILE] [--prefix P] [--on-conflict prompt|skip|overwrite|merge] [--allow-credential-helpers] [--allow-references] [--dry-run]\n")
+	}
+
+	file := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		file, args = args[0], args[1:]
+	}
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+	switch *onConflict {
+	case "prompt", string(secrets.MergeSkip), string(secrets.MergeOverwrite), string(secrets.MergeFill):
+	default:
+		fatalf("import-bundle: unknown --on-conflict %q", *onConflict)
+	}
+
+	in := os.Stdin
+	if file != "" && file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			fatalf("import-bundle: %v", err)
+		}
+		defer f.Close()
+		in = f
+	} else if *onConflict == "prompt" {
+		fatalf("import-bundle: reading the bundle from stdin leaves no terminal for prompts; pass a file or --on-conflict")
+	}
+
+	if len(identities) == 0 {
+		identities = []string{secrets.DefaultAgeIdentityPath()}
+	}
+	ids, err := secrets.LoadAgeIdentities(identities)
+	if err != nil {
+		fatalf("import-bundle: %v", err)
+	}
+	bundle, err := secrets.DecryptBundle(in, ids)
+	if err != nil {
+		fatalf("import-bundle: %v", err)
+	}
+	fmt.Printf("bundle: %d entries · prefix %q · created %s\n",
+		len(bundle.Entries), bundle.Prefix, bundle.Created.Local().Format("2006-01-02 15:04"))
+
+	// The declared prefix comes from the bundle itself, so it only limits
+	// the import once the user has chosen or confirmed it.
+	stdin := bufio.NewReader(os.Stdin)
+	scope := *importPrefix
+	if scope == "" {
+		if *onConflict != "prompt" {
+			fatalf("import-bundle: pass --prefix to choose where the bundle may write (it declares %q)", bundle.Prefix)
+		}
+		if bundle.Prefix == "" {
+			fmt.Print("the bundle declares no prefix and may write any entry; import anyway? [y/N] ")
+		} else {
+			fmt.Printf("import entries under %q, the prefix the bundle declares? [y/N] ", bundle.Prefix)
+		}
+		line, _ := stdin.ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(line)) != "y" {
+			fmt.Println("aborted; pass --prefix to choose where the bundle may write")
+			return
+		}
+		scope = bundle.Prefix
+	}
+
+	applyAll := secrets.MergePolicy("")
+	var created, updated, skipped, rejected int
+	for _, be := range bundle.Entries {
+		// age does not authenticate the sender: anyone with our public key
+		// can write a bundle, so its paths, helper commands and references
+		// to other vault entries are not trusted.
+		if err := secrets.CheckImportPath(be.Path, scope); err != nil {
+			fmt.Printf("x %v (rejected)\n", err)
+			rejected++
+			continue
+		}
+		if helper := be.CredentialHelper(); helper != "" {
+			if *allowHelpers {
+				fmt.Printf("  %s runs credential_helper: %s\n", be.Path, helper)
+			} else {
+				fmt.Printf("  %s: dropped credential_helper (pass --allow-credential-helpers to keep it)\n", be.Path)
+				be.StripCredentialHelper()
+			}
+		}
+		if refs := be.References(); len(refs) > 0 {
+			if *allowRefs {
+				fmt.Printf("  %s references %s\n", be.Path, strings.Join(refs, ", "))
+			} else {
+				fmt.Printf("  %s: dropped %s (pass --allow-references to keep them)\n", be.Path, strings.Join(refs, ", "))
+				be.StripReferences()
+			}
+		}
+		incoming := be.Entry()
+		local, err := p.Get(be.Path)
+		if err != nil && !errors.Is(err, secrets.ErrNotFound) {
+			fatalf("get %s: %v", be.Path, err)
+		}
+		if err != nil {
+			fmt.Printf("+ %s\n", be.Path)
+			if !*dryRun {
+				if err := p.Put(be.Path, incoming); err != nil {
+					fatalf("put %s: %v", be.Path, err)
+				}
+			}
+			created++
+			continue
+		}
+
+		policy := secrets.MergePolicy(*onConflict)
+		if *onConflict == "prompt" {
+			policy = applyAll
+		}
+		merged := secrets.MergeEntry(local, incoming, secrets.MergeOverwrite)
+		if secrets.EntriesEqual(local, merged) {
+			fmt.Printf("= %s (unchanged)\n", be.Path)
+			skipped++
+			continue
+		}
+		if policy == "" {
+			fmt.Printf("! %s differs:\n", be.Path)
+			for _, line := range entryDiff(local, incoming) {
+				fmt.Printf("    %s\n", line)
+			}
+			policy, applyAll = promptConflict(stdin)
+		}
+
+		merged = secrets.MergeEntry(local, incoming, policy)
+		if policy == secrets.MergeSkip || secrets.EntriesEqual(local, merged) {
+			fmt.Printf("= %s (kept local)\n", be.Path)
+			skipped++
+			continue
+		}
+		fmt.Printf("~ %s (%s)\n", be.Path, policy)
+		if !*dryRun {
+			if err := p.Put(be.Path, merged); err != nil {
+				fatalf("put %s: %v", be.Path, err)
+			}
+		}
+		updated++
+	}
+
+	prefix := ""
+	if *dryRun {
+		prefix = "dry run: "
+	}
+	fmt.Printf("%s%d created · %d updated · %d skipped", prefix, created, updated, skipped)
+	if rejected > 0 {
+		fmt.Printf(" · %d rejected", rejected)
+	}
+	fmt.Println()
+}
+
+// promptConflict asks how to resolve one conflicting entry. An upper-case
+// answer also applies to every remaining conflict.
+func promptConflict(r *bufio.Reader) (secrets.MergePolicy, secrets.MergePolicy) {
+	for {
+		fmt.Print("  [s]kip, [o]verwrite, [m]erge (upper-case = all remaining) [s]: ")
+		line, err := r.ReadString('\n')
+		answer := strings.TrimSpace(line)
+		if err != nil && answer == "" {
+			return secrets.MergeSkip, secrets.MergeSkip
+		}
+		var policy secrets.MergePolicy
+		switch strings.ToLower(answer) {
+		case "", "s":
+			policy = secrets.MergeSkip
+		case "o":
+			policy = secrets.MergeOverwrite
+		case "m":
+			policy = secrets.MergeFill
+		default:
+			continue
+		}
+		if answer != "" && answer == strings.ToUpper(answer) {
+			return policy, policy
+		}
+		return policy, ""
+	}
+}
+
+// entryDiff lists the fields where incoming would change local. Secrets are
+// masked.
+func entryDiff(local, incoming *secrets.Entry) []string {
+	var out []string
+	field := func(name, a, b string, secret bool) {
+		if b == "" || a == b {
+			return
+		}
+		if secret {
+			a, b = maskSecret(a), maskSecret(b)
+		}
+		out = append(out, fmt.Sprintf("%-12s %q → %q", name, a, b))
+	}
+	field("username", local.UserName, incoming.UserName, false)
+	field("password", local.Password, incoming.Password, true)
+	field("url", local.URL, incoming.URL, false)
+	field("notes", local.Notes, incoming.Notes, false)
+	keys := make([]string, 0, len(incoming.CustomAttributes))
+	for k := range incoming.CustomAttributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		field(k, local.CustomAttributes[k], incoming.CustomAttributes[k], secrets.IsSensitiveAttribute(k))
+	}
+	return out
+}
+
+func maskSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "***"
+}
+
+func runSecretsAgeKeyCmd() {
+	path := secrets.DefaultAgeIdentityPath()
+	id, err := secrets.EnsureAgeIdentity(path)
+	if err != nil {
+		fatalf("age-key: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "identity: %s\n", path)
+	fmt.Println(id.Recipient().String())
+}
+
 // ── reset ────────────────────────────────────────────────────────────────────
 
 func runSecretsReset(args []string) {
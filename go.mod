@@ -3,6 +3,7 @@ module omo
 go 1.25.0
 
 require (
+	filippo.io/age v1.2.1
 	github.com/IBM/sarama v1.46.3
 	github.com/aws/aws-sdk-go v1.55.6
 	github.com/docker/docker v28.0.4+incompatible
@@ -28,6 +29,7 @@ require (
 
 require (
 	dario.cat/mergo v1.0.0 // indirect
+	filippo.io/edwards25519 v1.1.0 // indirect
 	github.com/Microsoft/go-winio v0.6.1 // indirect
 	github.com/ProtonMail/go-crypto v0.0.0-20230828082145-3c4c8a2d2371 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
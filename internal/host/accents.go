@@ -0,0 +1,86 @@
+package host
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/ui"
+
+	"github.com/gdamore/tcell/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// AccentRule colors the main frame while a plugin runs against a KeePass
+// target matching Match (path.Match syntax, e.g. "*/production/*"). Color is
+// a semantic slot (ok, warn, error, muted), #rrggbb or a color name.
+type AccentRule struct {
+	Match string `yaml:"match"`
+	Color string `yaml:"color"`
+}
+
+// AccentRules is ~/.omo/accents.yaml; the first matching rule wins:
+//
+//   - match: "*/production/*"
+//     color: error
+//   - match: "*/staging/*"
+//     color: "#e8b86d"
+type AccentRules []AccentRule
+
+// LoadAccentRules reads ~/.omo/accents.yaml. A missing file returns no rules
+// and no error.
+func LoadAccentRules() (AccentRules, error) {
+	data, err := os.ReadFile(pluginapi.AccentsPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rules AccentRules
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", pluginapi.AccentsPath(), err)
+	}
+	for i, r := range rules {
+		if _, err := path.Match(r.Match, ""); err != nil {
+			return nil, fmt.Errorf("%s: rule %d: bad match %q: %w", pluginapi.AccentsPath(), i+1, r.Match, err)
+		}
+		if r.Color == "" {
+			return nil, fmt.Errorf("%s: rule %d: color required", pluginapi.AccentsPath(), i+1)
+		}
+	}
+	return rules, nil
+}
+
+// For returns the accent color of a target path, or "" when no rule matches.
+func (a AccentRules) For(target string) string {
+	if target == "" {
+		return ""
+	}
+	for _, r := range a {
+		if ok, _ := path.Match(r.Match, target); ok {
+			return r.Color
+		}
+	}
+	return ""
+}
+
+// setAccent recolors the main frame for the shown plugin target; "" uses the
+// theme border.
+func (h *Host) setAccent(color string) {
+	h.accent = color
+	if h.Body != nil {
+		h.Body.SetBordersColor(h.frameColor())
+	}
+}
+
+func (h *Host) frameColor() tcell.Color {
+	if h.accent == "" {
+		return ui.ColorBorder
+	}
+	if c := tcell.GetColor(ui.SlotHex(h.accent)); c != tcell.ColorDefault {
+		return c
+	}
+	return ui.ColorBorder
+}
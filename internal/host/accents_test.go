@@ -0,0 +1,46 @@
+package host
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAccentRulesFirstMatchWins(t *testing.T) {
+	rules := AccentRules{
+		{Match: "*/production/*", Color: "error"},
+		{Match: "redis/*/*", Color: "#00ff00"},
+	}
+	cases := map[string]string{
+		"redis/production/main": "error",
+		"redis/staging/main":    "#00ff00",
+		"docker/dev/local":      "",
+		"":                      "",
+	}
+	for target, want := range cases {
+		if got := rules.For(target); got != want {
+			t.Fatalf("For(%q) = %q, want %q", target, got, want)
+		}
+	}
+}
+
+func TestLoadAccentRules(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if rules, err := LoadAccentRules(); err != nil || rules != nil {
+		t.Fatalf("missing file = %v, %v; want no rules", rules, err)
+	}
+	path := filepath.Join(home, ".omo", "accents.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(path, []byte("- match: \"*/prod/*\"\n  color: error\n"), 0o644)
+	rules, err := LoadAccentRules()
+	if err != nil || len(rules) != 1 || rules.For("pg/prod/db") != "error" {
+		t.Fatalf("rules = %v, %v", rules, err)
+	}
+	os.WriteFile(path, []byte("- match: \"[\"\n  color: error\n"), 0o644)
+	if _, err := LoadAccentRules(); err == nil {
+		t.Fatal("bad glob accepted")
+	}
+}
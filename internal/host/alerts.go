@@ -0,0 +1,671 @@
+package host
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/pluginrpc"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AlertConfig is ~/.omo/alerts.yaml:
+//
+//	notify:
+//	  bell: true
+//	  command: notify-send "omo: $OMO_ALERT_NAME" "$OMO_ALERT_MESSAGE"
+//	  webhook: https://hooks.example.com/omo
+//	rules:
+//	  - name: consumer lag
+//	    plugin: kafka
+//	    target: kafka/prod/main
+//	    metric: Lag
+//	    op: ">"
+//	    value: 10k
+//	    for: 5m
+//	    severity: critical
+//	  - name: app out of sync
+//	    plugin: argocd
+//	    view: apps
+//	    row: "*"
+//	    op: contains
+//	    value: OutOfSync
+//
+// Rules are evaluated on every dashboard pulse against the tiles showing
+// their plugin (and target and view, when set). The subject is a widget
+// metric, a widget row ("*" for any row) or, with neither, the tile status.
+type AlertConfig struct {
+	Notify AlertNotify `yaml:"notify,omitempty"`
+	Rules  []AlertRule `yaml:"rules"`
+}
+
+// AlertNotify says where firing and resolved alerts go besides the
+// notification center.
+type AlertNotify struct {
+	Bell    *bool  `yaml:"bell,omitempty"`    // terminal bell, default on
+	Command string `yaml:"command,omitempty"` // run through sh with OMO_ALERT_* env
+	Webhook string `yaml:"webhook,omitempty"` // URL that receives the event as JSON
+}
+
+// AlertRule is one threshold or state condition.
+type AlertRule struct {
+	Name     string `yaml:"name,omitempty"`
+	Plugin   string `yaml:"plugin"`
+	Target   string `yaml:"target,omitempty"`
+	View     string `yaml:"view,omitempty"`
+	Metric   string `yaml:"metric,omitempty"`
+	Row      string `yaml:"row,omitempty"`
+	Op       string `yaml:"op"`
+	Value    string `yaml:"value"`
+	For      string `yaml:"for,omitempty"`
+	Severity string `yaml:"severity,omitempty"` // warning (default) or critical
+
+	hold time.Duration
+}
+
+const (
+	severityWarning  = "warning"
+	severityCritical = "critical"
+
+	maxNotifications   = 200
+	alertHookTimeout   = 10 * time.Second
+	alertWidgetRowsMax = 500
+)
+
+var alertOps = map[string]bool{
+	">": true, ">=": true, "<": true, "<=": true, "==": true, "!=": true,
+	"contains": true, "!contains": true,
+}
+
+// LoadAlertConfig reads ~/.omo/alerts.yaml. A missing file returns nil and
+// no error.
+func LoadAlertConfig() (*AlertConfig, error) {
+	data, err := os.ReadFile(pluginapi.AlertsPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseAlertConfig(data)
+}
+
+func parseAlertConfig(data []byte) (*AlertConfig, error) {
+	var cfg AlertConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", pluginapi.AlertsPath(), err)
+	}
+	for i := range cfg.Rules {
+		if err := cfg.Rules[i].normalize(); err != nil {
+			return nil, fmt.Errorf("%s: rule %d: %w", pluginapi.AlertsPath(), i+1, err)
+		}
+	}
+	return &cfg, nil
+}
+
+func (r *AlertRule) normalize() error {
+	r.Plugin = strings.TrimSpace(r.Plugin)
+	if r.Plugin == "" {
+		return errors.New("plugin is required")
+	}
+	r.Op = strings.ToLower(strings.TrimSpace(r.Op))
+	if r.Op == "" {
+		r.Op = "contains"
+		if r.Metric != "" {
+			r.Op = ">"
+		}
+	}
+	if !alertOps[r.Op] {
+		return fmt.Errorf("unknown op %q", r.Op)
+	}
+	if isNumericOp(r.Op) {
+		if _, ok := parseAlertNumber(r.Value); !ok {
+			return fmt.Errorf("%s needs a numeric value, got %q", r.Op, r.Value)
+		}
+	}
+	if r.For != "" {
+		d, err := time.ParseDuration(r.For)
+		if err != nil || d < 0 {
+			return fmt.Errorf("invalid for %q", r.For)
+		}
+		r.hold = d
+	}
+	switch r.Severity = strings.ToLower(strings.TrimSpace(r.Severity)); r.Severity {
+	case "":
+		r.Severity = severityWarning
+	case severityWarning, severityCritical:
+	default:
+		return fmt.Errorf("unknown severity %q", r.Severity)
+	}
+	if r.Name == "" {
+		r.Name = strings.Join(strings.Fields(r.Plugin+" "+r.subjectName()+" "+r.Op+" "+r.Value), " ")
+	}
+	return nil
+}
+
+func (r AlertRule) subjectName() string {
+	switch {
+	case r.Metric != "":
+		return r.Metric
+	case r.Row != "":
+		return r.Row
+	}
+	return "status"
+}
+
+// matches reports whether the rule watches tile.
+func (r AlertRule) matches(tile DashboardTile) bool {
+	if r.Plugin != tile.Plugin || (r.Target != "" && r.Target != tile.Target) {
+		return false
+	}
+	view, tileView := r.View, tile.View
+	if view == pluginrpc.DashboardView {
+		view = ""
+	}
+	if tileView == pluginrpc.DashboardView {
+		tileView = ""
+	}
+	return view == tileView
+}
+
+// check evaluates the rule against a widget. found is false when the widget
+// has no such metric or row, e.g. because the plugin is unreachable; the
+// alert keeps its state then instead of resolving.
+func (r AlertRule) check(view pluginrpc.ViewData) (hit bool, detail string, found bool) {
+	switch {
+	case r.Metric != "":
+		for _, m := range view.Metrics {
+			if strings.EqualFold(m.Name, r.Metric) {
+				actual := formatMetric(m.Value, m.Unit)
+				return compareAlert(r.Op, strconv.FormatFloat(m.Value, 'f', -1, 64), r.Value),
+					fmt.Sprintf("%s %s %s %s", m.Name, actual, r.Op, r.Value), true
+			}
+		}
+		return false, "", false
+	case r.Row != "":
+		for _, row := range view.Rows {
+			if len(row) < 2 {
+				continue
+			}
+			key := stripPluginPrefix(row[0])
+			if r.Row != "*" && !strings.EqualFold(key, r.Row) {
+				continue
+			}
+			found = true
+			if compareAlert(r.Op, row[1], r.Value) {
+				return true, fmt.Sprintf("%s: %s", key, stripColorTags(row[1])), true
+			}
+		}
+		return false, "", found
+	}
+	status := dashStatus(strings.TrimSpace(view.Status))
+	return compareAlert(r.Op, status, r.Value), "status " + status, true
+}
+
+func isNumericOp(op string) bool {
+	switch op {
+	case ">", ">=", "<", "<=":
+		return true
+	}
+	return false
+}
+
+func compareAlert(op, actual, want string) bool {
+	actual = strings.TrimSpace(stripColorTags(actual))
+	a, aNum := parseAlertNumber(actual)
+	w, wNum := parseAlertNumber(want)
+	switch op {
+	case ">":
+		return aNum && wNum && a > w
+	case ">=":
+		return aNum && wNum && a >= w
+	case "<":
+		return aNum && wNum && a < w
+	case "<=":
+		return aNum && wNum && a <= w
+	case "==", "!=":
+		equal := strings.EqualFold(actual, strings.TrimSpace(want))
+		if aNum && wNum {
+			equal = a == w
+		}
+		return equal == (op == "==")
+	case "contains", "!contains":
+		has := strings.Contains(strings.ToLower(actual), strings.ToLower(strings.TrimSpace(want)))
+		return has == (op == "contains")
+	}
+	return false
+}
+
+// parseAlertNumber reads 10k, 2.5M, 512MiB, 512MB (binary) or 80%.
+func parseAlertNumber(s string) (float64, bool) {
+	s = strings.TrimSpace(strings.ReplaceAll(s, ",", ""))
+	s = strings.TrimSuffix(s, "%")
+	if s == "" {
+		return 0, false
+	}
+	mult := 1.0
+	lower := strings.ToLower(s)
+	for _, u := range []struct {
+		suffix string
+		mult   float64
+	}{
+		{"kib", 1 << 10}, {"mib", 1 << 20}, {"gib", 1 << 30}, {"tib", 1 << 40},
+		{"kb", 1 << 10}, {"mb", 1 << 20}, {"gb", 1 << 30}, {"tb", 1 << 40},
+		{"k", 1e3}, {"m", 1e6}, {"g", 1e9}, {"t", 1e12},
+		{"b", 1},
+	} {
+		if strings.HasSuffix(lower, u.suffix) {
+			s, mult = strings.TrimSpace(s[:len(s)-len(u.suffix)]), u.mult
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v * mult, true
+}
+
+// Notification is one entry in the notification center.
+type Notification struct {
+	ID         int
+	Rule       string
+	Severity   string
+	Tile       string
+	Message    string
+	FiredAt    time.Time
+	ResolvedAt time.Time // zero while firing
+	Acked      bool
+}
+
+func (n *Notification) firing() bool { return n.ResolvedAt.IsZero() }
+
+// AlertEvent is what hooks receive when an alert fires or resolves.
+type AlertEvent struct {
+	State    string    `json:"state"` // firing or resolved
+	Rule     string    `json:"rule"`
+	Severity string    `json:"severity"`
+	Tile     string    `json:"tile"`
+	Message  string    `json:"message"`
+	At       time.Time `json:"at"`
+}
+
+type alertKey struct {
+	rule, tile string
+}
+
+type alertState struct {
+	pendingSince time.Time
+	note         *Notification // set while firing
+}
+
+// alertLevel is how a tile is highlighted.
+type alertLevel int
+
+const (
+	alertNone alertLevel = iota
+	alertAcked
+	alertWarning
+	alertCritical
+)
+
+// AlertCenter evaluates alert rules and keeps the notification history for
+// the session.
+type AlertCenter struct {
+	mu       sync.Mutex
+	config   AlertConfig
+	states   map[alertKey]*alertState
+	notes    []*Notification // newest first
+	silenced map[string]time.Time
+	nextID   int
+	deliver  func(AlertNotify, AlertEvent)
+	quiet    bool // a daemon runs the hooks
+}
+
+func NewAlertCenter() *AlertCenter {
+	return &AlertCenter{
+		states:   map[alertKey]*alertState{},
+		silenced: map[string]time.Time{},
+		deliver:  deliverAlert,
+	}
+}
+
+// SetConfig swaps the rules; alerts of rules that no longer exist resolve
+// silently.
+func (c *AlertCenter) SetConfig(cfg *AlertConfig) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config = AlertConfig{}
+	if cfg != nil {
+		c.config = *cfg
+	}
+	names := map[string]bool{}
+	for _, r := range c.config.Rules {
+		names[r.Name] = true
+	}
+	now := time.Now()
+	for key, st := range c.states {
+		if !names[key.rule] {
+			if st.note != nil {
+				st.note.ResolvedAt = now
+			}
+			delete(c.states, key)
+		}
+	}
+}
+
+// SetQuiet keeps alerts in the notification center without ringing the
+// bell or running hooks, for a TUI attached to a daemon that does.
+func (c *AlertCenter) SetQuiet(quiet bool) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.quiet = quiet
+	c.mu.Unlock()
+}
+
+// watches reports whether any rule applies to tile.
+func (c *AlertCenter) watches(tile DashboardTile) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range c.config.Rules {
+		if r.matches(tile) {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate runs the rules for one tile result, records fired and resolved
+// alerts and hands them to the notify hooks.
+func (c *AlertCenter) Evaluate(tile DashboardTile, view pluginrpc.ViewData, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	var events []AlertEvent
+	label := tileLabel(tile)
+	for _, r := range c.config.Rules {
+		if !r.matches(tile) {
+			continue
+		}
+		key := alertKey{r.Name, tileSessionKey(tile.Plugin, tile.Target, tile.View)}
+		st := c.states[key]
+		if st == nil {
+			st = &alertState{}
+			c.states[key] = st
+		}
+		hit, detail, found := r.check(view)
+		silenced := c.silencedLocked(r.Name, now)
+		switch {
+		case !found:
+		case hit && st.note != nil:
+			st.note.Message = detail
+		case hit:
+			if st.pendingSince.IsZero() {
+				st.pendingSince = now
+			}
+			if now.Sub(st.pendingSince) < r.hold {
+				break
+			}
+			c.nextID++
+			st.note = &Notification{
+				ID: c.nextID, Rule: r.Name, Severity: r.Severity, Tile: label,
+				Message: detail, FiredAt: now, Acked: silenced,
+			}
+			c.notes = append([]*Notification{st.note}, c.notes...)
+			if len(c.notes) > maxNotifications {
+				c.notes = c.notes[:maxNotifications]
+			}
+			if !silenced {
+				events = append(events, alertEvent("firing", st.note, now))
+			}
+		default:
+			st.pendingSince = time.Time{}
+			if st.note != nil {
+				st.note.ResolvedAt = now
+				if !silenced {
+					events = append(events, alertEvent("resolved", st.note, now))
+				}
+				st.note = nil
+			}
+		}
+	}
+	notify, deliver := c.config.Notify, c.deliver
+	if c.quiet {
+		deliver = nil
+	}
+	c.mu.Unlock()
+
+	for _, ev := range events {
+		pluginrpc.RPCLog("alert %s: %s on %s: %s", ev.State, ev.Rule, ev.Tile, ev.Message)
+		if deliver != nil {
+			go deliver(notify, ev)
+		}
+	}
+}
+
+// TileLevel is the highlight for tile: the worst firing, unsilenced alert
+// of the rules watching it.
+func (c *AlertCenter) TileLevel(tile DashboardTile) alertLevel {
+	if c == nil {
+		return alertNone
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	tileKey := tileSessionKey(tile.Plugin, tile.Target, tile.View)
+	level := alertNone
+	for _, r := range c.config.Rules {
+		st := c.states[alertKey{r.Name, tileKey}]
+		if !r.matches(tile) || st == nil || st.note == nil || c.silencedLocked(r.Name, now) {
+			continue
+		}
+		l := alertWarning
+		if st.note.Acked {
+			l = alertAcked
+		} else if st.note.Severity == severityCritical {
+			l = alertCritical
+		}
+		if l > level {
+			level = l
+		}
+	}
+	return level
+}
+
+func alertEvent(state string, n *Notification, at time.Time) AlertEvent {
+	return AlertEvent{State: state, Rule: n.Rule, Severity: n.Severity, Tile: n.Tile, Message: n.Message, At: at}
+}
+
+func (c *AlertCenter) silencedLocked(rule string, now time.Time) bool {
+	until, ok := c.silenced[rule]
+	if ok && !now.Before(until) {
+		delete(c.silenced, rule)
+		return false
+	}
+	return ok
+}
+
+// Notifications returns a copy of the history, newest first.
+func (c *AlertCenter) Notifications() []Notification {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]Notification, len(c.notes))
+	for i, n := range c.notes {
+		out[i] = *n
+	}
+	return out
+}
+
+// Firing counts unacknowledged firing alerts.
+func (c *AlertCenter) Firing() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	count := 0
+	for _, n := range c.notes {
+		if n.firing() && !n.Acked {
+			count++
+		}
+	}
+	return count
+}
+
+// Acknowledge marks notification id (0: every firing one) as seen; the tile
+// keeps a muted highlight until the alert resolves.
+func (c *AlertCenter) Acknowledge(id int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, n := range c.notes {
+		if id == 0 || n.ID == id {
+			n.Acked = true
+		}
+	}
+}
+
+// Silence suppresses hooks and highlighting for rule until the duration
+// passes; zero lifts an earlier silence.
+func (c *AlertCenter) Silence(rule string, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d <= 0 {
+		delete(c.silenced, rule)
+		return
+	}
+	c.silenced[rule] = time.Now().Add(d)
+}
+
+// SilencedUntil returns when rule's silence ends, zero if it is not silenced.
+func (c *AlertCenter) SilencedUntil(rule string) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.silencedLocked(rule, time.Now()) {
+		return c.silenced[rule]
+	}
+	return time.Time{}
+}
+
+// Dismiss drops notification id, or every resolved one when id is 0.
+func (c *AlertCenter) Dismiss(id int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := c.notes[:0]
+	for _, n := range c.notes {
+		if (id == 0 && !n.firing()) || n.ID == id {
+			continue
+		}
+		out = append(out, n)
+	}
+	c.notes = out
+}
+
+// Rules lists the configured rule names, sorted.
+func (c *AlertCenter) Rules() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	names := make([]string, 0, len(c.config.Rules))
+	for _, r := range c.config.Rules {
+		names = append(names, r.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// deliverAlert rings the bell and runs the configured hooks. Hook failures
+// only reach the log: the notification center already has the alert.
+func deliverAlert(notify AlertNotify, ev AlertEvent) {
+	if notify.Bell == nil || *notify.Bell {
+		ringBell()
+	}
+	if cmd := strings.TrimSpace(notify.Command); cmd != "" {
+		if err := runAlertCommand(cmd, ev); err != nil {
+			pluginrpc.RPCLog("alert command: %v", err)
+		}
+	}
+	if url := strings.TrimSpace(notify.Webhook); url != "" {
+		if err := postAlertWebhook(url, ev); err != nil {
+			pluginrpc.RPCLog("alert webhook: %v", err)
+		}
+	}
+}
+
+// ringBell writes BEL straight to the terminal; tcell owns stdout.
+func ringBell() {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return
+	}
+	defer tty.Close()
+	_, _ = tty.WriteString("\a")
+}
+
+func runAlertCommand(command string, ev AlertEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), alertHookTimeout)
+	defer cancel()
+	payload, _ := json.Marshal(ev)
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = append(os.Environ(),
+		"OMO_ALERT_STATE="+ev.State,
+		"OMO_ALERT_NAME="+ev.Rule,
+		"OMO_ALERT_SEVERITY="+ev.Severity,
+		"OMO_ALERT_TILE="+ev.Tile,
+		"OMO_ALERT_MESSAGE="+ev.Message,
+	)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.WaitDelay = 500 * time.Millisecond
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if line := lastLine(string(out)); line != "" {
+			return fmt.Errorf("%w: %s", err, line)
+		}
+		return err
+	}
+	return nil
+}
+
+func postAlertWebhook(url string, ev AlertEvent) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), alertHookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package host
+
+import (
+	"testing"
+	"time"
+
+	"omo/pkg/pluginrpc"
+)
+
+func TestParseAlertConfigDefaults(t *testing.T) {
+	cfg, err := parseAlertConfig([]byte(`
+rules:
+  - plugin: kafka
+    metric: Lag
+    value: 10k
+    for: 5m
+  - plugin: argocd
+    view: apps
+    row: "*"
+    value: OutOfSync
+`))
+	if err != nil {
+		t.Fatalf("parseAlertConfig: %v", err)
+	}
+	lag, sync := cfg.Rules[0], cfg.Rules[1]
+	if lag.Op != ">" || lag.hold != 5*time.Minute || lag.Severity != severityWarning || lag.Name != "kafka Lag > 10k" {
+		t.Fatalf("lag rule = %+v", lag)
+	}
+	if sync.Op != "contains" || sync.Name != "argocd * contains OutOfSync" {
+		t.Fatalf("sync rule = %+v", sync)
+	}
+
+	for _, bad := range []string{
+		"rules: [{metric: Lag, op: '>', value: 1}]",
+		"rules: [{plugin: kafka, op: '~~', value: 1}]",
+		"rules: [{plugin: kafka, op: '>', value: lots}]",
+		"rules: [{plugin: kafka, for: soon}]",
+	} {
+		if _, err := parseAlertConfig([]byte(bad)); err == nil {
+			t.Fatalf("parseAlertConfig(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestCompareAlert(t *testing.T) {
+	for _, tc := range []struct {
+		op, actual, want string
+		hit              bool
+	}{
+		{">", "12000", "10k", true},
+		{">", "9000", "10k", false},
+		{"<=", "512MiB", "1GB", true},
+		{">=", "85%", "80", true},
+		{"==", "Synced", "synced", true},
+		{"!=", "1000", "1k", false},
+		{"contains", "[red]OutOfSync[-] · Healthy", "outofsync", true},
+		{"!contains", "Synced · Healthy", "OutOfSync", true},
+		{">", "n/a", "1", false},
+	} {
+		if got := compareAlert(tc.op, tc.actual, tc.want); got != tc.hit {
+			t.Fatalf("compareAlert(%q, %q, %q) = %v, want %v", tc.op, tc.actual, tc.want, got, tc.hit)
+		}
+	}
+}
+
+func TestAlertCenterFiresAfterHoldAndResolves(t *testing.T) {
+	cfg, err := parseAlertConfig([]byte(`
+rules:
+  - name: lag
+    plugin: kafka
+    target: kafka/prod
+    metric: Lag
+    value: 10k
+    for: 5m
+    severity: critical
+`))
+	if err != nil {
+		t.Fatalf("parseAlertConfig: %v", err)
+	}
+	events := make(chan AlertEvent, 4)
+	c := NewAlertCenter()
+	c.deliver = func(_ AlertNotify, ev AlertEvent) { events <- ev }
+	c.SetConfig(cfg)
+
+	prod := DashboardTile{Plugin: "kafka", Target: "kafka/prod"}
+	lag := func(v float64) pluginrpc.ViewData {
+		return pluginrpc.ViewData{Metrics: []pluginrpc.Metric{{Name: "Lag", Value: v}}}
+	}
+	start := time.Unix(1700000000, 0)
+
+	if !c.watches(prod) || c.watches(DashboardTile{Plugin: "kafka", Target: "kafka/dev"}) {
+		t.Fatal("rule should watch only the prod target")
+	}
+	c.Evaluate(prod, lag(20000), start)
+	c.Evaluate(prod, lag(20000), start.Add(4*time.Minute))
+	if c.Firing() != 0 || c.TileLevel(prod) != alertNone {
+		t.Fatal("alert fired before its hold time")
+	}
+	// A widget without the metric (plugin unreachable) keeps the pending state.
+	c.Evaluate(prod, pluginrpc.ViewData{Status: "error"}, start.Add(4*time.Minute+30*time.Second))
+	c.Evaluate(prod, lag(20000), start.Add(5*time.Minute))
+	if c.Firing() != 1 || c.TileLevel(prod) != alertCritical {
+		t.Fatalf("Firing = %d, level = %v after hold", c.Firing(), c.TileLevel(prod))
+	}
+	if ev := <-events; ev.State != "firing" || ev.Rule != "lag" || ev.Message != "Lag 20.0k > 10k" {
+		t.Fatalf("event = %+v", ev)
+	}
+
+	c.Acknowledge(0)
+	if c.Firing() != 0 || c.TileLevel(prod) != alertAcked {
+		t.Fatalf("after ack Firing = %d, level = %v", c.Firing(), c.TileLevel(prod))
+	}
+
+	c.Evaluate(prod, lag(10), start.Add(6*time.Minute))
+	if ev := <-events; ev.State != "resolved" {
+		t.Fatalf("event = %+v, want resolved", ev)
+	}
+	notes := c.Notifications()
+	if len(notes) != 1 || notes[0].firing() || c.TileLevel(prod) != alertNone {
+		t.Fatalf("notifications after resolve = %+v", notes)
+	}
+	c.Dismiss(0)
+	if len(c.Notifications()) != 0 {
+		t.Fatal("Dismiss(0) kept a resolved notification")
+	}
+}
+
+func TestAlertCenterSilence(t *testing.T) {
+	cfg, err := parseAlertConfig([]byte(`
+rules:
+  - name: down
+    plugin: redis
+    op: contains
+    value: error
+`))
+	if err != nil {
+		t.Fatalf("parseAlertConfig: %v", err)
+	}
+	delivered := 0
+	c := NewAlertCenter()
+	c.deliver = func(AlertNotify, AlertEvent) { delivered++ }
+	c.SetConfig(cfg)
+	c.Silence("down", time.Hour)
+
+	tile := DashboardTile{Plugin: "redis"}
+	c.Evaluate(tile, pluginrpc.ViewData{Status: "error"}, time.Now())
+	if delivered != 0 || c.Firing() != 0 || c.TileLevel(tile) != alertNone {
+		t.Fatalf("silenced rule delivered=%d firing=%d level=%v", delivered, c.Firing(), c.TileLevel(tile))
+	}
+	if len(c.Notifications()) != 1 {
+		t.Fatal("silenced alert missing from the notification center")
+	}
+	c.Silence("down", 0)
+	if !c.SilencedUntil("down").IsZero() {
+		t.Fatal("Silence(0) did not lift the silence")
+	}
+}
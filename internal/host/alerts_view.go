@@ -0,0 +1,139 @@
+package host
+
+import (
+	"fmt"
+	"time"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/ui"
+)
+
+var silenceChoices = []struct {
+	label string
+	d     time.Duration
+}{
+	{"15 minutes", 15 * time.Minute},
+	{"1 hour", time.Hour},
+	{"4 hours", 4 * time.Hour},
+	{"24 hours", 24 * time.Hour},
+}
+
+// showNotifications opens the notification center: firing alerts first,
+// then the resolved history. Picking one offers acknowledge, silence and
+// dismiss.
+func (d *Dashboard) showNotifications() {
+	d.showModal(func() {
+		if d.alerts == nil {
+			return
+		}
+		notes := d.alerts.Notifications()
+		type choice struct {
+			label, detail string
+			run           func()
+		}
+		var choices []choice
+		if d.alerts.Firing() > 0 {
+			choices = append(choices, choice{"✓ Acknowledge all", "", func() { d.alerts.Acknowledge(0) }})
+		}
+		if len(notes) > d.alerts.Firing() {
+			choices = append(choices, choice{"− Clear resolved", "", func() { d.alerts.Dismiss(0) }})
+		}
+		now := time.Now()
+		for _, n := range notes {
+			choices = append(choices, choice{notificationLabel(n), notificationDetail(n, now), func() {
+				d.showNotificationActions(n)
+			}})
+		}
+		if len(choices) == 0 {
+			msg := "No alerts have fired this session."
+			if len(d.alerts.Rules()) == 0 {
+				msg = "No alert rules. Add them to " + pluginapi.AlertsPath() + "."
+			}
+			ui.ShowInfoModal(d.pages, d.app, "Notifications", msg, d.Focus)
+			return
+		}
+
+		items := make([][]string, len(choices))
+		for i, c := range choices {
+			items[i] = []string{c.label, c.detail}
+		}
+		title := fmt.Sprintf("Notifications (%d firing)", d.alerts.Firing())
+		ui.ShowStandardListSelectorModal(d.pages, d.app, title, items, func(index int, _ string, cancelled bool) {
+			d.Focus()
+			if cancelled || index < 0 || index >= len(choices) {
+				return
+			}
+			choices[index].run()
+			d.repaintAlerts()
+		})
+	})
+}
+
+func (d *Dashboard) showNotificationActions(n Notification) {
+	type choice struct {
+		label string
+		run   func()
+	}
+	var choices []choice
+	if n.firing() && !n.Acked {
+		choices = append(choices, choice{"Acknowledge", func() { d.alerts.Acknowledge(n.ID) }})
+	}
+	if until := d.alerts.SilencedUntil(n.Rule); !until.IsZero() {
+		choices = append(choices, choice{"Unsilence (silenced until " + until.Format("15:04") + ")", func() {
+			d.alerts.Silence(n.Rule, 0)
+		}})
+	}
+	for _, s := range silenceChoices {
+		dur := s.d
+		choices = append(choices, choice{"Silence for " + s.label, func() { d.alerts.Silence(n.Rule, dur) }})
+	}
+	choices = append(choices, choice{"Dismiss", func() { d.alerts.Dismiss(n.ID) }})
+
+	items := make([][]string, len(choices))
+	for i, c := range choices {
+		items[i] = []string{c.label, ""}
+	}
+	ui.ShowStandardListSelectorModal(d.pages, d.app, n.Rule, items, func(index int, _ string, cancelled bool) {
+		d.Focus()
+		if cancelled || index < 0 || index >= len(choices) {
+			return
+		}
+		choices[index].run()
+		d.repaintAlerts()
+	})
+}
+
+func notificationLabel(n Notification) string {
+	mark := "[gray]○[-]"
+	switch {
+	case !n.firing():
+	case n.Acked:
+		mark = "[yellow]◐[-]"
+	case n.Severity == severityCritical:
+		mark = "[red]●[-]"
+	default:
+		mark = "[yellow]●[-]"
+	}
+	return mark + " " + n.Rule + " · " + n.Tile
+}
+
+func notificationDetail(n Notification, now time.Time) string {
+	if n.firing() {
+		return fmt.Sprintf("%s · firing %s", n.Message, since(n.FiredAt, now))
+	}
+	return fmt.Sprintf("%s · resolved %s ago", n.Message, since(n.ResolvedAt, now))
+}
+
+// since is a compact age such as 45s, 12m or 3h.
+func since(at, now time.Time) string {
+	d := now.Sub(at)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
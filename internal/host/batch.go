@@ -0,0 +1,113 @@
+package host
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"omo/pkg/pluginrpc"
+	"omo/pkg/ui"
+)
+
+// batchPreview is how many marked rows the confirmation lists by name.
+const batchPreview = 5
+
+// confirmBatch asks before running a batch action on every marked row.
+func (r *RPCRenderer) confirmBatch(action, label string) {
+	rows := r.core.MarkedRows()
+	names := make([]string, 0, batchPreview)
+	for _, row := range rows {
+		if len(names) == batchPreview {
+			names = append(names, fmt.Sprintf("… and %d more", len(rows)-batchPreview))
+			break
+		}
+		names = append(names, batchItemName(row))
+	}
+	body := fmt.Sprintf("%s %d marked rows?\n\n%s", label, len(rows), strings.Join(names, "\n"))
+	ui.ShowStandardConfirmationModal(r.pages, r.app, "Batch "+label, body, func(ok bool) {
+		r.FocusTable()
+		if ok {
+			r.runBatch(action, label, rows)
+		}
+	})
+}
+
+// runBatch calls the action once per row, one at a time, and lists each
+// outcome in a progress modal. Rows that succeed are unmarked; failures stay
+// marked so the batch can be retried. Cancel stops after the current row.
+// The table refreshes once the modal is closed.
+func (r *RPCRenderer) runBatch(action, label string, rows [][]string) {
+	if r.plugin == nil {
+		r.core.Log("[yellow]plugin still loading…")
+		return
+	}
+	plugin := r.plugin
+	viewID := r.currentView
+	var cancelled atomic.Bool
+	pm := ui.NewProgressModal(r.pages, r.app, fmt.Sprintf("%s · %d rows", label, len(rows)), len(rows))
+	pm.SetCancellable(true)
+	pm.SetAutoClose(false)
+	pm.SetOnCancel(func() {
+		cancelled.Store(true)
+		r.FocusTable()
+	})
+	pm.SetOnClose(r.refresh) // after the results are read; refresh refocuses the table
+	pm.Show()
+	pm.Focus()
+	r.flashMood("pending", true, action, "")
+
+	go func() {
+		var done [][]string
+		failed := 0
+		for i, row := range rows {
+			if cancelled.Load() {
+				break
+			}
+			name := batchItemName(row)
+			pm.UpdateProgress(i, fmt.Sprintf("%d/%d · %s", i+1, len(rows), name))
+			result, err := plugin.DoAction(pluginrpc.ActionRequest{
+				Action:  action,
+				View:    viewID,
+				Payload: rowPayload(row),
+			})
+			switch {
+			case err != nil:
+				failed++
+				pm.AddResult(name, false, err.Error())
+			case !result.OK:
+				failed++
+				pm.AddResult(name, false, result.Message)
+			default:
+				done = append(done, row)
+				pm.AddResult(name, true, result.Message)
+			}
+		}
+		summary := fmt.Sprintf("[green]%d ok[white]", len(done))
+		if failed > 0 {
+			summary += fmt.Sprintf(" · [red]%d failed[white]", failed)
+		}
+		if skipped := len(rows) - len(done) - failed; skipped > 0 {
+			summary += fmt.Sprintf(" · [yellow]%d cancelled[white]", skipped)
+		}
+		pm.Finish(summary)
+		r.app.QueueUpdateDraw(func() {
+			r.core.UnmarkRows(done)
+			phase := "ok"
+			if failed > 0 {
+				phase = "fail"
+			}
+			r.flashMood(phase, failed == 0, action, "")
+			if cancelled.Load() {
+				r.refresh() // the modal is already gone
+			}
+		})
+	}()
+}
+
+// batchItemName labels a row in batch confirmations and results.
+func batchItemName(row []string) string {
+	if len(row) == 0 {
+		return "(empty row)"
+	}
+	return row[0]
+}
@@ -0,0 +1,58 @@
+package host
+
+import (
+	"testing"
+
+	"omo/pkg/pluginrpc"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestRendererMarksRowsForBatch(t *testing.T) {
+	app := tview.NewApplication()
+	renderer := NewRPCRenderer(app, tview.NewPages(), "test", nil)
+	home := false
+	renderer.SetHomeHook(func() { home = true })
+	renderer.Apply(pluginrpc.ViewData{
+		View:         renderer.homeView,
+		Headers:      []string{"Name", "State"},
+		Rows:         [][]string{{"a", "up"}, {"b", "up"}, {"c", "down"}},
+		SelectionKey: "Name",
+		Actions:      []pluginrpc.KeyBinding{{Key: "X", Label: "Stop", Action: "stop", Batch: true}},
+	})
+	if renderer.batch["stop"] != "Stop" {
+		t.Fatalf("batch actions = %v", renderer.batch)
+	}
+	press := func(ev *tcell.EventKey) {
+		renderer.root.InputHandler()(ev, func(tview.Primitive) {})
+	}
+
+	press(tcell.NewEventKey(tcell.KeyRune, ' ', 0)) // marks a, moves to b
+	press(tcell.NewEventKey(tcell.KeyRune, ' ', 0)) // marks b
+	if n := renderer.core.MarkCount(); n != 2 {
+		t.Fatalf("MarkCount = %d after two spaces", n)
+	}
+	marked := pluginrpc.MarkedRows(renderer.selectionPayload())
+	if len(marked) != 2 || marked[0]["key"] != "a" || marked[1]["col1"] != "up" {
+		t.Fatalf("marked payload = %v", marked)
+	}
+
+	// Marks follow the row, not its position.
+	renderer.core.SetTableData([][]string{{"c", "down"}, {"b", "up"}})
+	if rows := renderer.core.MarkedRows(); len(rows) != 1 || rows[0][0] != "b" {
+		t.Fatalf("marks after refresh = %v, want b only", rows)
+	}
+
+	press(tcell.NewEventKey(tcell.KeyRune, '*', 0))
+	if n := renderer.core.MarkCount(); n != 2 {
+		t.Fatalf("MarkCount = %d after mark all", n)
+	}
+	press(tcell.NewEventKey(tcell.KeyEscape, 0, 0))
+	if renderer.core.MarkCount() != 0 || home {
+		t.Fatalf("first Escape: marks = %d, home = %v; want marks cleared only", renderer.core.MarkCount(), home)
+	}
+	if _, ok := renderer.selectionPayload()[pluginrpc.PayloadMarked]; ok {
+		t.Fatal("payload still carries marked rows")
+	}
+}
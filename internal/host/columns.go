@@ -0,0 +1,95 @@
+package host
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/ui"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ColumnPrefs is ~/.omo/columns.yaml, the table layout of each plugin view.
+// The column editor (c) and sorting (o, or a header click) write it. Columns
+// are named by header, matched regardless of case:
+//
+//	redis:
+//	  keys:
+//	    hidden: [Type]
+//	    order: [Key, TTL]     # shown first; other columns follow
+//	    pin_first: true       # first column stays put when scrolling right
+//	    max_width:
+//	      Key: 40
+//	    sort_by: Size
+//	    sort_type: size       # auto, alphabet, number, size, duration, date
+//	    sort_desc: true
+type ColumnPrefs map[string]map[string]ui.TableLayout
+
+// LoadColumnPrefs reads ~/.omo/columns.yaml. A missing file returns empty
+// prefs and no error.
+func LoadColumnPrefs() (ColumnPrefs, error) {
+	prefs := ColumnPrefs{}
+	data, err := os.ReadFile(pluginapi.ColumnsPath())
+	if os.IsNotExist(err) {
+		return prefs, nil
+	}
+	if err != nil {
+		return prefs, err
+	}
+	if err := yaml.Unmarshal(data, &prefs); err != nil {
+		return ColumnPrefs{}, fmt.Errorf("parse %s: %w", pluginapi.ColumnsPath(), err)
+	}
+	if prefs == nil {
+		prefs = ColumnPrefs{}
+	}
+	return prefs, nil
+}
+
+// SaveColumnPrefs writes prefs to ~/.omo/columns.yaml.
+func SaveColumnPrefs(prefs ColumnPrefs) error {
+	data, err := yaml.Marshal(prefs)
+	if err != nil {
+		return err
+	}
+	path := pluginapi.ColumnsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Layout returns the saved layout of a plugin view (zero when none).
+func (p ColumnPrefs) Layout(plugin, view string) ui.TableLayout {
+	return p[plugin][view]
+}
+
+// SetLayout stores the layout of a plugin view; the default layout removes
+// the entry.
+func (p ColumnPrefs) SetLayout(plugin, view string, l ui.TableLayout) {
+	if l.IsZero() {
+		delete(p[plugin], view)
+		if len(p[plugin]) == 0 {
+			delete(p, plugin)
+		}
+		return
+	}
+	if p[plugin] == nil {
+		p[plugin] = map[string]ui.TableLayout{}
+	}
+	p[plugin][view] = l
+}
+
+// loadColumnPrefs reads the column layouts. A broken file is logged and
+// yields nil, so layout edits stay in memory instead of overwriting it.
+func loadColumnPrefs(logFn func(string, ...interface{})) ColumnPrefs {
+	prefs, err := LoadColumnPrefs()
+	if err != nil {
+		if logFn != nil {
+			logFn("columns: %v (using plugin layouts, not saving)", err)
+		}
+		return nil
+	}
+	return prefs
+}
@@ -0,0 +1,57 @@
+package host
+
+import (
+	"reflect"
+	"testing"
+
+	"omo/pkg/pluginrpc"
+	"omo/pkg/ui"
+
+	"github.com/rivo/tview"
+	"gopkg.in/yaml.v3"
+)
+
+func TestColumnPrefsRoundTrip(t *testing.T) {
+	prefs := ColumnPrefs{}
+	l := ui.TableLayout{Hidden: []string{"Encoding"}, PinFirst: true, MaxWidth: map[string]int{"Key": 40}, SortBy: "Size", SortDesc: true}
+	prefs.SetLayout("redis", "keys", l)
+
+	data, err := yaml.Marshal(prefs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back ColumnPrefs
+	if err := yaml.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if got := back.Layout("redis", "keys"); !reflect.DeepEqual(got, l) {
+		t.Fatalf("layout = %+v, want %+v\n%s", got, l, data)
+	}
+
+	prefs.SetLayout("redis", "keys", ui.TableLayout{})
+	if len(prefs) != 0 {
+		t.Fatalf("default layout kept an entry: %v", prefs)
+	}
+}
+
+func TestRendererAppliesSavedLayout(t *testing.T) {
+	renderer := NewRPCRenderer(tview.NewApplication(), tview.NewPages(), "test", nil)
+	renderer.SetColumnPrefs(ColumnPrefs{"test": {"pods": {SortBy: "Age", Hidden: []string{"Node"}}}})
+	renderer.Apply(pluginrpc.ViewData{
+		View:    "pods",
+		Headers: []string{"Name", "Node", "Age"},
+		Rows:    [][]string{{"a", "n1", "3d"}, {"b", "n2", "5m"}, {"c", "n1", "2h"}},
+	})
+	headers, rows := renderer.core.GetShownTable()
+	if !reflect.DeepEqual(headers, []string{"Name", "Age"}) {
+		t.Fatalf("shown headers = %v", headers)
+	}
+	if got := []string{rows[0][0], rows[1][0], rows[2][0]}; !reflect.DeepEqual(got, []string{"b", "c", "a"}) {
+		t.Fatalf("sorted by age = %v, want [b c a]", got)
+	}
+
+	renderer.Apply(pluginrpc.ViewData{View: "nodes", Headers: []string{"Name"}, Rows: [][]string{{"z"}, {"y"}}})
+	if _, rows := renderer.core.GetShownTable(); rows[0][0] != "z" {
+		t.Fatalf("nodes view kept the pods sort: %v", rows)
+	}
+}
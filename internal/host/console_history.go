@@ -0,0 +1,89 @@
+package host
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"omo/pkg/pluginapi"
+
+	"gopkg.in/yaml.v3"
+)
+
+// consoleHistoryMax caps the commands kept per target.
+const consoleHistoryMax = 500
+
+// ConsoleHistory is ~/.omo/console_history.yaml, the commands typed into
+// plugin consoles (Redis, …) keyed by KeePass target, oldest first:
+//
+//	redis/production/cache:
+//	  - INFO keyspace
+//	  - GET session:42
+type ConsoleHistory map[string][]string
+
+// sensitiveCommands carry passwords and are never written to history.
+var sensitiveCommands = []string{"auth", "hello", "acl setuser", "migrate", "config set requirepass", "config set masterauth"}
+
+// LoadConsoleHistory reads ~/.omo/console_history.yaml. A missing file
+// returns empty history and no error.
+func LoadConsoleHistory() (ConsoleHistory, error) {
+	history := ConsoleHistory{}
+	data, err := os.ReadFile(pluginapi.ConsoleHistoryPath())
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return history, err
+	}
+	if err := yaml.Unmarshal(data, &history); err != nil {
+		return ConsoleHistory{}, fmt.Errorf("parse %s: %w", pluginapi.ConsoleHistoryPath(), err)
+	}
+	if history == nil {
+		history = ConsoleHistory{}
+	}
+	return history, nil
+}
+
+// SaveConsoleHistory writes history to ~/.omo/console_history.yaml, readable
+// by the user only.
+func SaveConsoleHistory(history ConsoleHistory) error {
+	data, err := yaml.Marshal(history)
+	if err != nil {
+		return err
+	}
+	path := pluginapi.ConsoleHistoryPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Add appends line to the target's history. Repeats of the last command and
+// commands carrying passwords are skipped; it reports whether history changed.
+func (h ConsoleHistory) Add(target, line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" || sensitiveCommand(line) {
+		return false
+	}
+	items := h[target]
+	if n := len(items); n > 0 && items[n-1] == line {
+		return false
+	}
+	items = append(items, line)
+	if len(items) > consoleHistoryMax {
+		items = items[len(items)-consoleHistoryMax:]
+	}
+	h[target] = items
+	return true
+}
+
+func sensitiveCommand(line string) bool {
+	words := strings.ToLower(strings.Join(strings.Fields(line), " "))
+	for _, cmd := range sensitiveCommands {
+		if words == cmd || strings.HasPrefix(words, cmd+" ") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package host
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConsoleHistoryAdd(t *testing.T) {
+	h := ConsoleHistory{}
+	for _, line := range []string{"GET a", "GET a", " auth secret", "ACL  SETUSER bob on >pw", "KEYS *"} {
+		h.Add("redis/prod/cache", line)
+	}
+	want := []string{"GET a", "KEYS *"}
+	if got := h["redis/prod/cache"]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("history = %q, want %q", got, want)
+	}
+	for i := 0; i < consoleHistoryMax+5; i++ {
+		h.Add("t", string(rune('a'+i%26))+"x")
+	}
+	if n := len(h["t"]); n != consoleHistoryMax {
+		t.Fatalf("len = %d, want %d", n, consoleHistoryMax)
+	}
+}
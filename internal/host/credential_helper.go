@@ -0,0 +1,364 @@
+package host
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/pluginrpc"
+)
+
+// Entries with short-lived credentials (AWS SSO, gcloud access tokens, Vault
+// dynamic database users) name a command instead of storing a password:
+//
+//	credential_helper         = vault read -format=json database/creds/app
+//	credential_helper_timeout = 20s
+//
+// The host runs the command through sh when the plugin is configured. Its
+// stdout is JSON with any of username, password, token, expires_at and an
+// attributes object; AWS credential_process output and Vault lease JSON are
+// understood too, and plain text is taken as a token. The result is cached
+// per entry until it expires.
+const (
+	credentialHelperAttr        = pluginapi.CredentialHelperAttr
+	credentialHelperTimeoutAttr = pluginapi.CredentialHelperTimeoutAttr
+
+	defaultCredentialHelperTimeout = 10 * time.Second
+	// credentialRefreshMargin renews a cached credential before it lapses
+	// so a plugin is not configured with one that dies mid-request.
+	credentialRefreshMargin = 30 * time.Second
+)
+
+// helperCredential is the parsed output of a credential helper.
+type helperCredential struct {
+	UserName   string
+	Password   string
+	Token      string
+	Attributes map[string]string
+	ExpiresAt  time.Time
+}
+
+type cachedCredential struct {
+	command string
+	cred    helperCredential
+}
+
+var (
+	credentialCacheMu sync.Mutex
+	credentialCache   = map[string]cachedCredential{}
+)
+
+// configureSettings is resolveEntrySettings plus the entry's credential
+// helper, if any. Only targets that are actually configured pay for it; the
+// target picker lists entries with resolveEntrySettings alone.
+func configureSettings(path string, entry *pluginapi.SecretEntry) (map[string]string, error) {
+	settings, err := resolveEntrySettings(path, entry)
+	if err != nil {
+		return nil, err
+	}
+	command := strings.TrimSpace(settings[credentialHelperAttr])
+	if command == "" {
+		return settings, nil
+	}
+	timeout := defaultCredentialHelperTimeout
+	if v := strings.TrimSpace(settings[credentialHelperTimeoutAttr]); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("%s: invalid %s %q", path, credentialHelperTimeoutAttr, v)
+		}
+		timeout = d
+	}
+	cred, err := helperCredentialFor(path, command, timeout)
+	if err != nil {
+		return nil, err
+	}
+	applyHelperCredential(settings, cred)
+	return settings, nil
+}
+
+// helperCredentialFor returns the cached credential for path or runs the
+// helper again when there is none, it is about to expire or the command
+// changed.
+func helperCredentialFor(path, command string, timeout time.Duration) (helperCredential, error) {
+	credentialCacheMu.Lock()
+	cached, ok := credentialCache[path]
+	credentialCacheMu.Unlock()
+	if ok && cached.command == command && !cached.cred.ExpiresAt.IsZero() &&
+		time.Until(cached.cred.ExpiresAt) > credentialRefreshMargin {
+		return cached.cred, nil
+	}
+
+	pluginrpc.RPCLog("credential helper %s: running (timeout %s)", path, timeout)
+	cred, err := runCredentialHelper(path, command, timeout)
+	if err != nil {
+		pluginrpc.RPCLog("credential helper %s: %v", path, err)
+		return helperCredential{}, err
+	}
+	credentialCacheMu.Lock()
+	if cred.ExpiresAt.IsZero() {
+		delete(credentialCache, path)
+	} else {
+		credentialCache[path] = cachedCredential{command: command, cred: cred}
+	}
+	credentialCacheMu.Unlock()
+	return cred, nil
+}
+
+// credentialHelperError carries the helper's stderr so the dashboard can
+// show it on the not-configured tile.
+type credentialHelperError struct {
+	Path   string
+	Err    error
+	Stderr string // last non-empty stderr line
+}
+
+func (e *credentialHelperError) Error() string {
+	msg := "credential helper for " + e.Path + ": " + e.Err.Error()
+	if e.Stderr != "" {
+		msg += ": " + e.Stderr
+	}
+	return msg
+}
+
+func (e *credentialHelperError) Unwrap() error { return e.Err }
+
+func runCredentialHelper(path, command string, timeout time.Duration) (helperCredential, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = append(os.Environ(), "OMO_TARGET="+path)
+	// Grandchildren may hold the output pipes after sh is killed.
+	cmd.WaitDelay = 500 * time.Millisecond
+	if home, err := os.UserHomeDir(); err == nil {
+		cmd.Dir = home
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	fail := func(err error) (helperCredential, error) {
+		return helperCredential{}, &credentialHelperError{Path: path, Err: err, Stderr: lastLine(stderr.String())}
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return fail(fmt.Errorf("timed out after %s", timeout))
+	}
+	if err != nil {
+		return fail(err)
+	}
+	cred, err := parseHelperOutput(stdout.Bytes(), time.Now())
+	if err != nil {
+		return fail(err)
+	}
+	return cred, nil
+}
+
+// lastLine keeps the end of helper stderr: login prompts and "token expired,
+// run aws sso login" hints are what the user needs to see.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// parseHelperOutput accepts omo's own JSON shape, AWS credential_process
+// output (AccessKeyId/SecretAccessKey/SessionToken/Expiration), Vault lease
+// JSON (data.username/password, lease_duration) and a bare token.
+func parseHelperOutput(out []byte, now time.Time) (helperCredential, error) {
+	text := strings.TrimSpace(string(out))
+	if text == "" {
+		return helperCredential{}, errors.New("no output")
+	}
+	if !strings.HasPrefix(text, "{") {
+		if strings.ContainsAny(text, "\n ") {
+			return helperCredential{}, errors.New("output is neither JSON nor a single token")
+		}
+		return helperCredential{Token: text}, nil
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(text), &raw); err != nil {
+		return helperCredential{}, fmt.Errorf("parse output: %w", err)
+	}
+	if data, ok := raw["data"].(map[string]any); ok {
+		for k, v := range data {
+			if _, exists := raw[k]; !exists {
+				raw[k] = v
+			}
+		}
+	}
+	str := func(keys ...string) string {
+		for _, k := range keys {
+			if v, ok := raw[k]; ok {
+				switch v := v.(type) {
+				case string:
+					return v
+				case float64:
+					return strconv.FormatFloat(v, 'f', -1, 64)
+				}
+			}
+		}
+		return ""
+	}
+
+	cred := helperCredential{
+		UserName: str("username", "user", "AccessKeyId"),
+		Password: str("password", "SecretAccessKey"),
+		Token:    str("token", "access_token"),
+	}
+	if attrs, ok := raw["attributes"].(map[string]any); ok {
+		cred.Attributes = map[string]string{}
+		for k, v := range attrs {
+			cred.Attributes[k] = fmt.Sprint(v)
+		}
+	}
+	// AWS session tokens accompany a key pair rather than replace it.
+	if v := str("SessionToken"); v != "" {
+		if cred.Attributes == nil {
+			cred.Attributes = map[string]string{}
+		}
+		cred.Attributes["session_token"] = v
+	}
+	if v := str("expires_at", "expiry", "expiration", "Expiration"); v != "" {
+		at, err := pluginapi.ParseExpiry(v)
+		if err != nil {
+			return helperCredential{}, err
+		}
+		cred.ExpiresAt = at
+	} else if v := str("lease_duration", "expires_in", "ttl"); v != "" {
+		secs, err := strconv.ParseFloat(v, 64)
+		if err != nil || secs < 0 {
+			return helperCredential{}, fmt.Errorf("invalid lease duration %q", v)
+		}
+		if secs > 0 {
+			cred.ExpiresAt = now.Add(time.Duration(secs) * time.Second)
+		}
+	}
+	if cred.UserName == "" && cred.Password == "" && cred.Token == "" && len(cred.Attributes) == 0 {
+		return helperCredential{}, errors.New("output has no username, password, token or attributes")
+	}
+	return cred, nil
+}
+
+// applyHelperCredential overlays a helper result on Configure settings. A
+// token doubles as the password when the helper returns no password, which
+// is what token-authenticated plugins read.
+func applyHelperCredential(settings map[string]string, cred helperCredential) {
+	delete(settings, credentialHelperAttr)
+	delete(settings, credentialHelperTimeoutAttr)
+	if cred.UserName != "" {
+		settings["username"] = cred.UserName
+	}
+	if cred.Password != "" {
+		settings["password"] = cred.Password
+	}
+	if cred.Token != "" {
+		settings["token"] = cred.Token
+		if cred.Password == "" {
+			settings["password"] = cred.Token
+		}
+	}
+	for k, v := range cred.Attributes {
+		settings[k] = v
+	}
+	if !cred.ExpiresAt.IsZero() {
+		settings[pluginapi.ExpiresAtAttr] = pluginapi.FormatExpiry(cred.ExpiresAt)
+	}
+}
+
+// cachedHelperExpiry is when the cached helper credential for path lapses;
+// false when path has no helper or its credential does not expire.
+func cachedHelperExpiry(path string) (time.Time, bool) {
+	credentialCacheMu.Lock()
+	defer credentialCacheMu.Unlock()
+	cached, ok := credentialCache[path]
+	if !ok || cached.cred.ExpiresAt.IsZero() {
+		return time.Time{}, false
+	}
+	return cached.cred.ExpiresAt, true
+}
+
+// markConfigured records that sess was configured with the entry at path,
+// including when a helper credential it was given runs out.
+func (m *PluginManager) markConfigured(sess *PluginSession, path string) {
+	expiry, _ := cachedHelperExpiry(path)
+	m.mu.Lock()
+	sess.Configured = true
+	sess.CredentialExpiry = expiry
+	m.mu.Unlock()
+	m.setTargetPath(sess, path)
+}
+
+// credentialDue reports whether sess holds a helper credential that is
+// about to expire and must be fetched again before the next call.
+func (m *PluginManager) credentialDue(sess *PluginSession) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return sess.Configured && !sess.CredentialExpiry.IsZero() &&
+		time.Until(sess.CredentialExpiry) < credentialRefreshMargin
+}
+
+// refreshCredentialLocked re-runs the helper and reconfigures sess when its
+// credential is due. Configure only happens on activation otherwise, so a
+// warm session, dashboard tile or daemon poll would keep an expired token.
+// The caller holds sess.pulseMu.
+func (m *PluginManager) refreshCredentialLocked(sess *PluginSession) error {
+	if !m.credentialDue(sess) {
+		return nil
+	}
+	m.mu.Lock()
+	path, plugin := sess.TargetPath, sess.Plugin
+	m.mu.Unlock()
+	pluginrpc.RPCLog("credential helper %s: refreshing %s before expiry", path, sess.Name)
+	settings, err := targetSettings(path)
+	if err == nil {
+		err = plugin.Configure(pluginrpc.ConfigureRequest{Settings: settings})
+	}
+	if err != nil {
+		m.mu.Lock()
+		sess.Configured = false
+		m.mu.Unlock()
+		return fmt.Errorf("refresh credential: %w", err)
+	}
+	m.markConfigured(sess, path)
+	return nil
+}
+
+// refreshingPlugin refreshes an expiring helper credential before the
+// renderer's GetView and DoAction calls.
+type refreshingPlugin struct {
+	pluginrpc.Plugin
+	m    *PluginManager
+	sess *PluginSession
+}
+
+func (p refreshingPlugin) refresh() error {
+	if !p.m.credentialDue(p.sess) {
+		return nil
+	}
+	p.sess.pulseMu.Lock()
+	defer p.sess.pulseMu.Unlock()
+	return p.m.refreshCredentialLocked(p.sess)
+}
+
+func (p refreshingPlugin) GetView(req pluginrpc.ViewRequest) (pluginrpc.ViewData, error) {
+	if err := p.refresh(); err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	return p.Plugin.GetView(req)
+}
+
+func (p refreshingPlugin) DoAction(req pluginrpc.ActionRequest) (pluginrpc.ActionResult, error) {
+	if err := p.refresh(); err != nil {
+		return pluginrpc.ActionResult{}, err
+	}
+	return p.Plugin.DoAction(req)
+}
@@ -0,0 +1,128 @@
+package host
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/pluginrpc"
+)
+
+func TestParseHelperOutputFormats(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	aws, err := parseHelperOutput([]byte(`{"Version":1,"AccessKeyId":"AKIA","SecretAccessKey":"sk","SessionToken":"st","Expiration":"2026-01-01T01:00:00Z"}`), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aws.UserName != "AKIA" || aws.Password != "sk" || aws.Attributes["session_token"] != "st" || !aws.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("aws = %+v", aws)
+	}
+
+	vault, err := parseHelperOutput([]byte(`{"lease_duration":3600,"data":{"username":"v-app","password":"pw"}}`), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vault.UserName != "v-app" || vault.Password != "pw" || !vault.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("vault = %+v", vault)
+	}
+
+	bare, err := parseHelperOutput([]byte("ya29.token\n"), now)
+	if err != nil || bare.Token != "ya29.token" || !bare.ExpiresAt.IsZero() {
+		t.Fatalf("bare = %+v, %v", bare, err)
+	}
+
+	if _, err := parseHelperOutput([]byte("not logged in, run login"), now); err == nil {
+		t.Fatal("want error for free text output")
+	}
+}
+
+func TestApplyHelperCredentialTokenFallsBackToPassword(t *testing.T) {
+	settings := map[string]string{credentialHelperAttr: "gcloud auth print-access-token", "host": "x"}
+	applyHelperCredential(settings, helperCredential{Token: "tok"})
+	if settings["password"] != "tok" || settings["token"] != "tok" {
+		t.Fatalf("settings = %v", settings)
+	}
+	if _, ok := settings[credentialHelperAttr]; ok {
+		t.Fatalf("helper command leaked into settings: %v", settings)
+	}
+}
+
+func TestRunCredentialHelperSurfacesStderr(t *testing.T) {
+	_, err := runCredentialHelper("aws/prod/main", "echo 'step one' >&2; echo 'SSO session expired' >&2; exit 3", time.Second)
+	var helperErr *credentialHelperError
+	if !errors.As(err, &helperErr) || helperErr.Stderr != "SSO session expired" {
+		t.Fatalf("err = %v, want stderr surfaced", err)
+	}
+
+	_, err = runCredentialHelper("aws/prod/main", "sleep 5", 50*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("err = %v, want timeout", err)
+	}
+}
+
+type memSecrets map[string]*pluginapi.SecretEntry
+
+func (s memSecrets) Get(path string) (*pluginapi.SecretEntry, error) {
+	if e, ok := s[path]; ok {
+		return e, nil
+	}
+	return nil, errors.New("not found")
+}
+func (s memSecrets) Put(path string, e *pluginapi.SecretEntry) error { s[path] = e; return nil }
+func (s memSecrets) Delete(path string) error                        { delete(s, path); return nil }
+func (s memSecrets) List(string) ([]string, error)                   { return nil, nil }
+func (s memSecrets) Reload() error                                   { return nil }
+func (s memSecrets) Close() error                                    { return nil }
+
+type configureCounter struct {
+	pluginrpc.Plugin
+	settings []map[string]string
+}
+
+func (p *configureCounter) Configure(req pluginrpc.ConfigureRequest) error {
+	p.settings = append(p.settings, req.Settings)
+	return nil
+}
+
+func (p *configureCounter) GetView(pluginrpc.ViewRequest) (pluginrpc.ViewData, error) {
+	return pluginrpc.ViewData{}, nil
+}
+
+func TestExpiredHelperCredentialReconfiguresSession(t *testing.T) {
+	const path = "redis/test/helper"
+	pluginapi.SetSecretsProvider(memSecrets{path: {
+		URL:              "localhost:6379",
+		CustomAttributes: map[string]string{credentialHelperAttr: `echo '{"token":"fresh","expires_in":3600}'`},
+	}})
+	defer pluginapi.SetSecretsProvider(nil)
+	credentialCacheMu.Lock()
+	delete(credentialCache, path)
+	credentialCacheMu.Unlock()
+
+	m := &PluginManager{sessions: map[string]*PluginSession{}}
+	fake := &configureCounter{}
+	sess := &PluginSession{Name: "redis", Plugin: fake, Configured: true, TargetPath: path,
+		CredentialExpiry: time.Now().Add(-time.Minute)}
+	m.sessions["redis"] = sess
+
+	wrapped := refreshingPlugin{Plugin: fake, m: m, sess: sess}
+	if _, err := wrapped.GetView(pluginrpc.ViewRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.settings) != 1 || fake.settings[0]["token"] != "fresh" {
+		t.Fatalf("Configure calls = %v, want one with the fresh token", fake.settings)
+	}
+	if time.Until(sess.CredentialExpiry) < 50*time.Minute {
+		t.Fatalf("CredentialExpiry = %v, want about an hour ahead", sess.CredentialExpiry)
+	}
+
+	if _, err := wrapped.GetView(pluginrpc.ViewRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.settings) != 1 {
+		t.Fatalf("Configure ran %d times, want no refresh while the credential is fresh", len(fake.settings))
+	}
+}
@@ -0,0 +1,178 @@
+package host
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/pluginrpc"
+	"omo/pkg/ui"
+)
+
+// setTargetPath records which KeePass entry a session is configured with so
+// issued credentials land next to it and dashboard tiles can show its expiry.
+func (m *PluginManager) setTargetPath(sess *PluginSession, path string) {
+	m.mu.Lock()
+	sess.TargetPath = path
+	renderer := sess.Renderer
+	m.mu.Unlock()
+	if renderer != nil {
+		renderer.SetTargetPath(path)
+	}
+}
+
+// TargetExpiry returns the credential expiry of the entry a plugin is (or
+// would be) configured with.
+func (m *PluginManager) TargetExpiry(name string) (time.Time, bool) {
+	return m.TileExpiry(name, "")
+}
+
+// TileExpiry is TargetExpiry for a dashboard tile; a non-empty target pins
+// the entry instead of using the plugin's current one.
+func (m *PluginManager) TileExpiry(name, target string) (time.Time, bool) {
+	if !pluginapi.HasSecrets() {
+		return time.Time{}, false
+	}
+	path := target
+	if path == "" && m != nil {
+		m.mu.Lock()
+		if sess := m.sessions[name]; sess != nil {
+			path = sess.TargetPath
+		}
+		m.mu.Unlock()
+	}
+	if path == "" {
+		p, _, err := resolvePluginTarget(name, false)
+		if err != nil {
+			return time.Time{}, false
+		}
+		path = p
+	}
+	entry, err := pluginapi.Secrets().Get(path)
+	if err != nil || entry == nil {
+		return time.Time{}, false
+	}
+	if resolved, err := pluginapi.ResolveSecretEntry(pluginapi.Secrets(), path, entry); err == nil {
+		entry = resolved
+	}
+	at, _, ok := pluginapi.CredentialExpiry(entry)
+	return at, ok
+}
+
+// SetTargetPath tells the renderer which KeePass entry is active.
+func (r *RPCRenderer) SetTargetPath(path string) {
+	r.targetPath = path
+}
+
+// credentialPath suggests plugin/<env of active target>/<name> for a newly
+// issued credential.
+func credentialPath(plugin, targetPath, name string) string {
+	env := "development"
+	if parts := strings.Split(targetPath, "/"); len(parts) == 3 && parts[1] != "" {
+		env = parts[1]
+	}
+	name = strings.NewReplacer("/", "-", " ", "-").Replace(strings.TrimSpace(name))
+	if name == "" {
+		name = "credential"
+	}
+	return plugin + "/" + env + "/" + name
+}
+
+// offerSaveCredential asks where to store a credential returned by an action.
+// The entry inherits the active target's URL and records expires_at.
+func (r *RPCRenderer) offerSaveCredential(cred pluginrpc.IssuedCredential) {
+	if !pluginapi.HasSecrets() {
+		r.FocusTable()
+		return
+	}
+	def := credentialPath(r.name, r.targetPath, cred.Name)
+	ui.ShowCompactStyledInputModal(r.pages, r.app, "Save credential to KeePass", "Path", def, 48, nil,
+		func(path string, cancelled bool) {
+			r.FocusTable()
+			path = strings.TrimSpace(path)
+			if cancelled || path == "" {
+				r.core.Log("[yellow]credential not saved")
+				return
+			}
+			if strings.Count(path, "/") != 2 {
+				r.core.Log("[red]path must be plugin/env/name")
+				return
+			}
+			if err := saveIssuedCredential(path, r.targetPath, cred); err != nil {
+				r.core.Log("[red]save credential: " + err.Error())
+				return
+			}
+			msg := "[green]saved " + path
+			if !cred.ExpiresAt.IsZero() {
+				msg += " (expires " + cred.ExpiresAt.Local().Format("2006-01-02") + ")"
+			}
+			r.core.Log(msg)
+		})
+}
+
+func saveIssuedCredential(path, targetPath string, cred pluginrpc.IssuedCredential) error {
+	entry, err := pluginapi.Secrets().Get(path)
+	if err != nil || entry == nil {
+		entry = &pluginapi.SecretEntry{}
+	}
+	if entry.CustomAttributes == nil {
+		entry.CustomAttributes = map[string]string{}
+	}
+	if entry.URL == "" && targetPath != "" {
+		if target, err := pluginapi.Secrets().Get(targetPath); err == nil && target != nil {
+			entry.URL = target.URL
+		}
+	}
+	if cred.UserName != "" {
+		entry.UserName = cred.UserName
+	}
+	if cred.Password != "" {
+		entry.Password = cred.Password
+	}
+	for k, v := range cred.Attributes {
+		if v != "" {
+			entry.CustomAttributes[k] = v
+		}
+	}
+	if cred.ExpiresAt.IsZero() {
+		delete(entry.CustomAttributes, pluginapi.ExpiresAtAttr)
+	} else {
+		entry.CustomAttributes[pluginapi.ExpiresAtAttr] = pluginapi.FormatExpiry(cred.ExpiresAt)
+	}
+	delete(entry.CustomAttributes, pluginapi.ReferenceEntryAttr)
+	return pluginapi.Secrets().Put(path, entry)
+}
+
+// warnExpiringCredentials shows one modal at startup listing credentials that
+// are expired or expire within pluginapi.ExpiryWarning.
+func (h *Host) warnExpiringCredentials() {
+	if !pluginapi.HasSecrets() {
+		return
+	}
+	all, err := pluginapi.ListCredentialExpiries(pluginapi.Secrets(), "")
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	var b strings.Builder
+	n := 0
+	for _, c := range all {
+		if !c.Soon(now) {
+			continue
+		}
+		n++
+		h.log("credential %s expires %s (%s)", c.Path, c.ExpiresAt.Format(time.RFC3339), c.Source)
+		fmt.Fprintf(&b, "%-8s %s  [gray](%s, %s)[-]\n",
+			pluginapi.ExpiryBadge(c.ExpiresAt, now), c.Path, c.ExpiresAt.Local().Format("2006-01-02"), c.Source)
+	}
+	if n == 0 {
+		return
+	}
+	body := b.String() + "\nSettings → 7 (Expiry) lists every tracked credential."
+	ui.ShowInfoModal(h.Pages, h.App, fmt.Sprintf("%d credential(s) expiring", n), body, func() {
+		if h.PluginsList != nil {
+			h.App.SetFocus(h.PluginsList)
+		}
+	})
+}
@@ -0,0 +1,425 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/pluginrpc"
+)
+
+// `omo daemon` polls dashboard tiles with the TUI closed. It watches every
+// tile of every layout in dashboards.yaml plus one tile per alert rule,
+// records metric history, evaluates alerts (the daemon runs the notify
+// hooks) and serves the results on ~/.omo/daemon.sock. A TUI that finds the
+// socket reads its dashboard from the daemon's warm plugin sessions.
+
+const daemonPollWorkers = 4
+
+// Daemon is the headless poller behind `omo daemon`.
+type Daemon struct {
+	manager *PluginManager
+	metrics *MetricStore
+	alerts  *AlertCenter
+	logFn   func(string, ...interface{})
+	started time.Time
+	stop    context.CancelFunc
+
+	mu       sync.Mutex
+	interval time.Duration
+	fixed    time.Duration // --interval; overrides dashboards.yaml
+	tiles    map[string]dashboardTile
+	latest   map[string]daemonTile
+}
+
+type daemonTile struct {
+	View pluginrpc.ViewData
+	At   time.Time
+}
+
+// NewDaemon polls every interval, or at the dashboards.yaml refresh rate
+// when interval is zero.
+func NewDaemon(interval time.Duration, logFn func(string, ...interface{})) *Daemon {
+	return &Daemon{
+		manager: newPluginManager(nil, nil, logFn),
+		metrics: NewMetricStore(defaultMetricSamples, ""),
+		alerts:  NewAlertCenter(),
+		logFn:   logFn,
+		fixed:   interval,
+		tiles:   map[string]dashboardTile{},
+		latest:  map[string]daemonTile{},
+	}
+}
+
+func (d *Daemon) log(format string, args ...interface{}) {
+	if d.logFn != nil {
+		d.logFn(format, args...)
+	}
+}
+
+// Run serves socketPath and polls until ctx ends or a client asks the daemon
+// to stop. reload (SIGHUP) re-reads secrets and configs.
+func (d *Daemon) Run(ctx context.Context, socketPath string, reload <-chan os.Signal) error {
+	ctx, d.stop = context.WithCancel(ctx)
+	defer d.stop()
+	d.started = time.Now()
+
+	ln, err := listenDaemonSocket(socketPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(socketPath)
+	defer ln.Close()
+
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("Daemon", &DaemonService{d: d}); err != nil {
+		return err
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go srv.ServeConn(conn)
+		}
+	}()
+	d.log("daemon listening on %s", socketPath)
+
+	d.reloadConfig()
+	ticker := time.NewTicker(d.pollInterval())
+	defer ticker.Stop()
+	d.poll()
+	for {
+		select {
+		case <-ctx.Done():
+			d.log("daemon stopping")
+			if err := d.metrics.Save(); err != nil {
+				d.log("save metrics: %v", err)
+			}
+			d.manager.KillAll()
+			return nil
+		case <-reload:
+			d.log("daemon reload")
+			d.manager.ReloadSecrets()
+			d.reloadConfig()
+			ticker.Reset(d.pollInterval())
+		case <-ticker.C:
+			d.reloadConfig()
+			ticker.Reset(d.pollInterval())
+			d.poll()
+		}
+	}
+}
+
+// listenDaemonSocket refuses to start next to a live daemon and clears a
+// socket left behind by one that crashed.
+func listenDaemonSocket(path string) (net.Listener, error) {
+	if conn, err := net.DialTimeout("unix", path, 500*time.Millisecond); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("a daemon is already listening on %s", path)
+	}
+	_ = os.Remove(path)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+func (d *Daemon) pollInterval() time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.interval
+}
+
+// reloadConfig re-reads dashboards.yaml and alerts.yaml. Tiles a TUI asked
+// for stay watched.
+func (d *Daemon) reloadConfig() {
+	cfg, err := LoadDashboardConfig()
+	if err != nil {
+		d.log("dashboard config: %v", err)
+	}
+	entries, _ := discoverPluginEntries(pluginapi.PluginsDir())
+	if cfg == nil {
+		cfg = autoDashboardConfig(entries)
+	}
+	alerts, err := LoadAlertConfig()
+	if err != nil {
+		d.log("alerts config: %v", err)
+	} else {
+		d.alerts.SetConfig(alerts)
+	}
+
+	path := ""
+	if cfg.History.Persist {
+		path = pluginapi.MetricsPath()
+	}
+	d.metrics.Configure(cfg.History.Samples, path)
+
+	var tiles []DashboardTile
+	for _, l := range cfg.Dashboards {
+		tiles = append(tiles, l.Tiles...)
+	}
+	if alerts != nil {
+		for _, r := range alerts.Rules {
+			tiles = append(tiles, DashboardTile{Plugin: r.Plugin, Target: r.Target, View: r.View})
+		}
+	}
+
+	interval := d.fixed
+	if interval <= 0 {
+		interval = cfg.refreshInterval()
+	}
+	if interval <= 0 {
+		interval = defaultDashboardRefresh
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.interval = interval
+	for _, t := range tiles {
+		d.watchLocked(t, entries)
+	}
+}
+
+// watchLocked adds t to the polled tiles, keeping the tallest row span seen
+// for the same plugin, target and view.
+func (d *Daemon) watchLocked(t DashboardTile, entries []installedPlugin) {
+	key := tileSessionKey(t.Plugin, t.Target, t.View)
+	if cur, ok := d.tiles[key]; ok {
+		if t.RowSpan > cur.RowSpan {
+			cur.RowSpan = t.RowSpan
+			d.tiles[key] = cur
+		}
+		return
+	}
+	tile := dashboardTile{DashboardTile: DashboardTile{Plugin: t.Plugin, Target: t.Target, View: t.View, RowSpan: t.RowSpan}}
+	for _, e := range entries {
+		if e.Name == t.Plugin {
+			tile.BinPath = e.BinPath
+		}
+	}
+	d.tiles[key] = tile
+}
+
+// poll fetches every watched tile once with a few workers.
+func (d *Daemon) poll() {
+	d.mu.Lock()
+	tiles := make([]dashboardTile, 0, len(d.tiles))
+	for _, t := range d.tiles {
+		tiles = append(tiles, t)
+	}
+	d.mu.Unlock()
+
+	jobs := make(chan dashboardTile)
+	var wg sync.WaitGroup
+	for n := 0; n < daemonPollWorkers; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range jobs {
+				d.pollTile(t)
+			}
+		}()
+	}
+	for _, t := range tiles {
+		jobs <- t
+	}
+	close(jobs)
+	wg.Wait()
+	if err := d.metrics.Save(); err != nil {
+		d.log("save metrics: %v", err)
+	}
+}
+
+func (d *Daemon) pollTile(t dashboardTile) daemonTile {
+	var view pluginrpc.ViewData
+	if t.BinPath == "" {
+		view = d.manager.dashboardStatus(t.Plugin, "not installed", "install it from Package Manager")
+	} else {
+		rows := 4 * clampSpan(t.RowSpan, maxTileRowSpan)
+		if d.alerts.watches(t.DashboardTile) {
+			rows = alertWidgetRowsMax
+		}
+		view = d.manager.DashboardTileSnapshot(t.Plugin, t.BinPath, t.Target, t.View, rows)
+	}
+	now := time.Now()
+	d.metrics.Record(t.DashboardTile, view.Metrics, now)
+	d.alerts.Evaluate(t.DashboardTile, view, now)
+	result := daemonTile{View: view, At: now}
+	d.mu.Lock()
+	d.latest[tileSessionKey(t.Plugin, t.Target, t.View)] = result
+	d.mu.Unlock()
+	return result
+}
+
+// tile returns the last result for t, polling it now when it is not watched
+// yet or the result is older than the poll interval.
+func (d *Daemon) tile(t DashboardTile) daemonTile {
+	key := tileSessionKey(t.Plugin, t.Target, t.View)
+	d.mu.Lock()
+	cached, ok := d.latest[key]
+	fresh := ok && time.Since(cached.At) < d.interval
+	if _, watched := d.tiles[key]; !watched {
+		entries, _ := discoverPluginEntries(pluginapi.PluginsDir())
+		d.watchLocked(t, entries)
+	}
+	tile := d.tiles[key]
+	d.mu.Unlock()
+	if fresh {
+		return cached
+	}
+	return d.pollTile(tile)
+}
+
+// DaemonService is the net/rpc API on the daemon socket.
+type DaemonService struct {
+	d *Daemon
+}
+
+// DaemonTileArgs names a dashboard tile.
+type DaemonTileArgs struct {
+	Plugin, Target, View string
+	MaxRows              int
+}
+
+// DaemonTileReply is a tile's latest widget and the history of its metrics.
+type DaemonTileReply struct {
+	View    pluginrpc.ViewData
+	At      time.Time
+	History map[string][]float64
+}
+
+// DaemonStatus is what `omo daemon status` prints.
+type DaemonStatus struct {
+	PID      int
+	Started  time.Time
+	Interval time.Duration
+	Tiles    []DaemonTileStatus
+	Firing   int
+}
+
+// DaemonTileStatus is one watched tile.
+type DaemonTileStatus struct {
+	Label  string
+	Status string
+	At     time.Time
+}
+
+func (s *DaemonService) Tile(args DaemonTileArgs, reply *DaemonTileReply) error {
+	if args.Plugin == "" {
+		return errors.New("plugin is required")
+	}
+	tile := DashboardTile{Plugin: args.Plugin, Target: args.Target, View: args.View, RowSpan: (args.MaxRows + 3) / 4}
+	result := s.d.tile(tile)
+	reply.View = result.View
+	reply.At = result.At
+	if args.MaxRows > 0 && len(reply.View.Rows) > args.MaxRows {
+		reply.View.Rows = reply.View.Rows[:args.MaxRows]
+	}
+	reply.History = map[string][]float64{}
+	for _, m := range result.View.Metrics {
+		reply.History[m.Name] = s.d.metrics.Values(tile, m.Name)
+	}
+	return nil
+}
+
+func (s *DaemonService) Notifications(_ struct{}, reply *[]Notification) error {
+	*reply = s.d.alerts.Notifications()
+	return nil
+}
+
+func (s *DaemonService) Status(_ struct{}, reply *DaemonStatus) error {
+	d := s.d
+	d.mu.Lock()
+	reply.PID = os.Getpid()
+	reply.Started = d.started
+	reply.Interval = d.interval
+	for key, t := range d.tiles {
+		st := DaemonTileStatus{Label: tileLabel(t.DashboardTile), Status: "pending"}
+		if latest, ok := d.latest[key]; ok {
+			st.Status, st.At = dashStatus(latest.View.Status), latest.At
+		}
+		reply.Tiles = append(reply.Tiles, st)
+	}
+	d.mu.Unlock()
+	sort.Slice(reply.Tiles, func(i, j int) bool { return reply.Tiles[i].Label < reply.Tiles[j].Label })
+	reply.Firing = d.alerts.Firing()
+	return nil
+}
+
+func (s *DaemonService) Stop(_ struct{}, _ *struct{}) error {
+	// Reply before the listener closes.
+	time.AfterFunc(100*time.Millisecond, s.d.stop)
+	return nil
+}
+
+// DaemonClient talks to a running daemon.
+type DaemonClient struct {
+	client *rpc.Client
+}
+
+// DialDaemon connects to the daemon socket; an error means none is running.
+func DialDaemon(path string) (*DaemonClient, error) {
+	conn, err := net.DialTimeout("unix", path, 500*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+	return &DaemonClient{client: rpc.NewClient(conn)}, nil
+}
+
+func (c *DaemonClient) Close() error {
+	if c == nil {
+		return nil
+	}
+	return c.client.Close()
+}
+
+// call bounds a request so a wedged daemon cannot hang the dashboard.
+func (c *DaemonClient) call(method string, args, reply any, timeout time.Duration) error {
+	call := c.client.Go("Daemon."+method, args, reply, make(chan *rpc.Call, 1))
+	select {
+	case <-call.Done:
+		return call.Error
+	case <-time.After(timeout):
+		return fmt.Errorf("daemon %s: timed out after %s", method, timeout)
+	}
+}
+
+func (c *DaemonClient) Tile(t DashboardTile, maxRows int) (DaemonTileReply, error) {
+	var reply DaemonTileReply
+	err := c.call("Tile", DaemonTileArgs{Plugin: t.Plugin, Target: t.Target, View: t.View, MaxRows: maxRows}, &reply, 20*time.Second)
+	return reply, err
+}
+
+func (c *DaemonClient) Status() (DaemonStatus, error) {
+	var reply DaemonStatus
+	err := c.call("Status", struct{}{}, &reply, 5*time.Second)
+	return reply, err
+}
+
+func (c *DaemonClient) Notifications() ([]Notification, error) {
+	var reply []Notification
+	err := c.call("Notifications", struct{}{}, &reply, 5*time.Second)
+	return reply, err
+}
+
+func (c *DaemonClient) Stop() error {
+	return c.call("Stop", struct{}{}, &struct{}{}, 5*time.Second)
+}
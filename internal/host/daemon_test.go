@@ -0,0 +1,67 @@
+package host
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDaemonServesTilesOverSocket(t *testing.T) {
+	home, err := os.MkdirTemp("", "omo") // unix socket paths are short
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+	t.Setenv("HOME", home)
+	socket := filepath.Join(home, "d.sock")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- NewDaemon(time.Hour, nil).Run(context.Background(), socket, nil)
+	}()
+	var client *DaemonClient
+	for i := 0; i < 50 && client == nil; i++ {
+		client, _ = DialDaemon(socket)
+		time.Sleep(20 * time.Millisecond)
+	}
+	if client == nil {
+		t.Fatal("daemon socket never came up")
+	}
+	defer client.Close()
+
+	if _, err := listenDaemonSocket(socket); err == nil {
+		t.Fatal("second daemon started next to a live one")
+	}
+
+	reply, err := client.Tile(DashboardTile{Plugin: "nope", Target: "nope/prod/a"}, 4)
+	if err != nil {
+		t.Fatalf("Tile: %v", err)
+	}
+	if reply.View.Status != "not installed" {
+		t.Fatalf("Tile status = %q, want not installed", reply.View.Status)
+	}
+	st, err := client.Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if st.PID != os.Getpid() || len(st.Tiles) != 1 || st.Tiles[0].Status != "not installed" {
+		t.Fatalf("Status = %+v, want the requested tile watched", st)
+	}
+
+	if err := client.Stop(); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("daemon did not stop")
+	}
+	if _, err := os.Stat(socket); !os.IsNotExist(err) {
+		t.Fatalf("socket left behind: %v", err)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
+	"omo/pkg/pluginapi"
 	"omo/pkg/pluginrpc"
 	"omo/pkg/ui"
 
@@ -14,100 +16,268 @@ import (
 
 const dashboardColumns = 3
 
-// Dashboard is the host-owned live overview of installed RPC plugins.
+// dashboardTile is a configured tile bound to its installed plugin binary.
+// BinPath is empty when the plugin is not installed.
+type dashboardTile struct {
+	DashboardTile
+	BinPath string
+}
+
+func (t dashboardTile) plugin() installedPlugin {
+	return installedPlugin{Name: t.Plugin, BinPath: t.BinPath}
+}
+
+// Dashboard is the host-owned live overview of installed RPC plugins. Its
+// tiles come from the active layout in ~/.omo/dashboards.yaml, or one tile
+// per installed plugin when there is none.
 type Dashboard struct {
 	app        *tview.Application
+	pages      *tview.Pages
 	manager    *PluginManager
 	entries    []installedPlugin
-	onOpen     func(installedPlugin)
+	config     *DashboardConfig
+	saved      bool // config came from (or has been written to) disk
+	tiles      []dashboardTile
+	places     []tilePlace
+	columns    int
+	onOpen     func(dashboardTile)
 	onClose    func()
 	root       *tview.Flex
 	grid       *tview.Grid
+	hint       *tview.TextView
 	cards      []*tview.TextView
+	expiry     []time.Time // credential expiry of each card's target, zero if unknown
+	metrics    *MetricStore
+	alerts     *AlertCenter
+	alertsErr  error // alerts.yaml failed to load; shown on the hint line
+	daemon     *DaemonClient
 	selected   int
 	mu         sync.Mutex
 	generation int
+	pulsing    int // pulses with workers still running
+	stop       chan struct{}
 }
 
+// NewDashboard shows one tile per installed plugin.
 func NewDashboard(
 	app *tview.Application,
 	manager *PluginManager,
 	entries []installedPlugin,
 	onOpen func(installedPlugin),
 	onClose func(),
+) *Dashboard {
+	var open func(dashboardTile)
+	if onOpen != nil {
+		open = func(t dashboardTile) { onOpen(t.plugin()) }
+	}
+	return NewDashboardLayout(app, nil, manager, entries, nil, open, onClose)
+}
+
+// NewDashboardLayout shows the active layout of cfg. A nil cfg falls back to
+// one tile per installed plugin.
+func NewDashboardLayout(
+	app *tview.Application,
+	pages *tview.Pages,
+	manager *PluginManager,
+	entries []installedPlugin,
+	cfg *DashboardConfig,
+	onOpen func(dashboardTile),
+	onClose func(),
 ) *Dashboard {
 	d := &Dashboard{
 		app:     app,
+		pages:   pages,
 		manager: manager,
 		entries: entries,
+		config:  cfg,
+		saved:   cfg != nil && len(cfg.Dashboards) > 0,
 		onOpen:  onOpen,
 		onClose: onClose,
 	}
+	if !d.saved {
+		d.config = autoDashboardConfig(entries)
+	}
+	d.root = tview.NewFlex().SetDirection(tview.FlexRow)
+	d.root.SetBackgroundColor(ui.ColorAppBg)
+	d.root.SetInputCapture(d.handleKey)
+	d.hint = tview.NewTextView().SetDynamicColors(true)
+	d.hint.SetBackgroundColor(ui.ColorAppBg)
 	d.build()
 	return d
 }
 
 func (d *Dashboard) Primitive() tview.Primitive { return d.root }
 
+// SetMetrics makes the dashboard record widget metrics into store and draw
+// their history.
+func (d *Dashboard) SetMetrics(store *MetricStore) { d.metrics = store }
+
+// SetDaemon reads tiles and their metric history from a running daemon
+// instead of launching plugins here.
+func (d *Dashboard) SetDaemon(client *DaemonClient) {
+	d.mu.Lock()
+	d.daemon = client
+	d.mu.Unlock()
+}
+
+// SetAlerts evaluates center's rules on every pulse and highlights the tiles
+// with firing alerts. A non-nil configErr stays on the hint line.
+func (d *Dashboard) SetAlerts(center *AlertCenter, configErr error) {
+	d.alerts = center
+	d.alertsErr = configErr
+	d.repaintAlerts()
+}
+
+// Start pulses the tiles every interval until Close; zero disables it.
+func (d *Dashboard) Start(interval time.Duration) {
+	if interval <= 0 || d.app == nil || d.stop != nil {
+		return
+	}
+	stop := make(chan struct{})
+	d.stop = stop
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				d.app.QueueUpdate(func() {
+					select {
+					case <-stop:
+					default:
+						d.pulse(false)
+					}
+				})
+			}
+		}
+	}()
+}
+
+// Close stops the auto-refresh started by Start.
+func (d *Dashboard) Close() {
+	if d.stop != nil {
+		close(d.stop)
+		d.stop = nil
+	}
+}
+
 func (d *Dashboard) Focus() {
 	if d.app != nil && d.root != nil {
 		d.app.SetFocus(d.root)
 	}
 }
 
+// build (re)creates the grid and cards from the active layout.
 func (d *Dashboard) build() {
-	if len(d.entries) == 0 {
-		d.grid = newDashboardGrid(1)
+	layout := d.config.active()
+	d.columns = layout.Columns
+	if d.columns < 1 {
+		d.columns = dashboardColumns
+	}
+	bins := map[string]string{}
+	for _, e := range d.entries {
+		bins[e.Name] = e.BinPath
+	}
+	d.tiles = make([]dashboardTile, len(layout.Tiles))
+	for i, t := range layout.Tiles {
+		d.tiles[i] = dashboardTile{DashboardTile: t, BinPath: bins[t.Plugin]}
+	}
+	var rows int
+	d.places, rows = placeTiles(layout.Tiles, d.columns)
+	if d.selected >= len(d.tiles) {
+		d.selected = len(d.tiles) - 1
+	}
+	if d.selected < 0 {
+		d.selected = 0
+	}
+
+	d.cards = nil
+	d.expiry = nil
+	if len(d.tiles) == 0 {
+		d.grid = newDashboardGrid(1, d.columns)
+		msg := "\n[yellow]No installed plugins found[white]\n\nInstall one from Package Manager."
+		if d.saved {
+			msg = "\n[yellow]No tiles on " + layout.Name + "[white]\n\nPress a to add one, n to switch dashboards."
+		}
 		empty := tview.NewTextView().
 			SetDynamicColors(true).
 			SetTextAlign(tview.AlignCenter).
-			SetText("\n[yellow]No installed plugins found[white]\n\nInstall one from Package Manager.")
+			SetText(msg)
 		empty.SetBackgroundColor(ui.ColorAppBg)
 		empty.SetBorder(true)
-		d.grid.AddItem(empty, 0, 0, 1, dashboardColumns, 0, 0, false)
+		d.grid.AddItem(empty, 0, 0, 1, d.columns, 0, 0, false)
 	} else {
-		rows := (len(d.entries) + dashboardColumns - 1) / dashboardColumns
-		d.grid = newDashboardGrid(rows)
-		d.cards = make([]*tview.TextView, len(d.entries))
-		for i, entry := range d.entries {
+		d.grid = newDashboardGrid(rows, d.columns)
+		d.cards = make([]*tview.TextView, len(d.tiles))
+		d.expiry = make([]time.Time, len(d.tiles))
+		for i, tile := range d.tiles {
 			card := tview.NewTextView()
 			card.SetDynamicColors(true)
 			card.SetWrap(false)
 			card.SetBackgroundColor(ui.ColorAppBg)
 			card.SetBorder(true)
 			card.SetBorderPadding(0, 0, 1, 1)
-			card.SetTitle(" " + entry.Name + " ")
+			card.SetTitle(" " + tileLabel(tile.DashboardTile) + " ")
 			card.SetText("[yellow]loading…[white]\n[gray]Preparing live summary")
 			d.cards[i] = card
-			d.grid.AddItem(card, i/dashboardColumns, i%dashboardColumns, 1, 1, 0, 0, false)
+			p := d.places[i]
+			d.grid.AddItem(card, p.Row, p.Col, p.RowSpan, p.ColSpan, 0, 0, false)
 		}
 	}
 
-	d.root = tview.NewFlex().
-		SetDirection(tview.FlexRow).
-		AddItem(d.grid, 0, 1, false)
-	d.root.SetBackgroundColor(ui.ColorAppBg)
-	d.root.SetInputCapture(d.handleKey)
+	d.root.Clear()
+	d.root.AddItem(d.grid, 0, 1, false)
+	d.root.AddItem(d.hint, 1, 0, false)
+	d.repaintAlerts()
+}
+
+// repaintAlerts redraws tile highlights and the hint line after alerts
+// change.
+func (d *Dashboard) repaintAlerts() {
+	hint := fmt.Sprintf(" [%s]%s[-]  [gray]? help · a add · x remove · < > move · n dashboards · ! alerts[-]",
+		ui.HexInfoKey, tview.Escape(d.config.active().Name))
+	if n := d.alerts.Firing(); n > 0 {
+		hint = fmt.Sprintf(" [red::b]🔔 %d firing[-::-] ·%s", n, hint)
+	}
+	d.mu.Lock()
+	attached := d.daemon != nil
+	d.mu.Unlock()
+	if attached {
+		hint += " [gray]· via daemon[-]"
+	}
+	if d.alertsErr != nil {
+		hint = " [red]" + tview.Escape(d.alertsErr.Error()) + "[-] ·" + hint
+	}
+	d.hint.SetText(hint)
 	d.paintSelection()
 }
 
 // newDashboardGrid uses proportional rows so tiles always fill the content
 // area instead of leaving dead space below a fixed-height grid.
-func newDashboardGrid(rows int) *tview.Grid {
+func newDashboardGrid(rows, columns int) *tview.Grid {
 	grid := tview.NewGrid()
 	grid.SetBackgroundColor(ui.ColorAppBg)
 	grid.SetBorders(false)
-	grid.SetColumns(0, 0, 0)
+	grid.SetColumns(make([]int, columns)...)
 	grid.SetRows(make([]int, rows)...)
 	return grid
 }
 
 func (d *Dashboard) handleKey(event *tcell.EventKey) *tcell.EventKey {
-	if len(d.entries) == 0 {
-		if event.Key() == tcell.KeyEscape && d.onClose != nil {
+	if event.Key() == tcell.KeyEscape {
+		if d.onClose != nil {
 			d.onClose()
+		}
+		return nil
+	}
+	if event.Key() == tcell.KeyRune {
+		if d.handleEditKey(event.Rune()) {
 			return nil
 		}
+	}
+	if len(d.tiles) == 0 {
 		if event.Key() == tcell.KeyRune && (event.Rune() == 'r' || event.Rune() == 'R') {
 			d.Refresh()
 			return nil
@@ -116,15 +286,8 @@ func (d *Dashboard) handleKey(event *tcell.EventKey) *tcell.EventKey {
 	}
 
 	switch event.Key() {
-	case tcell.KeyEscape:
-		if d.onClose != nil {
-			d.onClose()
-		}
-		return nil
 	case tcell.KeyEnter:
-		if d.onOpen != nil {
-			d.onOpen(d.entries[d.selected])
-		}
+		d.openSelected()
 		return nil
 	case tcell.KeyLeft:
 		d.move(-1)
@@ -133,10 +296,10 @@ func (d *Dashboard) handleKey(event *tcell.EventKey) *tcell.EventKey {
 		d.move(1)
 		return nil
 	case tcell.KeyUp:
-		d.move(-dashboardColumns)
+		d.moveVertical(-1)
 		return nil
 	case tcell.KeyDown:
-		d.move(dashboardColumns)
+		d.moveVertical(1)
 		return nil
 	case tcell.KeyRune:
 		switch event.Rune() {
@@ -147,75 +310,155 @@ func (d *Dashboard) handleKey(event *tcell.EventKey) *tcell.EventKey {
 			d.move(1)
 			return nil
 		case 'k':
-			d.move(-dashboardColumns)
+			d.moveVertical(-1)
 			return nil
 		case 'j':
-			d.move(dashboardColumns)
+			d.moveVertical(1)
 			return nil
 		case 'r', 'R':
 			d.Refresh()
 			return nil
 		case 'o':
-			if d.onOpen != nil {
-				d.onOpen(d.entries[d.selected])
-			}
+			d.openSelected()
 			return nil
 		}
 	}
 	return event
 }
 
+func (d *Dashboard) openSelected() {
+	if d.onOpen != nil && d.selected < len(d.tiles) {
+		d.onOpen(d.tiles[d.selected])
+	}
+}
+
 func (d *Dashboard) move(delta int) {
 	next := d.selected + delta
 	if next < 0 {
 		next = 0
 	}
-	if next >= len(d.entries) {
-		next = len(d.entries) - 1
+	if next >= len(d.tiles) {
+		next = len(d.tiles) - 1
 	}
 	d.selected = next
 	d.paintSelection()
 }
 
+// moveVertical selects the nearest tile in the row band above (dir -1) or
+// below (dir 1) that overlaps the current tile's columns.
+func (d *Dashboard) moveVertical(dir int) {
+	if d.selected >= len(d.places) {
+		return
+	}
+	cur := d.places[d.selected]
+	best, bestDist := -1, 0
+	for i, p := range d.places {
+		if i == d.selected {
+			continue
+		}
+		var dist int
+		if dir < 0 {
+			dist = cur.Row - (p.Row + p.RowSpan)
+		} else {
+			dist = p.Row - (cur.Row + cur.RowSpan)
+		}
+		if dist < 0 {
+			continue
+		}
+		overlap := p.Col < cur.Col+cur.ColSpan && cur.Col < p.Col+p.ColSpan
+		score := dist*2*maxDashboardColumns + abs(p.Col-cur.Col)
+		if !overlap {
+			score += maxDashboardColumns
+		}
+		if best < 0 || score < bestDist {
+			best, bestDist = i, score
+		}
+	}
+	if best >= 0 {
+		d.selected = best
+		d.paintSelection()
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
 func (d *Dashboard) paintSelection() {
 	for i, card := range d.cards {
+		border, title := ui.ColorBorder, ui.ColorBorder
+		switch d.alerts.TileLevel(d.tiles[i].DashboardTile) {
+		case alertCritical:
+			border, title = tcell.ColorRed, tcell.ColorRed
+		case alertWarning:
+			border, title = tcell.ColorYellow, tcell.ColorYellow
+		case alertAcked:
+			title = tcell.ColorYellow
+		}
 		if i == d.selected {
-			card.SetBorderColor(ui.ColorHighlight)
-			card.SetTitleColor(ui.ColorHighlight)
-		} else {
-			card.SetBorderColor(ui.ColorBorder)
-			card.SetTitleColor(ui.ColorBorder)
+			border = ui.ColorHighlight
+			if title == ui.ColorBorder {
+				title = ui.ColorHighlight
+			}
 		}
+		card.SetBorderColor(border)
+		card.SetTitleColor(title)
 	}
 }
 
-// Refresh starts a bounded live pulse. Tiles remain interactive while results
-// arrive and stale results from a previous refresh are ignored.
+// Refresh reloads secrets and starts a bounded live pulse. Tiles remain
+// interactive while results arrive and stale results from a previous refresh
+// are ignored.
 func (d *Dashboard) Refresh() {
-	d.mu.Lock()
-	d.generation++
-	generation := d.generation
-	d.mu.Unlock()
-
 	for i, card := range d.cards {
-		card.SetTitle(" " + d.entries[i].Name + " ")
+		card.SetTitle(" " + tileLabel(d.tiles[i].DashboardTile) + " ")
 		card.SetText("[yellow]loading…[white]\n[gray]Fetching plugin data")
 	}
 
 	if d.manager != nil {
 		d.manager.ReloadSecrets()
 	}
+	d.pulse(true)
+}
+
+// pulse fetches every tile once and records its metrics. Timed pulses keep
+// the current card text until results arrive and are skipped while a
+// previous pulse is still running.
+func (d *Dashboard) pulse(force bool) {
+	d.mu.Lock()
+	if !force && d.pulsing > 0 {
+		d.mu.Unlock()
+		return
+	}
+	d.generation++
+	d.pulsing++
+	generation := d.generation
+	d.mu.Unlock()
 
+	tiles, places := d.tiles, d.places
+	var wg sync.WaitGroup
 	jobs := make(chan int)
-	workers := dashboardColumns
-	if len(d.entries) < workers {
-		workers = len(d.entries)
+	workers := d.columns
+	if len(tiles) < workers {
+		workers = len(tiles)
 	}
+	wg.Add(workers)
 	for n := 0; n < workers; n++ {
 		go func() {
+			defer wg.Done()
 			for i := range jobs {
-				entry := d.entries[i]
-				view := d.manager.DashboardSnapshot(entry.Name, entry.BinPath)
+				tile := tiles[i]
+				rows := 4 * places[i].RowSpan
+				if d.alerts.watches(tile.DashboardTile) {
+					// Row rules look past what the card has room for.
+					rows = alertWidgetRowsMax
+				}
+				view := d.fetchTile(tile, rows)
+				d.alerts.Evaluate(tile.DashboardTile, view, time.Now())
+				expiry, _ := d.manager.TileExpiry(tile.Plugin, tile.Target)
 				d.app.QueueUpdateDraw(func() {
 					d.mu.Lock()
 					current := d.generation
@@ -223,24 +466,67 @@ func (d *Dashboard) Refresh() {
 					if current != generation || i >= len(d.cards) {
 						return
 					}
+					d.expiry[i] = expiry
 					d.renderCard(i, view)
+					d.repaintAlerts()
 				})
 			}
 		}()
 	}
 	go func() {
 		defer close(jobs)
-		for i := range d.entries {
+		for i := range tiles {
 			jobs <- i
 		}
 	}()
+	go func() {
+		wg.Wait()
+		d.mu.Lock()
+		d.pulsing--
+		d.mu.Unlock()
+		if err := d.metrics.Save(); err != nil {
+			pluginrpc.RPCLog("dashboard: save metrics: %v", err)
+		}
+	}()
+}
+
+// fetchTile asks the daemon for tile when one is attached, taking its metric
+// history along; otherwise it polls the plugin here and records the sample.
+// A daemon that stops answering is dropped for the rest of the session.
+func (d *Dashboard) fetchTile(tile dashboardTile, rows int) pluginrpc.ViewData {
+	d.mu.Lock()
+	daemon := d.daemon
+	d.mu.Unlock()
+	if daemon != nil {
+		reply, err := daemon.Tile(tile.DashboardTile, rows)
+		if err == nil {
+			d.metrics.Replace(tile.DashboardTile, reply.History)
+			return reply.View
+		}
+		pluginrpc.RPCLog("dashboard: daemon: %v", err)
+		d.mu.Lock()
+		if d.daemon == daemon {
+			d.daemon = nil
+		}
+		d.mu.Unlock()
+	}
+	if tile.BinPath == "" {
+		return d.manager.dashboardStatus(tile.Plugin, "not installed", "install it from Package Manager")
+	}
+	view := d.manager.DashboardTileSnapshot(tile.Plugin, tile.BinPath, tile.Target, tile.View, rows)
+	d.metrics.Record(tile.DashboardTile, view.Metrics, time.Now())
+	return view
 }
 
 func (d *Dashboard) renderCard(index int, view pluginrpc.ViewData) {
 	card := d.cards[index]
+	tile := d.tiles[index]
 	title := strings.TrimSpace(view.Title)
-	if title == "" {
-		title = d.entries[index].Name
+	if title == "" || tile.Title != "" || tile.Target != "" || tile.View != "" {
+		title = tileLabel(tile.DashboardTile)
+	}
+	if index < len(d.expiry) {
+		title += expiryBadge(d.expiry[index], time.Now())
 	}
 	card.SetTitle(" " + title + " ")
 
@@ -253,31 +539,80 @@ func (d *Dashboard) renderCard(index int, view pluginrpc.ViewData) {
 		statusColor = "yellow"
 	}
 
-	var b strings.Builder
-	fmt.Fprintf(&b, "[%s::b]%s[white::-]\n", statusColor, dashStatus(status))
-	shown := 0
+	limit := 4
+	if index < len(d.places) {
+		limit *= d.places[index].RowSpan
+	}
+	// The header line already carries the status; don't repeat it as a row.
+	var rows [][]string
 	for _, row := range view.Rows {
-		if shown >= 4 || len(row) == 0 {
+		if len(rows) >= limit || len(row) == 0 {
 			break
 		}
-		key := pluginrpc.Truncate(stripPluginPrefix(row[0]), 16)
-		// The header line already carries the status; don't repeat it as a row.
-		if strings.EqualFold(key, "Status") {
-			continue
+		if !strings.EqualFold(stripPluginPrefix(row[0]), "Status") {
+			rows = append(rows, row)
 		}
+	}
+	span := 1
+	if index < len(d.places) {
+		span = d.places[index].ColSpan
+	}
+	// Rows left over under the limit go to min/max/avg lines for metrics.
+	spare := limit - len(rows)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s::b]%s[white::-]\n", statusColor, dashStatus(status))
+	for _, row := range rows {
+		name := stripPluginPrefix(row[0])
+		key := pluginrpc.Truncate(name, 16)
 		value := "-"
 		if len(row) > 1 {
 			value = pluginrpc.Truncate(row[1], 28)
 		}
-		fmt.Fprintf(&b, "[%s]%s:[%s] %s\n", ui.HexInfoKey, key, ui.HexValue, value)
-		shown++
+		metric, history := d.metricHistory(tile.DashboardTile, view.Metrics, name)
+		if len(history) < 2 {
+			fmt.Fprintf(&b, "[%s]%s:[%s] %s\n", ui.HexInfoKey, key, ui.HexValue, value)
+			continue
+		}
+		fmt.Fprintf(&b, "[%s]%s:[%s] %s [%s]%s[-]\n", ui.HexInfoKey, key, ui.HexValue, value,
+			ui.HexInfoKey, sparkline(history, sparklineWidth*span))
+		if spare > 0 {
+			lo, hi, avg := metricStats(history)
+			fmt.Fprintf(&b, "[gray]  min %s · avg %s · max %s[-]\n",
+				formatMetric(lo, metric.Unit), formatMetric(avg, metric.Unit), formatMetric(hi, metric.Unit))
+			spare--
+		}
 	}
-	if shown == 0 {
+	if len(rows) == 0 {
 		b.WriteString("[gray]No summary rows")
 	}
 	card.SetText(strings.TrimRight(b.String(), "\n"))
 }
 
+// metricHistory returns the metric the widget marked for row name and its
+// retained samples.
+func (d *Dashboard) metricHistory(tile DashboardTile, metrics []pluginrpc.Metric, name string) (pluginrpc.Metric, []float64) {
+	for _, m := range metrics {
+		if strings.EqualFold(m.Name, name) {
+			return m, d.metrics.Values(tile, m.Name)
+		}
+	}
+	return pluginrpc.Metric{}, nil
+}
+
+// expiryBadge marks a tile whose credential expires within
+// pluginapi.ExpiryWarning.
+func expiryBadge(at, now time.Time) string {
+	if at.IsZero() || at.Sub(now) > pluginapi.ExpiryWarning {
+		return ""
+	}
+	color := "yellow"
+	if !at.After(now) {
+		color = "red"
+	}
+	return fmt.Sprintf(" [%s]⏳ %s[-]", color, pluginapi.ExpiryBadge(at, now))
+}
+
 func dashStatus(status string) string {
 	if status == "" {
 		return "connected"
@@ -0,0 +1,277 @@
+package host
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"omo/pkg/pluginapi"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DashboardConfig is ~/.omo/dashboards.yaml:
+//
+//	active: ops
+//	refresh: 15s          # auto-refresh interval, "off" to disable
+//	history:
+//	  samples: 240        # samples kept per metric
+//	  persist: true       # keep history in ~/.omo/metrics.json
+//	dashboards:
+//	  - name: ops
+//	    columns: 4
+//	    tiles:
+//	      - plugin: redis
+//	        target: redis/production/cache
+//	        title: prod cache
+//	        col_span: 2
+//	      - plugin: redis
+//	        target: redis/staging/cache
+//	      - plugin: postgres
+//	        view: locks
+//	        row_span: 2
+//
+// Without the file the dashboard shows one tile per installed plugin; the
+// first edit in the TUI writes that layout out as "default".
+type DashboardConfig struct {
+	Active     string            `yaml:"active,omitempty"`
+	Refresh    string            `yaml:"refresh,omitempty"`
+	History    DashboardHistory  `yaml:"history,omitempty"`
+	Dashboards []DashboardLayout `yaml:"dashboards"`
+}
+
+// DashboardHistory controls the metric samples behind tile sparklines.
+type DashboardHistory struct {
+	Samples int  `yaml:"samples,omitempty"`
+	Persist bool `yaml:"persist,omitempty"`
+}
+
+// DashboardLayout is one named dashboard.
+type DashboardLayout struct {
+	Name    string          `yaml:"name"`
+	Columns int             `yaml:"columns,omitempty"`
+	Tiles   []DashboardTile `yaml:"tiles"`
+}
+
+// DashboardTile is one card: a plugin, optionally pinned to a KeePass target
+// and a plugin view other than the dashboard widget.
+type DashboardTile struct {
+	Plugin  string `yaml:"plugin"`
+	Target  string `yaml:"target,omitempty"`
+	View    string `yaml:"view,omitempty"`
+	Title   string `yaml:"title,omitempty"`
+	ColSpan int    `yaml:"col_span,omitempty"`
+	RowSpan int    `yaml:"row_span,omitempty"`
+}
+
+const (
+	defaultDashboardName = "default"
+	maxDashboardColumns  = 6
+	maxTileRowSpan       = 4
+
+	defaultDashboardRefresh = 30 * time.Second
+	minDashboardRefresh     = 2 * time.Second
+)
+
+// LoadDashboardConfig reads ~/.omo/dashboards.yaml. A missing file returns
+// nil and no error.
+func LoadDashboardConfig() (*DashboardConfig, error) {
+	data, err := os.ReadFile(pluginapi.DashboardsPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cfg DashboardConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", pluginapi.DashboardsPath(), err)
+	}
+	cfg.normalize()
+	return &cfg, nil
+}
+
+// SaveDashboardConfig writes cfg to ~/.omo/dashboards.yaml.
+func SaveDashboardConfig(cfg *DashboardConfig) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	path := pluginapi.DashboardsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// autoDashboardConfig is the built-in layout: one tile per installed plugin.
+func autoDashboardConfig(entries []installedPlugin) *DashboardConfig {
+	layout := DashboardLayout{Name: defaultDashboardName, Columns: dashboardColumns}
+	for _, e := range entries {
+		layout.Tiles = append(layout.Tiles, DashboardTile{Plugin: e.Name})
+	}
+	return &DashboardConfig{Active: layout.Name, Dashboards: []DashboardLayout{layout}}
+}
+
+func (c *DashboardConfig) normalize() {
+	seen := map[string]bool{}
+	out := c.Dashboards[:0]
+	for _, d := range c.Dashboards {
+		d.Name = strings.TrimSpace(d.Name)
+		if d.Name == "" {
+			d.Name = fmt.Sprintf("dashboard-%d", len(out)+1)
+		}
+		if seen[d.Name] {
+			continue
+		}
+		seen[d.Name] = true
+		if d.Columns <= 0 {
+			d.Columns = dashboardColumns
+		}
+		if d.Columns > maxDashboardColumns {
+			d.Columns = maxDashboardColumns
+		}
+		tiles := d.Tiles[:0]
+		for _, t := range d.Tiles {
+			t.Plugin = strings.TrimSpace(t.Plugin)
+			if t.Plugin == "" {
+				continue
+			}
+			t.ColSpan = clampSpan(t.ColSpan, d.Columns)
+			t.RowSpan = clampSpan(t.RowSpan, maxTileRowSpan)
+			tiles = append(tiles, t)
+		}
+		d.Tiles = tiles
+		out = append(out, d)
+	}
+	c.Dashboards = out
+	if c.index(c.Active) < 0 && len(c.Dashboards) > 0 {
+		c.Active = c.Dashboards[0].Name
+	}
+}
+
+// refreshInterval is how often the open dashboard pulses its tiles; zero
+// means only on open and r.
+func (c *DashboardConfig) refreshInterval() time.Duration {
+	v := strings.ToLower(strings.TrimSpace(c.Refresh))
+	switch v {
+	case "":
+		return defaultDashboardRefresh
+	case "0", "off", "never", "false":
+		return 0
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return defaultDashboardRefresh
+	}
+	if d < minDashboardRefresh {
+		return minDashboardRefresh
+	}
+	return d
+}
+
+func clampSpan(v, limit int) int {
+	if v < 1 {
+		return 1
+	}
+	if v > limit {
+		return limit
+	}
+	return v
+}
+
+func (c *DashboardConfig) index(name string) int {
+	for i, d := range c.Dashboards {
+		if d.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// active returns the layout being shown; normalize guarantees one exists
+// whenever Dashboards is non-empty.
+func (c *DashboardConfig) active() *DashboardLayout {
+	if i := c.index(c.Active); i >= 0 {
+		return &c.Dashboards[i]
+	}
+	if len(c.Dashboards) == 0 {
+		c.Dashboards = []DashboardLayout{{Name: defaultDashboardName, Columns: dashboardColumns}}
+	}
+	c.Active = c.Dashboards[0].Name
+	return &c.Dashboards[0]
+}
+
+// tilePlace is a tile's cell in the grid.
+type tilePlace struct {
+	Row, Col, RowSpan, ColSpan int
+}
+
+// placeTiles flows tiles row by row into the first free cell wide enough for
+// their span (like CSS grid auto-flow) and returns the number of rows used.
+func placeTiles(tiles []DashboardTile, columns int) ([]tilePlace, int) {
+	if columns < 1 {
+		columns = 1
+	}
+	var used [][]bool
+	occupied := func(r, c int) bool {
+		return r < len(used) && used[r][c]
+	}
+	fits := func(r, c, rs, cs int) bool {
+		if c+cs > columns {
+			return false
+		}
+		for i := r; i < r+rs; i++ {
+			for j := c; j < c+cs; j++ {
+				if occupied(i, j) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	places := make([]tilePlace, len(tiles))
+	rows := 0
+	for i, t := range tiles {
+		cs, rs := clampSpan(t.ColSpan, columns), clampSpan(t.RowSpan, maxTileRowSpan)
+		r, c := 0, 0
+		for !fits(r, c, rs, cs) {
+			c++
+			if c+cs > columns {
+				c = 0
+				r++
+			}
+		}
+		for len(used) < r+rs {
+			used = append(used, make([]bool, columns))
+		}
+		for y := r; y < r+rs; y++ {
+			for x := c; x < c+cs; x++ {
+				used[y][x] = true
+			}
+		}
+		places[i] = tilePlace{Row: r, Col: c, RowSpan: rs, ColSpan: cs}
+		if r+rs > rows {
+			rows = r + rs
+		}
+	}
+	return places, rows
+}
+
+// tileLabel is the card title before the plugin's own title arrives.
+func tileLabel(t DashboardTile) string {
+	if t.Title != "" {
+		return t.Title
+	}
+	label := t.Plugin
+	if t.Target != "" {
+		parts := strings.Split(t.Target, "/")
+		label += " · " + strings.Join(parts[1:], "/")
+	}
+	if t.View != "" && t.View != "dashboard" {
+		label += " · " + t.View
+	}
+	return label
+}
@@ -0,0 +1,300 @@
+package host
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"omo/pkg/pluginrpc"
+	"omo/pkg/ui"
+)
+
+// dashboardHelp lists the editor keys shown by ? on the dashboard.
+const dashboardHelp = `Navigate
+  ←↑↓→ / hjkl   select tile
+  Enter / o     open plugin (switches to the tile's target)
+  r             refresh
+  !             notifications (acknowledge, silence, dismiss alerts)
+  Esc           back to cover
+
+Edit (saved to ~/.omo/dashboards.yaml)
+  a             add tile (plugin, target, view)
+  e             edit selected tile
+  x             remove selected tile
+  < >           move tile earlier / later
+  [ ]           narrower / wider
+  { }           shorter / taller
+  c             grid columns
+  n             switch, create, rename or delete dashboards`
+
+// handleEditKey runs dashboard editor keys; it reports whether r was one.
+func (d *Dashboard) handleEditKey(r rune) bool {
+	switch r {
+	case '?':
+		d.showModal(func() {
+			ui.ShowInfoModal(d.pages, d.app, "Dashboard keys", dashboardHelp, d.Focus)
+		})
+	case 'a':
+		d.promptTile("Add tile", DashboardTile{}, func(t DashboardTile) {
+			d.mutate(func(l *DashboardLayout) {
+				l.Tiles = append(l.Tiles, t)
+				d.selected = len(l.Tiles) - 1
+			})
+		})
+	case 'e':
+		if d.selected >= len(d.tiles) {
+			return true
+		}
+		index := d.selected
+		d.promptTile("Edit tile", d.tiles[index].DashboardTile, func(t DashboardTile) {
+			d.mutate(func(l *DashboardLayout) { l.Tiles[index] = t })
+		})
+	case 'x':
+		if d.selected >= len(d.tiles) {
+			return true
+		}
+		index := d.selected
+		label := tileLabel(d.tiles[index].DashboardTile)
+		d.showModal(func() {
+			ui.ShowStandardConfirmationModal(d.pages, d.app, "Remove tile", "Remove "+label+" from this dashboard?", func(ok bool) {
+				d.Focus()
+				if ok {
+					d.mutate(func(l *DashboardLayout) {
+						l.Tiles = append(l.Tiles[:index], l.Tiles[index+1:]...)
+					})
+				}
+			})
+		})
+	case '<', '>':
+		delta := 1
+		if r == '<' {
+			delta = -1
+		}
+		i, j := d.selected, d.selected+delta
+		if i >= len(d.tiles) || j < 0 || j >= len(d.tiles) {
+			return true
+		}
+		d.mutate(func(l *DashboardLayout) {
+			l.Tiles[i], l.Tiles[j] = l.Tiles[j], l.Tiles[i]
+			d.selected = j
+		})
+	case '[', ']', '{', '}':
+		if d.selected >= len(d.tiles) {
+			return true
+		}
+		index := d.selected
+		d.mutate(func(l *DashboardLayout) {
+			t := &l.Tiles[index]
+			switch r {
+			case '[':
+				t.ColSpan--
+			case ']':
+				t.ColSpan = clampSpan(t.ColSpan, l.Columns) + 1
+			case '{':
+				t.RowSpan--
+			case '}':
+				t.RowSpan = clampSpan(t.RowSpan, maxTileRowSpan) + 1
+			}
+		})
+	case 'c':
+		columns := strconv.Itoa(d.columns)
+		d.showModal(func() {
+			ui.ShowCompactStyledInputModal(d.pages, d.app, "Grid columns", fmt.Sprintf("Columns (1-%d)", maxDashboardColumns), columns, 24, nil,
+				func(text string, cancelled bool) {
+					d.Focus()
+					n, err := strconv.Atoi(strings.TrimSpace(text))
+					if cancelled || err != nil || n < 1 {
+						return
+					}
+					d.mutate(func(l *DashboardLayout) { l.Columns = n })
+				})
+		})
+	case 'n':
+		d.showDashboardsMenu()
+	case '!':
+		d.showNotifications()
+	default:
+		return false
+	}
+	return true
+}
+
+// showModal runs open only when the dashboard is mounted in a page stack.
+func (d *Dashboard) showModal(open func()) {
+	if d.pages == nil || d.app == nil {
+		return
+	}
+	open()
+}
+
+// mutate edits the active layout, saves the config and rebuilds the grid.
+// The first edit of the built-in layout writes it out as "default".
+func (d *Dashboard) mutate(fn func(*DashboardLayout)) {
+	fn(d.config.active())
+	d.config.normalize()
+	d.saved = true
+	d.build()
+	if err := SaveDashboardConfig(d.config); err != nil {
+		d.hint.SetText(" [red]save dashboards: " + err.Error() + "[-]")
+	}
+	if d.app != nil {
+		d.Refresh()
+	}
+}
+
+// promptTile walks plugin → target → view → title, starting from initial.
+func (d *Dashboard) promptTile(title string, initial DashboardTile, done func(DashboardTile)) {
+	d.showModal(func() {
+		pickPlugin := func(next func(string)) {
+			if initial.Plugin != "" {
+				next(initial.Plugin)
+				return
+			}
+			if len(d.entries) == 0 {
+				d.hint.SetText(" [yellow]no installed plugins to add[-]")
+				return
+			}
+			items := make([][]string, len(d.entries))
+			for i, e := range d.entries {
+				items[i] = []string{e.Name, e.BinPath}
+			}
+			ui.ShowStandardListSelectorModal(d.pages, d.app, title+": plugin", items, func(index int, _ string, cancelled bool) {
+				if cancelled || index < 0 || index >= len(d.entries) {
+					d.Focus()
+					return
+				}
+				next(d.entries[index].Name)
+			})
+		}
+
+		pickPlugin(func(plugin string) {
+			t := initial
+			if t.Plugin != plugin {
+				t = DashboardTile{Plugin: plugin}
+			}
+			targets, _ := listSecretTargets(plugin)
+			items := [][]string{{"(default)", "first KeePass entry, like the sidebar"}}
+			for _, tg := range targets {
+				items = append(items, []string{tg.Path, tg.Detail})
+			}
+			ui.ShowStandardListSelectorModal(d.pages, d.app, title+": target", items, func(index int, _ string, cancelled bool) {
+				if cancelled || index < 0 || index >= len(items) {
+					d.Focus()
+					return
+				}
+				t.Target = ""
+				if index > 0 {
+					t.Target = targets[index-1].Path
+				}
+				view := t.View
+				if view == "" {
+					view = pluginrpc.DashboardView
+				}
+				ui.ShowCompactStyledInputModal(d.pages, d.app, title+": view", "View", view, 32, nil,
+					func(text string, cancelled bool) {
+						if cancelled {
+							d.Focus()
+							return
+						}
+						t.View = strings.TrimSpace(text)
+						if t.View == pluginrpc.DashboardView {
+							t.View = ""
+						}
+						ui.ShowCompactStyledInputModal(d.pages, d.app, title+": title", "Title (optional)", t.Title, 32, nil,
+							func(text string, cancelled bool) {
+								d.Focus()
+								if cancelled {
+									return
+								}
+								t.Title = strings.TrimSpace(text)
+								done(t)
+							})
+					})
+			})
+		})
+	})
+}
+
+// showDashboardsMenu switches between named dashboards and manages them.
+func (d *Dashboard) showDashboardsMenu() {
+	d.showModal(func() {
+		type choice struct {
+			label, detail string
+			run           func()
+		}
+		var choices []choice
+		for _, l := range d.config.Dashboards {
+			name := l.Name
+			mark := "  "
+			if name == d.config.Active {
+				mark = "● "
+			}
+			choices = append(choices, choice{mark + name, fmt.Sprintf("%d tiles · %d columns", len(l.Tiles), l.Columns), func() {
+				d.config.Active = name
+				d.selected = 0
+				d.mutate(func(*DashboardLayout) {})
+			}})
+		}
+		choices = append(choices, choice{"+ New dashboard", "empty layout", func() {
+			d.promptDashboardName("New dashboard", "", func(name string) {
+				d.config.Dashboards = append(d.config.Dashboards, DashboardLayout{Name: name, Columns: dashboardColumns})
+				d.config.Active = name
+				d.selected = 0
+				d.mutate(func(*DashboardLayout) {})
+			})
+		}})
+		active := d.config.Active
+		choices = append(choices, choice{"✎ Rename " + active, "", func() {
+			d.promptDashboardName("Rename dashboard", active, func(name string) {
+				if i := d.config.index(active); i >= 0 {
+					d.config.Dashboards[i].Name = name
+				}
+				d.config.Active = name
+				d.mutate(func(*DashboardLayout) {})
+			})
+		}})
+		if len(d.config.Dashboards) > 1 {
+			choices = append(choices, choice{"− Delete " + active, "", func() {
+				ui.ShowStandardConfirmationModal(d.pages, d.app, "Delete dashboard", "Delete dashboard "+active+"?", func(ok bool) {
+					d.Focus()
+					if !ok {
+						return
+					}
+					if i := d.config.index(active); i >= 0 {
+						d.config.Dashboards = append(d.config.Dashboards[:i], d.config.Dashboards[i+1:]...)
+					}
+					d.config.Active = ""
+					d.selected = 0
+					d.mutate(func(*DashboardLayout) {})
+				})
+			}})
+		}
+
+		items := make([][]string, len(choices))
+		for i, c := range choices {
+			items[i] = []string{c.label, c.detail}
+		}
+		ui.ShowStandardListSelectorModal(d.pages, d.app, "Dashboards", items, func(index int, _ string, cancelled bool) {
+			d.Focus()
+			if cancelled || index < 0 || index >= len(choices) {
+				return
+			}
+			choices[index].run()
+		})
+	})
+}
+
+func (d *Dashboard) promptDashboardName(title, initial string, done func(string)) {
+	ui.ShowCompactStyledInputModal(d.pages, d.app, title, "Name", initial, 32, nil, func(text string, cancelled bool) {
+		d.Focus()
+		name := strings.TrimSpace(text)
+		if cancelled || name == "" || name == initial {
+			return
+		}
+		if d.config.index(name) >= 0 {
+			d.hint.SetText(" [red]dashboard " + name + " already exists[-]")
+			return
+		}
+		done(name)
+	})
+}
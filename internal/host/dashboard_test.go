@@ -113,3 +113,57 @@ func TestPluginHomeEscapeReturnsToDashboard(t *testing.T) {
 		t.Fatal("plugin home Escape did not return to dashboard")
 	}
 }
+
+func TestPlaceTilesFlowsAroundSpans(t *testing.T) {
+	tiles := []DashboardTile{
+		{Plugin: "redis", ColSpan: 2, RowSpan: 2},
+		{Plugin: "postgres"},
+		{Plugin: "kafka"},
+		{Plugin: "docker", ColSpan: 3},
+	}
+	places, rows := placeTiles(tiles, 3)
+	want := []tilePlace{
+		{Row: 0, Col: 0, RowSpan: 2, ColSpan: 2},
+		{Row: 0, Col: 2, RowSpan: 1, ColSpan: 1},
+		{Row: 1, Col: 2, RowSpan: 1, ColSpan: 1},
+		{Row: 2, Col: 0, RowSpan: 1, ColSpan: 3},
+	}
+	if rows != 3 {
+		t.Fatalf("rows = %d, want 3", rows)
+	}
+	for i := range want {
+		if places[i] != want[i] {
+			t.Fatalf("tile %d placed at %+v, want %+v", i, places[i], want[i])
+		}
+	}
+}
+
+func TestDashboardLayoutTargetsAndVerticalMove(t *testing.T) {
+	cfg := &DashboardConfig{Active: "ops", Dashboards: []DashboardLayout{
+		{Name: "other"},
+		{Name: "ops", Columns: 2, Tiles: []DashboardTile{
+			{Plugin: "redis", Target: "redis/production/cache", ColSpan: 2},
+			{Plugin: "redis", Target: "redis/staging/cache"},
+			{Plugin: "missing"},
+		}},
+	}}
+	cfg.normalize()
+	entries := []installedPlugin{{Name: "redis", BinPath: "/plugins/redis"}}
+	var opened dashboardTile
+	d := NewDashboardLayout(nil, nil, nil, entries, cfg, func(tile dashboardTile) { opened = tile }, nil)
+
+	if len(d.cards) != 3 || d.tiles[2].BinPath != "" {
+		t.Fatalf("tiles = %+v", d.tiles)
+	}
+	d.handleKey(tcell.NewEventKey(tcell.KeyDown, 0, 0))
+	if d.selected != 1 {
+		t.Fatalf("down from wide tile selected %d, want 1", d.selected)
+	}
+	d.handleKey(tcell.NewEventKey(tcell.KeyEnter, 0, 0))
+	if opened.Target != "redis/staging/cache" || opened.BinPath != "/plugins/redis" {
+		t.Fatalf("opened = %+v", opened)
+	}
+	if got := tileLabel(d.tiles[0].DashboardTile); got != "redis · production/cache" {
+		t.Fatalf("label = %q", got)
+	}
+}
@@ -0,0 +1,99 @@
+package host
+
+import (
+	"strings"
+	"time"
+
+	"omo/pkg/pluginrpc"
+)
+
+// detailDelay lets the highlight settle before the detail pane asks the
+// plugin, so holding ↓ does not fire one action per row.
+const detailDelay = 120 * time.Millisecond
+
+// detailEntry is a rendered detail pane page.
+type detailEntry struct {
+	title, text string
+}
+
+// toggleDetail opens or closes the detail pane.
+func (r *RPCRenderer) toggleDetail() {
+	if r.core.ToggleDetailPane() {
+		r.updateDetail()
+	}
+}
+
+// updateDetail fills the open detail pane for the highlighted row, from the
+// cache or by calling the view's DetailAction.
+func (r *RPCRenderer) updateDetail() {
+	if !r.core.DetailPaneVisible() {
+		return
+	}
+	seq := r.detailSeq.Add(1)
+	row := r.core.GetSelectedRowData()
+	switch {
+	case r.detailAction == "":
+		r.core.SetDetail("Details", "[muted]This view has no details.[-]")
+		return
+	case row == nil:
+		r.core.SetDetail("Details", "[muted]No row selected.[-]")
+		return
+	case r.plugin == nil:
+		r.core.SetDetail("Details", "[muted]plugin still loading…[-]")
+		return
+	}
+	key := r.currentView + "\x00" + strings.Join(row, "\x00")
+	if e, ok := r.detailCache[key]; ok {
+		r.core.SetDetail(e.title, e.text)
+		return
+	}
+	name := batchItemName(row)
+	r.core.SetDetail(name, "[muted]loading…[-]")
+
+	plugin := r.plugin
+	req := pluginrpc.ActionRequest{Action: r.detailAction, View: r.currentView, Payload: rowPayload(row)}
+	go func() {
+		time.Sleep(detailDelay)
+		if r.detailSeq.Load() != seq {
+			return
+		}
+		result, err := plugin.DoAction(req)
+		r.app.QueueUpdateDraw(func() {
+			if r.detailSeq.Load() != seq {
+				return
+			}
+			e, ok := detailPage(name, result, err)
+			if ok {
+				if r.detailCache == nil {
+					r.detailCache = map[string]detailEntry{}
+				}
+				r.detailCache[key] = e
+			}
+			r.core.SetDetail(e.title, e.text)
+		})
+	}()
+}
+
+// detailPage renders an action result for the detail pane; ok is false for
+// failures, which are not cached.
+func detailPage(name string, result pluginrpc.ActionResult, err error) (detailEntry, bool) {
+	switch {
+	case err != nil:
+		return detailEntry{name, "[red]" + err.Error() + "[-]"}, false
+	case !result.OK:
+		return detailEntry{name, "[red]" + result.Message + "[-]"}, false
+	case result.Detail != nil:
+		d := *result.Detail
+		if d.Title != "" {
+			name = d.Title
+		}
+		d.Title = "" // shown in the pane frame
+		return detailEntry{name, d.Render()}, true
+	case result.ModalBody != "":
+		if result.ModalTitle != "" {
+			name = result.ModalTitle
+		}
+		return detailEntry{name, result.ModalBody}, true
+	}
+	return detailEntry{name, "[muted]No details.[-]"}, true
+}
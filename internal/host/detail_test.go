@@ -0,0 +1,47 @@
+package host
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"omo/pkg/pluginrpc"
+
+	"github.com/rivo/tview"
+)
+
+func TestDetailPage(t *testing.T) {
+	detail := &pluginrpc.Detail{Title: "web", Sections: []pluginrpc.DetailSection{{Text: "hello"}}}
+	e, ok := detailPage("row", pluginrpc.ActionResult{OK: true, Detail: detail}, nil)
+	if !ok || e.title != "web" || strings.Contains(e.text, "web") || !strings.Contains(e.text, "hello") {
+		t.Fatalf("detail page = %+v, %v; want title web moved to the frame", e, ok)
+	}
+	if detail.Title != "web" {
+		t.Fatalf("detailPage modified the result's title")
+	}
+
+	e, ok = detailPage("row", pluginrpc.ActionResult{OK: true, ModalTitle: "t", ModalBody: "body"}, nil)
+	if !ok || e.title != "t" || e.text != "body" {
+		t.Fatalf("modal page = %+v, %v", e, ok)
+	}
+
+	if _, ok := detailPage("row", pluginrpc.ActionResult{}, errors.New("boom")); ok {
+		t.Fatal("errors must not be cached")
+	}
+	if _, ok := detailPage("row", pluginrpc.ActionResult{Message: "gone"}, nil); ok {
+		t.Fatal("failed results must not be cached")
+	}
+}
+
+func TestDetailPaneWithoutAction(t *testing.T) {
+	renderer := NewRPCRenderer(tview.NewApplication(), tview.NewPages(), "test", nil)
+	renderer.Apply(pluginrpc.ViewData{View: "items", Headers: []string{"Name"}, Rows: [][]string{{"a"}}})
+	renderer.toggleDetail()
+	if !renderer.core.DetailPaneVisible() {
+		t.Fatal("detail pane did not open")
+	}
+	renderer.toggleDetail()
+	if renderer.core.DetailPaneVisible() {
+		t.Fatal("detail pane did not close")
+	}
+}
@@ -0,0 +1,31 @@
+package host
+
+import (
+	"strings"
+
+	"omo/pkg/pluginapi"
+)
+
+// promptFunctions asks for the local files and arguments fn_load,
+// fn_restore and fn_eval need. The plugin confirms changes itself.
+func (r *RPCRenderer) promptFunctions(action string) {
+	run := func(payload map[string]string) {
+		r.FocusTable()
+		r.runAction(action, payload)
+	}
+	switch action {
+	case "fn_load":
+		r.promptChain("Load Library", []string{"Library file (.lua):", "Replace existing (y/n):"}, []string{"", "n"}, func(v []string) {
+			run(map[string]string{"file": strings.TrimSpace(v[0]), "replace": strings.TrimSpace(v[1])})
+		})
+	case "fn_restore":
+		dir := pluginapi.PluginExportsDir(r.name) + "/"
+		r.promptChain("Restore Libraries", []string{"Dump file:", "Policy (append/replace/flush):"}, []string{dir, "append"}, func(v []string) {
+			run(map[string]string{"file": strings.TrimSpace(v[0]), "policy": strings.TrimSpace(v[1])})
+		})
+	case "fn_eval":
+		r.promptChain("Run Script", []string{"Lua script file:", "KEYS (space separated):", "ARGV (space separated):"}, []string{"", "", ""}, func(v []string) {
+			run(map[string]string{"file": strings.TrimSpace(v[0]), "keys": v[1], "args": v[2]})
+		})
+	}
+}
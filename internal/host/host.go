@@ -41,6 +41,12 @@ type Host struct {
 	activePluginIdx int
 	rpcManager      *PluginManager
 	dashboard       *Dashboard
+	metrics         *MetricStore
+	alerts          *AlertCenter
+	daemon          *DaemonClient
+	keymap          *Keymap
+	accent          string // frame color of the shown plugin target
+	chord           ui.KeyChord
 	PluginsDir      string
 	logger          *pluginapi.Logger
 	version         string
@@ -94,7 +100,11 @@ func New(app *tview.Application, pages *tview.Pages, logger *pluginapi.Logger, v
 	h.rpcManager.SetLogo(h.Logo.View())
 	h.rpcManager.SetBreadcrumbHook(h.SetCrumbs)
 	h.rpcManager.SetHeaderHook(h.SetPluginHeader)
+	h.rpcManager.SetAccentHook(h.setAccent)
+	h.loadKeymap()
+	h.loadAccents()
 	go h.pollGitHubUpdate()
+	go h.watchThemes()
 	return h
 }
 
@@ -107,6 +117,11 @@ func (h *Host) Shutdown() {
 	if h.proverb != nil {
 		h.proverb.Stop()
 	}
+	h.closeDashboard()
+	if err := h.metrics.Save(); err != nil {
+		h.log("save dashboard metrics: %v", err)
+	}
+	_ = h.daemon.Close()
 	if h.rpcManager != nil {
 		h.log("shutting down RPC plugins")
 		h.rpcManager.KillAll()
@@ -176,7 +191,7 @@ func (h *Host) markActive(i int) string {
 
 func (h *Host) activateRPC(i int, binPath string) {
 	pluginrpc.RPCLog("host.activateRPC begin bin=%s", binPath)
-	h.dashboard = nil
+	h.closeDashboard()
 
 	h.overlayRestyle = func() {
 		if h.rpcManager != nil {
@@ -212,7 +227,7 @@ func (h *Host) activateInstalled(entry installedPlugin) {
 		}
 	}
 	// List may be stale after refresh; still open by path.
-	h.dashboard = nil
+	h.closeDashboard()
 	pluginLogger, err := pluginapi.NewLogger(entry.Name)
 	if err != nil {
 		h.log("failed to create logger for %s: %v", entry.Name, err)
@@ -249,6 +264,14 @@ func (h *Host) SelectTarget() {
 	h.rpcManager.ShowTargetSelector()
 }
 
+// ExportTable opens the table export menu for the active RPC plugin (Ctrl+e).
+func (h *Host) ExportTable() {
+	if h.rpcManager == nil {
+		return
+	}
+	h.rpcManager.ExportActiveTable()
+}
+
 // LogoView returns the OMO mark used in the plugin header (action mood flashes).
 func (h *Host) LogoView() tview.Primitive {
 	if h.Logo == nil {
@@ -329,6 +352,7 @@ func (h *Host) SetPluginHeader(p tview.Primitive) {
 	if h == nil || h.HeaderFrame == nil {
 		return
 	}
+	h.setAccent("") // the plugin manager sets it again for plugin views
 	if p == nil {
 		h.HeaderFrame.SetPrimitive(h.homeHeader())
 		if h.proverb != nil {
@@ -410,6 +434,7 @@ func (h *Host) resetHostActions() {
 // RefreshPlugins reloads the plugins list.
 func (h *Host) RefreshPlugins() {
 	h.log("refreshing plugins")
+	h.loadKeymap()
 	reopenDashboard := h.dashboard != nil
 	h.Body.RemoveItem(h.PluginsList)
 	h.PluginsList = h.LoadPlugins()
@@ -461,12 +486,13 @@ func (h *Host) DismissSplash() {
 		if h.App != nil && h.PluginsList != nil {
 			h.App.SetFocus(h.PluginsList)
 		}
+		h.warnExpiringCredentials()
 	})
 }
 
 // ShowCover mounts the branded splash and focuses its Enter-to-dashboard CTA.
 func (h *Host) ShowCover() {
-	h.dashboard = nil
+	h.closeDashboard()
 	h.overlayRestyle = func() { h.ShowCover() }
 	h.ShowHomeHeader()
 	h.SetCrumbs(ui.FormatBreadcrumbs([]string{"cover"}))
@@ -476,7 +502,8 @@ func (h *Host) ShowCover() {
 	}
 }
 
-// OpenDashboard shows live summaries for all installed RPC plugins.
+// OpenDashboard shows live summaries from the active dashboard layout, or
+// one tile per installed RPC plugin when none is configured.
 func (h *Host) OpenDashboard() {
 	entries, err := discoverPluginEntries(h.PluginsDir)
 	if err != nil {
@@ -486,12 +513,24 @@ func (h *Host) OpenDashboard() {
 	if h.rpcManager != nil {
 		h.rpcManager.PauseActive()
 	}
+	cfg, err := LoadDashboardConfig()
+	if err != nil {
+		h.log("dashboard config: %v", err)
+	}
 	h.ShowHomeHeader()
-	h.dashboard = NewDashboard(
+	h.closeDashboard()
+	h.dashboard = NewDashboardLayout(
 		h.App,
+		h.Pages,
 		h.rpcManager,
 		entries,
-		func(entry installedPlugin) { h.activateInstalled(entry) },
+		cfg,
+		func(tile dashboardTile) {
+			if tile.Target != "" && h.rpcManager != nil {
+				h.rpcManager.PreferTarget(tile.Plugin, tile.Target)
+			}
+			h.activateInstalled(tile.plugin())
+		},
 		h.ShowCover,
 	)
 	h.MainFrame.SetPrimitive(h.dashboard.Primitive())
@@ -500,9 +539,72 @@ func (h *Host) OpenDashboard() {
 			h.dashboard.ApplyTheme()
 		}
 	}
+	h.attachDaemon()
+	h.dashboard.SetDaemon(h.daemon)
+	h.dashboard.SetMetrics(h.dashboardMetrics(cfg))
+	h.dashboard.SetAlerts(h.dashboardAlerts())
 	h.SetCrumbs(ui.FormatBreadcrumbs([]string{"dashboard"}))
 	h.dashboard.Focus()
 	h.dashboard.Refresh()
+	h.dashboard.Start(h.dashboard.config.refreshInterval())
+}
+
+// closeDashboard stops the open dashboard's auto-refresh and forgets it.
+func (h *Host) closeDashboard() {
+	if h.dashboard != nil {
+		h.dashboard.Close()
+		h.dashboard = nil
+	}
+}
+
+// attachDaemon connects to a running `omo daemon`, if any, so the dashboard
+// reads its warm sessions and history instead of launching every plugin.
+func (h *Host) attachDaemon() {
+	_ = h.daemon.Close()
+	h.daemon = nil
+	client, err := DialDaemon(pluginapi.DaemonSocketPath())
+	if err != nil {
+		return
+	}
+	h.log("dashboard attached to omo daemon")
+	h.daemon = client
+}
+
+// dashboardAlerts reloads ~/.omo/alerts.yaml into the session's alert
+// center. A broken file keeps the previous rules.
+func (h *Host) dashboardAlerts() (*AlertCenter, error) {
+	if h.alerts == nil {
+		h.alerts = NewAlertCenter()
+	}
+	// An attached daemon evaluates the same rules and runs the hooks.
+	h.alerts.SetQuiet(h.daemon != nil)
+	cfg, err := LoadAlertConfig()
+	if err != nil {
+		h.log("alerts config: %v", err)
+		return h.alerts, err
+	}
+	h.alerts.SetConfig(cfg)
+	return h.alerts, nil
+}
+
+// dashboardMetrics returns the metric history shared by every dashboard
+// opened this session, sized and persisted as cfg's history asks.
+func (h *Host) dashboardMetrics(cfg *DashboardConfig) *MetricStore {
+	var history DashboardHistory
+	if cfg != nil {
+		history = cfg.History
+	}
+	path := ""
+	if history.Persist && h.daemon == nil {
+		// With a daemon attached, it owns metrics.json.
+		path = pluginapi.MetricsPath()
+	}
+	if h.metrics == nil {
+		h.metrics = NewMetricStore(history.Samples, path)
+	} else {
+		h.metrics.Configure(history.Samples, path)
+	}
+	return h.metrics
 }
 
 // OpenPackageManager shows the package manager UI.
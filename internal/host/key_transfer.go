@@ -0,0 +1,97 @@
+package host
+
+import (
+	"maps"
+	"strings"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/ui"
+)
+
+// promptKeyTransfer asks for what dump_keys, restore_keys and copy_keys
+// need. The plugin confirms imports and copies itself, after a dry run.
+func (r *RPCRenderer) promptKeyTransfer(action string) {
+	payload := r.selectionPayload()
+	run := func(extra map[string]string) {
+		r.FocusTable()
+		maps.Copy(payload, extra)
+		r.runAction(action, payload)
+	}
+	switch action {
+	case "dump_keys":
+		r.promptChain("Dump Keys", []string{"Pattern (blank = marked or selected keys):"}, []string{""}, func(v []string) {
+			run(map[string]string{"pattern": strings.TrimSpace(v[0])})
+		})
+	case "restore_keys":
+		dir := pluginapi.PluginExportsDir(r.name) + "/"
+		r.promptChain("Import Keys", []string{"Dump file:", "Existing keys (skip/replace):"}, []string{dir, "skip"}, func(v []string) {
+			run(map[string]string{"file": strings.TrimSpace(v[0]), "policy": strings.TrimSpace(v[1])})
+		})
+	case "copy_keys":
+		r.pickCopyTarget(func(dest map[string]string) {
+			r.promptChain("Copy Keys", []string{"Pattern (blank = marked or selected keys):", "Existing keys (skip/replace):"}, []string{"", "skip"},
+				func(v []string) {
+					extra := map[string]string{"pattern": strings.TrimSpace(v[0]), "policy": strings.TrimSpace(v[1])}
+					for k, val := range dest {
+						extra["dest."+k] = val
+					}
+					run(extra)
+				})
+		})
+	}
+}
+
+// pickCopyTarget lists this plugin's other KeePass targets and passes the
+// chosen one's resolved settings to done, on the tview thread.
+func (r *RPCRenderer) pickCopyTarget(done func(map[string]string)) {
+	all, err := listSecretTargets(r.name)
+	if err != nil {
+		r.core.Log("[red]" + err.Error())
+		return
+	}
+	var targets []secretTarget
+	for _, t := range all {
+		if t.Path != r.targetPath {
+			targets = append(targets, t)
+		}
+	}
+	if len(targets) == 0 {
+		r.core.Log("[yellow]no other KeePass targets under " + r.name + "/")
+		return
+	}
+	items := make([][]string, len(targets))
+	for i, t := range targets {
+		items[i] = []string{t.Label, t.Detail}
+	}
+	ui.ShowStandardListSelectorModal(r.pages, r.app, "Copy keys to", items,
+		func(index int, _ string, cancelled bool) {
+			r.FocusTable()
+			if cancelled || index < 0 || index >= len(targets) {
+				return
+			}
+			target := targets[index]
+			if target.Err != nil {
+				r.core.Log("[red]" + target.Path + ": " + target.Err.Error())
+				return
+			}
+			// A credential helper may take a while; resolve off the UI thread.
+			go func() {
+				settings := target.Settings
+				var err error
+				if settings[credentialHelperAttr] != "" {
+					var entry *pluginapi.SecretEntry
+					if entry, err = pluginapi.Secrets().Get(target.Path); err == nil {
+						settings, err = configureSettings(target.Path, entry)
+					}
+				}
+				r.app.QueueUpdateDraw(func() {
+					if err != nil {
+						r.core.Log("[red]" + err.Error())
+						r.FocusTable()
+						return
+					}
+					done(settings)
+				})
+			}()
+		})
+}
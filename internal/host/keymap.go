@@ -0,0 +1,542 @@
+package host
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/pluginrpc"
+	"omo/pkg/ui"
+
+	"github.com/rivo/tview"
+	"gopkg.in/yaml.v3"
+)
+
+// Keymap is ~/.omo/keymap.yaml:
+//
+//	host:
+//	  dashboard: g d
+//	  packages: P
+//	  filter: [/, f]
+//	  target: ctrl+o
+//	plugins:
+//	  redis:
+//	    delete_key: d d
+//	    goto_info: g i
+//	    flush_db: none
+//
+// Host actions are remapped by id (see hostActions), plugin shortcuts by
+// their KeyBinding.Action, so the file keeps working when a plugin changes
+// its default keys. A key is one character, ctrl+x, space, or a chord of
+// those separated by spaces ("g g"); a list binds several keys and "none"
+// unbinds. Host keys win over plugin keys that clash with them.
+type Keymap struct {
+	host      map[string][]string
+	custom    map[string]bool                // host ids set in the file
+	plugins   map[string]map[string][]string // plugin -> action -> keys
+	conflicts []keyConflict
+	err       error // load error; the keymap is the default one
+}
+
+type keyScope int
+
+const (
+	scopeGlobal  keyScope = iota // everywhere outside modals
+	scopeSidebar                 // plugins list focused
+	scopePlugin                  // plugin pane focused
+)
+
+type hostAction struct {
+	id, label string
+	scope     keyScope
+	keys      []string
+}
+
+var hostActions = []hostAction{
+	{"target", "Switch target", scopeGlobal, []string{"^t"}},
+	{"export", "Export table", scopeGlobal, []string{"^e"}},
+	{"reload_plugins", "Refresh plugins", scopeSidebar, []string{"r", "R"}},
+	{"dashboard", "Dashboard", scopeSidebar, []string{"D"}},
+	{"packages", "Package manager", scopeSidebar, []string{"p", "P"}},
+	{"settings", "Settings / info", scopeSidebar, []string{"i", "I", "s", "S"}},
+	{"themes", "Themes", scopeSidebar, []string{"t", "T"}},
+	{"keys", "Key bindings", scopeSidebar, []string{"?"}},
+	{"refresh", "Refresh", scopePlugin, []string{"R"}},
+	{"filter", "Filter", scopePlugin, []string{"/"}},
+	{"help", "Help", scopePlugin, []string{"?"}},
+	{"mark", "Mark row", scopePlugin, []string{"space"}},
+	{"mark_all", "Mark all shown", scopePlugin, []string{"*"}},
+	{"sort", "Sort by column", scopePlugin, []string{"o"}},
+	{"columns", "Columns", scopePlugin, []string{"c"}},
+	{"detail", "Detail pane", scopePlugin, []string{"p"}},
+}
+
+func findHostAction(id string) (hostAction, bool) {
+	for _, a := range hostActions {
+		if a.id == id {
+			return a, true
+		}
+	}
+	return hostAction{}, false
+}
+
+// keyConflict is one clash found in the keymap, shown in the "?" help.
+type keyConflict struct {
+	Key    string
+	Detail string
+}
+
+// keyList is one key or a YAML list of keys.
+type keyList []string
+
+func (l *keyList) UnmarshalYAML(n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		*l = keyList{n.Value}
+		return nil
+	case yaml.SequenceNode:
+		var keys []string
+		if err := n.Decode(&keys); err != nil {
+			return err
+		}
+		*l = keys
+		return nil
+	}
+	return fmt.Errorf("line %d: want a key or a list of keys", n.Line)
+}
+
+type keymapFile struct {
+	Host    map[string]keyList            `yaml:"host"`
+	Plugins map[string]map[string]keyList `yaml:"plugins"`
+}
+
+// defaultKeymap is the built-in bindings.
+func defaultKeymap() *Keymap {
+	k := &Keymap{
+		host:    make(map[string][]string, len(hostActions)),
+		custom:  map[string]bool{},
+		plugins: map[string]map[string][]string{},
+	}
+	for _, a := range hostActions {
+		k.host[a.id] = a.keys
+	}
+	return k
+}
+
+// LoadKeymap reads ~/.omo/keymap.yaml. A missing file gives the default
+// keymap; a broken one gives the default keymap and the error, which the
+// "?" help keeps showing.
+func LoadKeymap() (*Keymap, error) {
+	data, err := os.ReadFile(pluginapi.KeymapPath())
+	if os.IsNotExist(err) {
+		return defaultKeymap(), nil
+	}
+	if err == nil {
+		var k *Keymap
+		if k, err = parseKeymap(data); err == nil {
+			return k, nil
+		}
+	}
+	k := defaultKeymap()
+	k.err = err
+	return k, err
+}
+
+func parseKeymap(data []byte) (*Keymap, error) {
+	var file keymapFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", pluginapi.KeymapPath(), err)
+	}
+	k := defaultKeymap()
+	for _, id := range sortedKeys(file.Host) {
+		if _, ok := findHostAction(id); !ok {
+			return nil, fmt.Errorf("%s: host: unknown action %q", pluginapi.KeymapPath(), id)
+		}
+		keys, err := normalizeKeys(file.Host[id])
+		if err != nil {
+			return nil, fmt.Errorf("%s: host.%s: %w", pluginapi.KeymapPath(), id, err)
+		}
+		k.host[id] = keys
+		k.custom[id] = true
+	}
+	for _, plugin := range sortedKeys(file.Plugins) {
+		actions := file.Plugins[plugin]
+		k.plugins[plugin] = make(map[string][]string, len(actions))
+		for _, action := range sortedKeys(actions) {
+			keys, err := normalizeKeys(actions[action])
+			if err != nil {
+				return nil, fmt.Errorf("%s: plugins.%s.%s: %w", pluginapi.KeymapPath(), plugin, action, err)
+			}
+			k.plugins[plugin][action] = keys
+		}
+	}
+	k.conflicts = k.detectConflicts()
+	return k, nil
+}
+
+func normalizeKeys(specs keyList) ([]string, error) {
+	keys := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		if s := strings.TrimSpace(spec); s == "" || strings.EqualFold(s, "none") {
+			continue
+		}
+		key, err := ui.NormalizeKeySpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// keysClash reports whether a and b are the same key or one is a chord
+// starting with the other (which then can never complete).
+func keysClash(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+" ") || strings.HasPrefix(b, a+" ")
+}
+
+func clash(a, b string, ownerA, ownerB string) keyConflict {
+	if a == b {
+		return keyConflict{a, fmt.Sprintf("%s and %s", ownerA, ownerB)}
+	}
+	if len(a) > len(b) {
+		a, b, ownerA, ownerB = b, a, ownerB, ownerA
+	}
+	return keyConflict{a, fmt.Sprintf("%s shadows %s on %s", ownerA, ownerB, b)}
+}
+
+type ownedKey struct {
+	key, owner string
+	scope      keyScope
+	custom     bool // set in the keymap file
+}
+
+func (k *Keymap) hostKeys(scopes ...keyScope) []ownedKey {
+	var out []ownedKey
+	for _, a := range hostActions {
+		for _, s := range scopes {
+			if a.scope != s {
+				continue
+			}
+			for _, key := range k.host[a.id] {
+				out = append(out, ownedKey{key, "host " + a.id, a.scope, k.custom[a.id]})
+			}
+		}
+	}
+	return out
+}
+
+func scopesOverlap(a, b keyScope) bool {
+	return a == b || a == scopeGlobal || b == scopeGlobal
+}
+
+// detectConflicts lists clashes between host actions, between plugin
+// overrides and host keys, and between overrides of one plugin. Clashes
+// with a plugin's default keys depend on the view and are found by
+// applyView.
+func (k *Keymap) detectConflicts() []keyConflict {
+	var out []keyConflict
+	host := k.hostKeys(scopeGlobal, scopeSidebar, scopePlugin)
+	for i, a := range host {
+		for _, b := range host[i+1:] {
+			if a.owner != b.owner && scopesOverlap(a.scope, b.scope) && keysClash(a.key, b.key) {
+				out = append(out, clash(a.key, b.key, a.owner, b.owner))
+			}
+		}
+	}
+	paneKeys := k.hostKeys(scopeGlobal, scopePlugin)
+	for _, plugin := range sortedKeys(k.plugins) {
+		var own []ownedKey
+		for _, action := range sortedKeys(k.plugins[plugin]) {
+			for _, key := range k.plugins[plugin][action] {
+				own = append(own, ownedKey{key, plugin + " " + action, scopePlugin, true})
+			}
+		}
+		for i, a := range own {
+			for _, h := range paneKeys {
+				if keysClash(a.key, h.key) {
+					out = append(out, clash(h.key, a.key, h.owner, a.owner))
+				}
+			}
+			for _, b := range own[i+1:] {
+				if a.owner != b.owner && keysClash(a.key, b.key) {
+					out = append(out, clash(a.key, b.key, a.owner, b.owner))
+				}
+			}
+		}
+	}
+	return out
+}
+
+// HostKeys returns the effective keys of a host action.
+func (k *Keymap) HostKeys(id string) []string {
+	return k.host[id]
+}
+
+// hostLookup reports bound/prefix for seq among the host actions active in
+// the sidebar or, with sidebar false, the rest of the UI.
+func (k *Keymap) hostLookup(seq string, sidebar bool) (bound, prefix bool) {
+	for _, owned := range k.hostKeys(k.activeScopes(sidebar)...) {
+		if owned.key == seq {
+			bound = true
+		} else if strings.HasPrefix(owned.key, seq+" ") {
+			prefix = true
+		}
+	}
+	return bound, prefix
+}
+
+// hostAction returns the host action bound to seq, or "".
+func (k *Keymap) hostAction(seq string, sidebar bool) string {
+	scopes := k.activeScopes(sidebar)
+	for _, a := range hostActions {
+		for _, s := range scopes {
+			if a.scope != s {
+				continue
+			}
+			for _, key := range k.host[a.id] {
+				if key == seq {
+					return a.id
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// activeScopes are the scopes main dispatches; plugin-pane keys belong to
+// the renderer.
+func (k *Keymap) activeScopes(sidebar bool) []keyScope {
+	if sidebar {
+		return []keyScope{scopeGlobal, scopeSidebar}
+	}
+	return []keyScope{scopeGlobal}
+}
+
+// pluginKeys returns the keys the user gave a plugin action, if any.
+func (k *Keymap) pluginKeys(plugin, action string) ([]string, bool) {
+	if action == "" {
+		return nil, false
+	}
+	keys, ok := k.plugins[plugin][action]
+	return keys, ok
+}
+
+// applyView moves a view's plugin bindings to their effective keys. A key
+// that clashes with a host key, or with a binding the user moved onto it,
+// is dropped; clashes involving the keymap file are returned so the help
+// can list them (default-key clashes are the plugin's business). Extra
+// keys of an action come back as aliases to bind without a header entry.
+func (k *Keymap) applyView(plugin string, view pluginrpc.ViewData) (pluginrpc.ViewData, []pluginrpc.KeyBinding, []keyConflict) {
+	type entry struct {
+		kb     pluginrpc.KeyBinding
+		keys   []string
+		custom bool
+		kept   []string
+	}
+	lists := []*[]pluginrpc.KeyBinding{&view.ViewBindings, &view.KeyBindings, &view.Actions}
+	entries := make([][]*entry, len(lists))
+	for i, l := range lists {
+		for _, kb := range *l {
+			e := &entry{kb: kb}
+			if keys, ok := k.pluginKeys(plugin, kb.Action); ok {
+				e.keys, e.custom = keys, true
+			} else if kb.Key != "" {
+				e.keys = []string{kb.Key}
+			}
+			entries[i] = append(entries[i], e)
+		}
+	}
+
+	type claim struct {
+		action string
+		custom bool
+		owner  string
+	}
+	host := k.hostKeys(scopeGlobal, scopePlugin)
+	claims := map[string]claim{}
+	var conflicts []keyConflict
+	seen := map[keyConflict]bool{}
+	report := func(c keyConflict) {
+		if !seen[c] {
+			seen[c] = true
+			conflicts = append(conflicts, c)
+		}
+	}
+	resolve := func(e *entry) {
+		owner := plugin + " " + e.kb.Action
+	keys:
+		for _, key := range e.keys {
+			for _, h := range host {
+				if keysClash(key, h.key) {
+					if e.custom || h.custom {
+						report(clash(h.key, key, h.owner, owner))
+					}
+					continue keys
+				}
+			}
+			for claimed, c := range claims {
+				if c.action == e.kb.Action || !keysClash(key, claimed) {
+					continue
+				}
+				if !e.custom && !c.custom {
+					continue // two defaults: the later binding wins, as before keymaps
+				}
+				report(clash(claimed, key, c.owner, owner))
+				continue keys
+			}
+			claims[key] = claim{e.kb.Action, e.custom, owner}
+			e.kept = append(e.kept, key)
+		}
+	}
+	// Keys the user chose claim first; defaults make do with what is left.
+	for _, custom := range []bool{true, false} {
+		for _, l := range entries {
+			for _, e := range l {
+				if e.custom == custom {
+					resolve(e)
+				}
+			}
+		}
+	}
+
+	var aliases []pluginrpc.KeyBinding
+	for i, l := range lists {
+		out := make([]pluginrpc.KeyBinding, 0, len(entries[i]))
+		for _, e := range entries[i] {
+			if len(e.kept) == 0 {
+				continue
+			}
+			kb := e.kb
+			kb.Key = e.kept[0]
+			out = append(out, kb)
+			for _, alias := range e.kept[1:] {
+				aliases = append(aliases, pluginrpc.KeyBinding{Key: alias, Label: kb.Label, Action: kb.Action})
+			}
+		}
+		*l = out
+	}
+	view.HelpSections = k.helpSections(plugin, view.HelpSections)
+	return view, aliases, conflicts
+}
+
+// helpSections rewrites the plugin's "?" help to the effective keys and
+// swaps its Global group for the host's.
+func (k *Keymap) helpSections(plugin string, sections []pluginrpc.HelpSection) []pluginrpc.HelpSection {
+	out := make([]pluginrpc.HelpSection, 0, len(sections))
+	for _, s := range sections {
+		if s.Title == pluginrpc.GlobalHelpSection().Title {
+			out = append(out, k.globalHelp())
+			continue
+		}
+		bindings := make([]pluginrpc.KeyBinding, 0, len(s.Bindings))
+		for _, b := range s.Bindings {
+			if keys, ok := k.pluginKeys(plugin, b.Action); ok {
+				if len(keys) == 0 {
+					continue
+				}
+				b.Key = strings.Join(keys, ", ")
+			}
+			if b.Action != "" {
+				id := b.Action
+				if b.Batch {
+					id += " · batch"
+				}
+				b.Label += " [gray]" + id + "[white]"
+			}
+			bindings = append(bindings, b)
+		}
+		s.Bindings = bindings
+		out = append(out, s)
+	}
+	return out
+}
+
+func (k *Keymap) globalHelp() pluginrpc.HelpSection {
+	labels := map[string]string{"help": "Help (this screen)"}
+	var bindings []pluginrpc.KeyBinding
+	for _, id := range []string{"refresh", "help", "filter", "target", "export", "mark", "mark_all", "sort", "columns", "detail"} {
+		a, _ := findHostAction(id)
+		label := a.label
+		if l, ok := labels[id]; ok {
+			label = l
+		}
+		if keys := k.host[id]; len(keys) > 0 {
+			bindings = append(bindings, pluginrpc.KeyBinding{Key: strings.Join(keys, ", "), Label: label})
+		}
+	}
+	bindings = append(bindings, pluginrpc.KeyBinding{Key: "ESC", Label: "Clear marks / back / home"})
+	return pluginrpc.HelpSection{Title: pluginrpc.GlobalHelpSection().Title, Bindings: bindings}
+}
+
+// problemsHelp is the "?" section listing the load error and clashes, or
+// false when the keymap is clean.
+func (k *Keymap) problemsHelp(extra []keyConflict) (pluginrpc.HelpSection, bool) {
+	var bindings []pluginrpc.KeyBinding
+	if k.err != nil {
+		bindings = append(bindings, pluginrpc.KeyBinding{Key: "!", Label: k.err.Error() + " (using defaults)"})
+	}
+	for _, c := range append(append([]keyConflict(nil), k.conflicts...), extra...) {
+		bindings = append(bindings, pluginrpc.KeyBinding{Key: c.Key, Label: c.Detail})
+	}
+	if len(bindings) == 0 {
+		return pluginrpc.HelpSection{}, false
+	}
+	return pluginrpc.HelpSection{Title: "Keymap conflicts", Bindings: bindings}, true
+}
+
+// Conflicts describes the clashes found when the keymap was loaded.
+func (k *Keymap) Conflicts() []string {
+	out := make([]string, len(k.conflicts))
+	for i, c := range k.conflicts {
+		out[i] = c.Key + ": " + c.Detail
+	}
+	return out
+}
+
+// helpText is the sidebar "?" modal: every host action with its keys.
+func (k *Keymap) helpText() string {
+	var sb strings.Builder
+	scopes := []struct {
+		scope keyScope
+		title string
+	}{
+		{scopeGlobal, "Global"},
+		{scopeSidebar, "Plugins list"},
+		{scopePlugin, "Inside a plugin"},
+	}
+	for _, s := range scopes {
+		sb.WriteString(fmt.Sprintf("[yellow]%s[white]\n", s.title))
+		for _, a := range hostActions {
+			if a.scope != s.scope {
+				continue
+			}
+			keys := "unbound"
+			if len(k.host[a.id]) > 0 {
+				keys = tview.Escape(strings.Join(k.host[a.id], ", "))
+			}
+			sb.WriteString(fmt.Sprintf("  %-16s %-16s %s\n", a.id, keys, a.label))
+		}
+		sb.WriteString("\n")
+	}
+	if section, ok := k.problemsHelp(nil); ok {
+		sb.WriteString(fmt.Sprintf("[red]%s[white]\n", section.Title))
+		for _, b := range section.Bindings {
+			sb.WriteString(fmt.Sprintf("  %-8s %s\n", tview.Escape(b.Key), tview.Escape(b.Label)))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("[gray]Remap in %s (host ids above; plugin actions by id).[white]", pluginapi.KeymapPath()))
+	return sb.String()
+}
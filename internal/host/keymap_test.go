@@ -0,0 +1,155 @@
+package host
+
+import (
+	"strings"
+	"testing"
+
+	"omo/pkg/pluginrpc"
+)
+
+func TestParseKeymap(t *testing.T) {
+	k, err := parseKeymap([]byte(`
+host:
+  dashboard: g d
+  filter: [/, f]
+  target: ctrl+o
+plugins:
+  redis:
+    delete_key: d d
+    flush_db: none
+`))
+	if err != nil {
+		t.Fatalf("parseKeymap: %v", err)
+	}
+	if got := strings.Join(k.HostKeys("filter"), ","); got != "/,f" {
+		t.Fatalf("filter keys = %q", got)
+	}
+	if got := k.HostKeys("target"); len(got) != 1 || got[0] != "^o" {
+		t.Fatalf("target keys = %q", got)
+	}
+	if keys, ok := k.pluginKeys("redis", "flush_db"); !ok || len(keys) != 0 {
+		t.Fatalf("flush_db = %q, %v; want unbound", keys, ok)
+	}
+	if len(k.conflicts) != 0 {
+		t.Fatalf("conflicts = %+v", k.conflicts)
+	}
+
+	if bound, prefix := k.hostLookup("g", true); bound || !prefix {
+		t.Fatalf("hostLookup(g) = %v, %v; want chord prefix", bound, prefix)
+	}
+	if got := k.hostAction("g d", true); got != "dashboard" {
+		t.Fatalf("hostAction(g d) = %q", got)
+	}
+	if got := k.hostAction("g d", false); got != "" {
+		t.Fatalf("sidebar key fired outside the sidebar: %q", got)
+	}
+
+	for _, bad := range []string{
+		"host: {nope: x}",
+		"host: {target: ctrl+1}",
+		"host: {target: ctrl+i}",
+		"plugins: {redis: {x: hyper+x}}",
+	} {
+		if _, err := parseKeymap([]byte(bad)); err == nil {
+			t.Fatalf("parseKeymap(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestKeymapConflictsAtLoad(t *testing.T) {
+	k, err := parseKeymap([]byte(`
+host:
+  themes: D
+  refresh: g
+plugins:
+  redis:
+    delete_key: "?"
+    view_key: g g
+`))
+	if err != nil {
+		t.Fatalf("parseKeymap: %v", err)
+	}
+	got := strings.Join(k.Conflicts(), "\n")
+	for _, want := range []string{
+		"D: host dashboard and host themes",
+		"?: host help and redis delete_key",
+		"g: host refresh shadows redis view_key on g g",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("conflicts missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "host keys") {
+		t.Fatalf("sidebar ? clashed with the plugin pane ?:\n%s", got)
+	}
+}
+
+func TestKeymapApplyView(t *testing.T) {
+	k, err := parseKeymap([]byte(`
+host:
+  filter: f
+plugins:
+  redis:
+    goto_info: g i
+    delete_key: [x, d]
+    flush_db: none
+`))
+	if err != nil {
+		t.Fatalf("parseKeymap: %v", err)
+	}
+	view := pluginrpc.ViewData{
+		ViewBindings: []pluginrpc.KeyBinding{{Key: "1", Label: "Info", Action: "goto_info"}},
+		Actions: []pluginrpc.KeyBinding{
+			{Key: "D", Label: "Delete", Action: "delete_key"},
+			{Key: "x", Label: "Expire", Action: "expire_key"},
+			{Key: "F", Label: "Flush", Action: "flush_db"},
+			{Key: "f", Label: "Find", Action: "find"},
+			{Key: "R", Label: "Reload", Action: "reload"},
+		},
+		HelpSections: []pluginrpc.HelpSection{
+			{Title: "Keys", Bindings: []pluginrpc.KeyBinding{{Key: "D", Label: "Delete", Action: "delete_key"}}},
+			pluginrpc.GlobalHelpSection(),
+		},
+	}
+	out, aliases, conflicts := k.applyView("redis", view)
+
+	if got := out.ViewBindings[0].Key; got != "g i" {
+		t.Fatalf("goto_info key = %q, want g i", got)
+	}
+	keys := map[string]string{}
+	for _, kb := range out.Actions {
+		keys[kb.Action] = kb.Key
+	}
+	if keys["delete_key"] != "x" || len(aliases) != 1 || aliases[0].Key != "d" {
+		t.Fatalf("delete_key = %q, aliases = %+v", keys["delete_key"], aliases)
+	}
+	if _, ok := keys["expire_key"]; ok {
+		t.Fatal("expire_key kept x after delete_key was remapped onto it")
+	}
+	if _, ok := keys["flush_db"]; ok {
+		t.Fatal("flush_db still bound after none")
+	}
+	if _, ok := keys["find"]; ok {
+		t.Fatal("plugin f kept over the remapped host filter")
+	}
+	if _, ok := keys["reload"]; ok {
+		t.Fatal("plugin R kept over host refresh")
+	}
+	if len(conflicts) != 2 {
+		t.Fatalf("conflicts = %+v, want expire_key and find", conflicts)
+	}
+
+	if got := out.HelpSections[0].Bindings[0].Key; got != "x, d" {
+		t.Fatalf("help key = %q, want x, d", got)
+	}
+	global := out.HelpSections[1]
+	found := false
+	for _, b := range global.Bindings {
+		if b.Label == "Filter" && b.Key == "f" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Global help = %+v, want Filter on f", global.Bindings)
+	}
+}
@@ -0,0 +1,63 @@
+package host
+
+import (
+	"omo/pkg/ui"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// loadKeymap (re)reads ~/.omo/keymap.yaml and hands it to the plugin views.
+func (h *Host) loadKeymap() {
+	k, err := LoadKeymap()
+	if err != nil {
+		h.log("keymap: %v (using defaults)", err)
+	}
+	for _, c := range k.Conflicts() {
+		h.log("keymap conflict: %s", c)
+	}
+	h.keymap = k
+	h.chord.Reset()
+	h.rpcManager.SetKeymap(k)
+}
+
+// HandleKey runs the host shortcut bound to event, if any. sidebar reports
+// whether the plugins list has focus; plugin-pane keys (refresh, filter,
+// help) are left to the plugin view. It returns false for keys the host
+// does not own.
+func (h *Host) HandleKey(event *tcell.EventKey, sidebar bool) bool {
+	if h.keymap == nil {
+		return false
+	}
+	seq, consumed := h.chord.Feed(ui.KeyName(event), func(seq string) (bool, bool) {
+		return h.keymap.hostLookup(seq, sidebar)
+	})
+	if seq == "" {
+		return consumed
+	}
+	switch h.keymap.hostAction(seq, sidebar) {
+	case "target":
+		h.SelectTarget()
+	case "export":
+		h.ExportTable()
+	case "reload_plugins":
+		h.RefreshPlugins()
+	case "dashboard":
+		h.OpenDashboard()
+	case "packages":
+		h.OpenPackageManager()
+	case "settings":
+		h.OpenSettings()
+	case "themes":
+		h.OpenThemes()
+	case "keys":
+		h.ShowKeymap()
+	}
+	return true
+}
+
+// ShowKeymap lists the host key bindings and any keymap conflicts.
+func (h *Host) ShowKeymap() {
+	ui.ShowInfoModal(h.Pages, h.App, "Key bindings", h.keymap.helpText(), func() {
+		h.App.SetFocus(h.PluginsList)
+	})
+}
@@ -0,0 +1,308 @@
+package host
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"omo/pkg/pluginrpc"
+)
+
+// Dashboard widgets mark numeric values with pluginrpc.Metric. Every pulse
+// appends one sample per metric to a ring buffer keyed by tile and metric
+// name; cards draw the retained window as a sparkline with min/max/avg.
+// With history.persist the buffers survive restarts in ~/.omo/metrics.json.
+
+const (
+	defaultMetricSamples = 120
+	maxMetricSamples     = 2880
+	sparklineWidth       = 10
+)
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+type metricSample struct {
+	At    time.Time `json:"t"`
+	Value float64   `json:"v"`
+}
+
+// metricRing keeps the newest len(buf) samples.
+type metricRing struct {
+	buf   []metricSample
+	start int
+	n     int
+}
+
+func newMetricRing(capacity int) *metricRing {
+	return &metricRing{buf: make([]metricSample, capacity)}
+}
+
+func (r *metricRing) add(s metricSample) {
+	if len(r.buf) == 0 {
+		return
+	}
+	if r.n < len(r.buf) {
+		r.buf[(r.start+r.n)%len(r.buf)] = s
+		r.n++
+		return
+	}
+	r.buf[r.start] = s
+	r.start = (r.start + 1) % len(r.buf)
+}
+
+// samples returns the retained samples, oldest first.
+func (r *metricRing) samples() []metricSample {
+	out := make([]metricSample, r.n)
+	for i := range out {
+		out[i] = r.buf[(r.start+i)%len(r.buf)]
+	}
+	return out
+}
+
+// MetricStore holds the sample history of every dashboard tile metric.
+type MetricStore struct {
+	mu       sync.Mutex
+	capacity int
+	path     string // empty: memory only
+	series   map[string]*metricRing
+	units    map[string]string
+	dirty    int // samples recorded since the last successful save
+}
+
+// NewMetricStore keeps capacity samples per metric. A non-empty path loads
+// earlier history from it and is where Save writes.
+func NewMetricStore(capacity int, path string) *MetricStore {
+	s := &MetricStore{series: map[string]*metricRing{}, units: map[string]string{}}
+	s.Configure(capacity, path)
+	return s
+}
+
+// Configure applies a new sample count and persistence path, keeping the
+// newest samples already held.
+func (s *MetricStore) Configure(capacity int, path string) {
+	if capacity <= 0 {
+		capacity = defaultMetricSamples
+	}
+	if capacity > maxMetricSamples {
+		capacity = maxMetricSamples
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if capacity != s.capacity {
+		s.capacity = capacity
+		for key, ring := range s.series {
+			s.series[key] = ringOf(ring.samples(), capacity)
+		}
+	}
+	if path != "" && path != s.path {
+		s.loadLocked(path)
+	}
+	s.path = path
+}
+
+func ringOf(samples []metricSample, capacity int) *metricRing {
+	r := newMetricRing(capacity)
+	for _, sample := range samples {
+		r.add(sample)
+	}
+	return r
+}
+
+// metricKey is the series key for one metric of a tile.
+func metricKey(tile DashboardTile, metric string) string {
+	return tileSessionKey(tile.Plugin, tile.Target, tile.View) + "/" + metric
+}
+
+// Record appends one sample per metric of tile.
+func (s *MetricStore) Record(tile DashboardTile, metrics []pluginrpc.Metric, at time.Time) {
+	if s == nil || len(metrics) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, m := range metrics {
+		if m.Name == "" || math.IsNaN(m.Value) || math.IsInf(m.Value, 0) {
+			continue
+		}
+		key := metricKey(tile, m.Name)
+		ring := s.series[key]
+		if ring == nil {
+			ring = newMetricRing(s.capacity)
+			s.series[key] = ring
+		}
+		ring.add(metricSample{At: at, Value: m.Value})
+		s.units[key] = m.Unit
+		s.dirty++
+	}
+}
+
+// Replace swaps a tile's series for history kept elsewhere (the daemon).
+func (s *MetricStore) Replace(tile DashboardTile, history map[string][]float64) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name, values := range history {
+		ring := newMetricRing(s.capacity)
+		for _, v := range values {
+			ring.add(metricSample{Value: v})
+		}
+		s.series[metricKey(tile, name)] = ring
+	}
+}
+
+// Values returns the retained values of one tile metric, oldest first.
+func (s *MetricStore) Values(tile DashboardTile, metric string) []float64 {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ring := s.series[metricKey(tile, metric)]
+	if ring == nil {
+		return nil
+	}
+	samples := ring.samples()
+	values := make([]float64, len(samples))
+	for i, sample := range samples {
+		values[i] = sample.Value
+	}
+	return values
+}
+
+type metricFileSeries struct {
+	Unit    string         `json:"unit,omitempty"`
+	Samples []metricSample `json:"samples"`
+}
+
+func (s *MetricStore) loadLocked(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var file map[string]metricFileSeries
+	if err := json.Unmarshal(data, &file); err != nil {
+		pluginrpc.RPCLog("metrics: ignore %s: %v", path, err)
+		return
+	}
+	for key, fs := range file {
+		if _, ok := s.series[key]; ok {
+			continue
+		}
+		s.series[key] = ringOf(fs.Samples, s.capacity)
+		s.units[key] = fs.Unit
+	}
+}
+
+// Save writes the history to the store's path when it has one and something
+// changed since the last save.
+func (s *MetricStore) Save() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	if s.path == "" || s.dirty == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	file := make(map[string]metricFileSeries, len(s.series))
+	for key, ring := range s.series {
+		file[key] = metricFileSeries{Unit: s.units[key], Samples: ring.samples()}
+	}
+	path, saved := s.path, s.dirty
+	s.mu.Unlock()
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	// Samples recorded while writing stay dirty for the next save.
+	s.mu.Lock()
+	s.dirty -= saved
+	s.mu.Unlock()
+	return nil
+}
+
+// sparkline draws the last width values scaled between their min and max.
+func sparkline(values []float64, width int) string {
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi, _ := metricStats(values)
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if hi > lo {
+			i = int(math.Round((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1)))
+		}
+		b.WriteRune(sparkBlocks[i])
+	}
+	return b.String()
+}
+
+func metricStats(values []float64) (lo, hi, avg float64) {
+	if len(values) == 0 {
+		return 0, 0, 0
+	}
+	lo, hi = values[0], values[0]
+	var sum float64
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+		sum += v
+	}
+	return lo, hi, sum / float64(len(values))
+}
+
+// formatMetric renders a value in its unit: bytes in binary multiples, rates
+// and counts with k/M suffixes.
+func formatMetric(v float64, unit string) string {
+	switch unit {
+	case "B":
+		const k = 1024
+		if math.Abs(v) < k {
+			return fmt.Sprintf("%.0fB", v)
+		}
+		exp := 0
+		for math.Abs(v) >= k && exp < 5 {
+			v /= k
+			exp++
+		}
+		return fmt.Sprintf("%.1f%cB", v, "KMGTP"[exp-1])
+	case "%":
+		return fmt.Sprintf("%.1f%%", v)
+	}
+	var s string
+	switch a := math.Abs(v); {
+	case a >= 1e9:
+		s = fmt.Sprintf("%.1fG", v/1e9)
+	case a >= 1e6:
+		s = fmt.Sprintf("%.1fM", v/1e6)
+	case a >= 1e4:
+		s = fmt.Sprintf("%.1fk", v/1e3)
+	case v == math.Trunc(v):
+		s = fmt.Sprintf("%.0f", v)
+	default:
+		s = fmt.Sprintf("%.2f", v)
+	}
+	return s + unit
+}
@@ -0,0 +1,104 @@
+package host
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"omo/pkg/pluginrpc"
+)
+
+func TestMetricStoreKeepsNewestSamples(t *testing.T) {
+	store := NewMetricStore(3, "")
+	tile := DashboardTile{Plugin: "redis", Target: "redis/prod"}
+	at := time.Unix(1700000000, 0)
+	for i := 1; i <= 5; i++ {
+		store.Record(tile, []pluginrpc.Metric{{Name: "Memory", Value: float64(i), Unit: "B"}}, at.Add(time.Duration(i)*time.Second))
+	}
+	got := store.Values(tile, "Memory")
+	if len(got) != 3 || got[0] != 3 || got[2] != 5 {
+		t.Fatalf("Values = %v, want [3 4 5]", got)
+	}
+	if other := store.Values(DashboardTile{Plugin: "redis"}, "Memory"); other != nil {
+		t.Fatalf("untargeted tile shares history: %v", other)
+	}
+
+	store.Configure(2, "")
+	if got := store.Values(tile, "Memory"); len(got) != 2 || got[0] != 4 {
+		t.Fatalf("after shrink Values = %v, want [4 5]", got)
+	}
+}
+
+func TestMetricStorePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	tile := DashboardTile{Plugin: "docker"}
+	store := NewMetricStore(10, path)
+	store.Record(tile, []pluginrpc.Metric{{Name: "Running", Value: 7}}, time.Now())
+	store.Record(tile, []pluginrpc.Metric{{Name: "Running", Value: 9}}, time.Now())
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded := NewMetricStore(10, path)
+	if got := loaded.Values(tile, "Running"); len(got) != 2 || got[1] != 9 {
+		t.Fatalf("loaded Values = %v, want [7 9]", got)
+	}
+}
+
+func TestMetricStoreStaysDirtyWhenSaveFails(t *testing.T) {
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "state")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(blocker, "metrics.json")
+	tile := DashboardTile{Plugin: "docker"}
+	store := NewMetricStore(10, path)
+	store.Record(tile, []pluginrpc.Metric{{Name: "Running", Value: 7}}, time.Now())
+	if err := store.Save(); err == nil {
+		t.Fatalf("Save under a file succeeded, want error")
+	}
+
+	if err := os.Remove(blocker); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save after failure: %v", err)
+	}
+	if got := NewMetricStore(10, path).Values(tile, "Running"); len(got) != 1 || got[0] != 7 {
+		t.Fatalf("loaded Values = %v, want [7]", got)
+	}
+	if store.dirty != 0 {
+		t.Fatalf("dirty = %d after a successful save, want 0", store.dirty)
+	}
+}
+
+func TestSparklineAndFormatMetric(t *testing.T) {
+	if got := sparkline([]float64{0, 1, 2, 3, 4, 5, 6, 7}, 10); got != "▁▂▃▄▅▆▇█" {
+		t.Fatalf("sparkline = %q", got)
+	}
+	if got := sparkline([]float64{9, 1, 5, 5}, 2); got != "▁▁" {
+		t.Fatalf("flat sparkline = %q, want %q", got, "▁▁")
+	}
+	lo, hi, avg := metricStats([]float64{2, 4, 9})
+	if lo != 2 || hi != 9 || avg != 5 {
+		t.Fatalf("metricStats = %v %v %v, want 2 9 5", lo, hi, avg)
+	}
+	for _, tc := range []struct {
+		v    float64
+		unit string
+		want string
+	}{
+		{512, "B", "512B"},
+		{1536, "B", "1.5KB"},
+		{3 << 30, "B", "3.0GB"},
+		{42, "", "42"},
+		{12500, "", "12.5k"},
+		{12.5, "%", "12.5%"},
+		{3.5, "/s", "3.50/s"},
+	} {
+		if got := formatMetric(tc.v, tc.unit); got != tc.want {
+			t.Fatalf("formatMetric(%v, %q) = %q, want %q", tc.v, tc.unit, got, tc.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package host
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -33,10 +34,15 @@ type PluginSession struct {
 	Cached     *pluginrpc.ViewData
 	Dashboard  *pluginrpc.ViewData
 	Renderer   *RPCRenderer
+	TargetPath string // KeePass entry the plugin was last configured with
 	LastUsed   time.Time
 	LastError  string
 	loading    bool
 	pulseMu    sync.Mutex
+
+	// CredentialExpiry is when the credential helper result the plugin was
+	// configured with runs out; zero when there is none.
+	CredentialExpiry time.Time
 }
 
 // PluginManager tracks per-plugin RPC connections (pattern 2: lazy-connect, keep warm).
@@ -54,6 +60,11 @@ type PluginManager struct {
 	onHeader  func(tview.Primitive)
 	logo      tview.Primitive
 	logoCore  *ui.CoreView
+	preferred map[string]string // plugin → KeePass target for its next activation
+	keymap    *Keymap
+	columns   ColumnPrefs
+	accents   AccentRules
+	onAccent  func(string)
 }
 
 func newPluginManager(app *tview.Application, pages *tview.Pages, logFn func(string, ...interface{})) *PluginManager {
@@ -64,6 +75,7 @@ func newPluginManager(app *tview.Application, pages *tview.Pages, logFn func(str
 		pages:    pages,
 		sessions: make(map[string]*PluginSession),
 		logFn:    logFn,
+		columns:  loadColumnPrefs(logFn),
 	}
 }
 
@@ -82,6 +94,54 @@ func (m *PluginManager) SetHomeHook(fn func()) {
 	m.onHome = fn
 }
 
+// SetKeymap applies user key remaps to every plugin view from its next paint.
+func (m *PluginManager) SetKeymap(k *Keymap) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keymap = k
+	for _, sess := range m.sessions {
+		if sess.Renderer != nil {
+			sess.Renderer.SetKeymap(k)
+		}
+	}
+}
+
+// SetAccentHook wires the host frame color to the active plugin's target.
+func (m *PluginManager) SetAccentHook(fn func(color string)) {
+	m.onAccent = fn
+}
+
+// SetAccentRules replaces the target accent rules; plugin views pick them up
+// on their next paint.
+func (m *PluginManager) SetAccentRules(rules AccentRules) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.accents = rules
+	for _, sess := range m.sessions {
+		if sess.Renderer != nil {
+			sess.Renderer.accentTarget = "\x00"
+		}
+	}
+}
+
+// publishAccent sends the accent of r's target to the host when r is the
+// active plugin view and its target changed since the last call.
+func (m *PluginManager) publishAccent(r *RPCRenderer) {
+	if m.onAccent == nil || r == nil || r.accentTarget == r.targetPath {
+		return
+	}
+	m.mu.Lock()
+	sess := m.sessions[m.active]
+	active := sess != nil && sess.Renderer == r
+	color := m.accents.For(r.targetPath)
+	m.mu.Unlock()
+	if !active {
+		return
+	}
+	r.accentTarget = r.targetPath
+	m.onAccent(color)
+}
+
 // SetLogo places the host mark in the active plugin header (top right).
 func (m *PluginManager) SetLogo(logo tview.Primitive) {
 	m.logo = logo
@@ -124,6 +184,8 @@ func (m *PluginManager) attachChrome(renderer *RPCRenderer) {
 	if m.onHeader != nil {
 		m.onHeader(renderer.core.DetachHeader())
 	}
+	renderer.accentTarget = "\x00"
+	m.publishAccent(renderer)
 }
 
 // ReattachActiveChrome remounts the active plugin header and logo after a
@@ -180,8 +242,11 @@ func (m *PluginManager) Activate(name, binPath string) (tview.Primitive, error)
 		renderer.SetActionsHook(m.onActions)
 		renderer.SetMoodHook(m.onMood)
 		renderer.SetHomeHook(m.onHome)
+		renderer.SetAccentHook(m.publishAccent)
 		m.attachChrome(renderer)
 		m.mu.Lock()
+		renderer.SetKeymap(m.keymap)
+		renderer.SetColumnPrefs(m.columns)
 		if m.sessions[name] == sess {
 			sess.Renderer = renderer
 		}
@@ -269,7 +334,7 @@ func (m *PluginManager) activateAsync(name, binPath string) {
 		sess.Client = lr.client
 		sess.Plugin = lr.plugin
 		if sess.Renderer != nil {
-			sess.Renderer.SetPlugin(lr.plugin)
+			sess.Renderer.SetPlugin(refreshingPlugin{Plugin: lr.plugin, m: m, sess: sess})
 		}
 		m.mu.Unlock()
 	}
@@ -286,11 +351,25 @@ func (m *PluginManager) activateAsync(name, binPath string) {
 
 	pluginrpc.RPCLog("activateAsync: resolvePluginConfig …")
 	t0 := time.Now()
-	cfg, cfgErr := resolvePluginConfig(name)
+	preferred := m.takePreferredTarget(name)
+	var (
+		cfgPath string
+		cfg     map[string]string
+		cfgErr  error
+	)
+	if preferred != "" {
+		if pluginapi.HasSecrets() {
+			_ = pluginapi.Secrets().Reload()
+		}
+		cfgPath = preferred
+		cfg, cfgErr = targetSettings(preferred)
+	} else {
+		cfgPath, cfg, cfgErr = resolvePluginTarget(name, true)
+	}
 	pluginrpc.RPCLog("activateAsync: resolvePluginConfig done in %s err=%v cfg_host=%s", time.Since(t0), cfgErr, cfg["host"])
 	if cfgErr != nil {
 		pluginrpc.RPCLog("activateAsync: config warn: %v", cfgErr)
-	} else if warm && sess.Configured {
+	} else if warm && sess.Configured && (preferred == "" || preferred == sess.TargetPath) && !m.credentialDue(sess) {
 		// Keep-warm sessions (e.g. k8sportforward tunnels) must not be reconfigured
 		// on every sidebar click — Configure often resets plugin state.
 		// Ctrl+t target switch still calls Configure directly via applyTarget.
@@ -301,7 +380,7 @@ func (m *PluginManager) activateAsync(name, binPath string) {
 			m.failSession(name, fmt.Errorf("configure: %w", err))
 			return
 		}
-		sess.Configured = true
+		m.markConfigured(sess, cfgPath)
 	}
 
 	pluginrpc.RPCLog("activateAsync: GetView …")
@@ -332,6 +411,10 @@ func (m *PluginManager) activateAsync(name, binPath string) {
 		if renderer != nil {
 			renderer.Apply(view)
 			renderer.FocusTable()
+			var helperErr *credentialHelperError
+			if errors.As(cfgErr, &helperErr) && renderer.core != nil {
+				renderer.core.Log("[red]" + helperErr.Error())
+			}
 		}
 		pluginrpc.RPCLog("activateAsync: Apply done")
 	})
@@ -339,14 +422,54 @@ func (m *PluginManager) activateAsync(name, binPath string) {
 	m.log("activated RPC plugin %s %s", name, meta.Version)
 }
 
+// PreferTarget makes the next activation of name configure it with the
+// KeePass entry at path (opening a dashboard tile pinned to a target).
+func (m *PluginManager) PreferTarget(name, path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.preferred == nil {
+		m.preferred = map[string]string{}
+	}
+	m.preferred[name] = path
+}
+
+func (m *PluginManager) takePreferredTarget(name string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	path := m.preferred[name]
+	delete(m.preferred, name)
+	return path
+}
+
 // DashboardSnapshot returns a live, compact plugin summary without making the
 // plugin active. Calls for one plugin are serialized with normal activation.
 func (m *PluginManager) DashboardSnapshot(name, binPath string) pluginrpc.ViewData {
+	return m.DashboardTileSnapshot(name, binPath, "", "", 4)
+}
+
+// tileSessionKey names the session backing a dashboard tile. Tiles pinned to
+// a target or a non-dashboard view get their own plugin process so they
+// never reconfigure or re-route the session the user works in.
+func tileSessionKey(name, target, view string) string {
+	if target == "" && (view == "" || view == pluginrpc.DashboardView) {
+		return name
+	}
+	return name + "@" + target + "#" + view
+}
+
+// DashboardTileSnapshot is DashboardSnapshot for a configured tile: target
+// pins the KeePass entry, view picks a plugin view (default: the dashboard
+// widget) and maxRows bounds the rows kept.
+func (m *PluginManager) DashboardTileSnapshot(name, binPath, target, viewID string, maxRows int) pluginrpc.ViewData {
+	if viewID == "" {
+		viewID = pluginrpc.DashboardView
+	}
+	key := tileSessionKey(name, target, viewID)
 	m.mu.Lock()
-	sess := m.sessions[name]
+	sess := m.sessions[key]
 	if sess == nil {
 		sess = &PluginSession{Name: name, BinPath: binPath, State: ConnPaused}
-		m.sessions[name] = sess
+		m.sessions[key] = sess
 	}
 	m.mu.Unlock()
 
@@ -371,7 +494,7 @@ func (m *PluginManager) DashboardSnapshot(name, binPath string) pluginrpc.ViewDa
 				return m.dashboardError(name, "launch", result.err)
 			}
 			m.mu.Lock()
-			if m.sessions[name] != sess {
+			if m.sessions[key] != sess {
 				m.mu.Unlock()
 				result.client.Kill()
 				return m.dashboardError(name, "launch", fmt.Errorf("session replaced"))
@@ -391,9 +514,38 @@ func (m *PluginManager) DashboardSnapshot(name, binPath string) pluginrpc.ViewDa
 		}
 	}
 
+	if err := m.refreshCredentialLocked(sess); err != nil {
+		pluginrpc.RPCLog("dashboard %s: %v", name, err)
+	}
 	if !sess.Configured {
-		cfg, err := resolvePluginConfigWithReload(name, false)
-		if err != nil {
+		var (
+			cfgPath string
+			cfg     map[string]string
+			cfgErr  error
+		)
+		if target != "" {
+			cfgPath = target
+			cfg, cfgErr = targetSettings(target)
+			if cfgErr != nil && !errors.As(cfgErr, new(*credentialHelperError)) {
+				return m.dashboardStatus(name, "not configured", cfgErr.Error())
+			}
+		} else {
+			cfgPath, cfg, cfgErr = resolvePluginTarget(name, false)
+		}
+		var helperErr *credentialHelperError
+		if errors.As(cfgErr, &helperErr) {
+			// A failing credential helper is the real reason; don't mask it
+			// with the plugin's generic missing-settings error.
+			rows := [][2]string{
+				{"Status", "not configured"},
+				{"Helper", pluginrpc.Truncate(helperErr.Err.Error(), 60)},
+			}
+			if helperErr.Stderr != "" {
+				rows = append(rows, [2]string{"Stderr", pluginrpc.Truncate(helperErr.Stderr, 60)})
+			}
+			return pluginrpc.Widget(name, "not configured", "", rows)
+		}
+		if cfgErr != nil {
 			// Config-free plugins (for example system process inspection) can
 			// still provide a live widget. Required-config plugins reject this
 			// empty Configure and become a clear not-configured tile.
@@ -402,16 +554,22 @@ func (m *PluginManager) DashboardSnapshot(name, binPath string) pluginrpc.ViewDa
 		if err := sess.Plugin.Configure(pluginrpc.ConfigureRequest{Settings: cfg}); err != nil {
 			return m.dashboardStatus(name, "not configured", err.Error())
 		}
-		sess.Configured = true
+		m.markConfigured(sess, cfgPath)
 	}
 
+	requested := viewID
 	view, err := withTimeout(8*time.Second, func() (pluginrpc.ViewData, error) {
-		return sess.Plugin.GetView(pluginrpc.ViewRequest{View: pluginrpc.DashboardView})
+		return sess.Plugin.GetView(pluginrpc.ViewRequest{View: requested})
 	})
 	if err != nil {
 		return m.dashboardError(name, "widget", err)
 	}
-	if view.View != "" && view.View != pluginrpc.DashboardView {
+	if requested != pluginrpc.DashboardView {
+		if view.View != "" && view.View != requested {
+			return m.dashboardStatus(name, "error", "unknown view "+requested)
+		}
+		view = tableWidget(name, view)
+	} else if view.View != "" && view.View != pluginrpc.DashboardView {
 		// Legacy plugins usually route unknown views to their default table.
 		// Use that live result as a generic widget, then restore its real view so
 		// opening the plugin later does not inherit "dashboard" as currentView.
@@ -439,8 +597,11 @@ func (m *PluginManager) DashboardSnapshot(name, binPath string) pluginrpc.ViewDa
 	view.KeyBindings = nil
 	view.Actions = nil
 	view.HelpSections = nil
-	if len(view.Rows) > 4 {
-		view.Rows = view.Rows[:4]
+	if maxRows < 1 {
+		maxRows = 4
+	}
+	if len(view.Rows) > maxRows {
+		view.Rows = view.Rows[:maxRows]
 	}
 
 	m.mu.Lock()
@@ -451,6 +612,39 @@ func (m *PluginManager) DashboardSnapshot(name, binPath string) pluginrpc.ViewDa
 	return view
 }
 
+// tableWidget folds a plugin table into the two-column rows a dashboard card
+// renders: first column as the key, the rest joined as the value.
+func tableWidget(name string, view pluginrpc.ViewData) pluginrpc.ViewData {
+	rows := make([][]string, 0, len(view.Rows))
+	for _, row := range view.Rows {
+		if len(row) == 0 {
+			continue
+		}
+		value := strings.Join(row[1:], " · ")
+		rows = append(rows, []string{row[0], value})
+	}
+	view.Rows = rows
+	if view.Title == "" {
+		view.Title = name
+	}
+	if view.Status == "" {
+		view.Status = fmt.Sprintf("%d rows", len(rows))
+	}
+	return view
+}
+
+// targetSettings resolves a specific KeePass entry for Configure.
+func targetSettings(path string) (map[string]string, error) {
+	if !pluginapi.HasSecrets() {
+		return nil, fmt.Errorf("secrets unavailable")
+	}
+	entry, err := pluginapi.Secrets().Get(path)
+	if err != nil || entry == nil {
+		return nil, fmt.Errorf("target %s not found", path)
+	}
+	return configureSettings(path, entry)
+}
+
 func firstDashboardValue(values ...string) string {
 	for _, value := range values {
 		if strings.TrimSpace(value) != "" {
@@ -542,6 +736,12 @@ func (m *PluginManager) Kill(name string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.killLocked(name)
+	// Dashboard tiles pinned to a target or view run their own process.
+	for key := range m.sessions {
+		if strings.HasPrefix(key, name+"@") {
+			m.killLocked(key)
+		}
+	}
 }
 
 func (m *PluginManager) killLocked(name string) {
@@ -608,10 +808,6 @@ func (m *PluginManager) ActivePrimitive() tview.Primitive {
 	return sess.Renderer.Primitive()
 }
 
-func resolvePluginConfig(pluginName string) (map[string]string, error) {
-	return resolvePluginConfigWithReload(pluginName, true)
-}
-
 // ReloadSecrets refreshes KeePass once before a multi-plugin dashboard pulse.
 func (m *PluginManager) ReloadSecrets() {
 	if !pluginapi.HasSecrets() {
@@ -622,10 +818,12 @@ func (m *PluginManager) ReloadSecrets() {
 	}
 }
 
-func resolvePluginConfigWithReload(pluginName string, reload bool) (map[string]string, error) {
+// resolvePluginTarget picks the KeePass entry a plugin connects with by
+// default and returns its path alongside the resolved settings.
+func resolvePluginTarget(pluginName string, reload bool) (string, map[string]string, error) {
 	pluginrpc.RPCLog("resolvePluginConfig %s reload=%v", pluginName, reload)
 	if !pluginapi.HasSecrets() {
-		return nil, fmt.Errorf("secrets unavailable")
+		return "", nil, fmt.Errorf("secrets unavailable")
 	}
 	if reload {
 		if err := pluginapi.Secrets().Reload(); err != nil {
@@ -637,12 +835,13 @@ func resolvePluginConfigWithReload(pluginName string, reload bool) (map[string]s
 	preferred := pluginName + "/development/local"
 	if entry, err := pluginapi.Secrets().Get(preferred); err == nil && entry != nil && !pluginapi.IsReferenceEntry(entry) {
 		pluginrpc.RPCLog("resolvePluginConfig: using preferred %s host=%s user=%s", preferred, entry.URL, entry.UserName)
-		return entryToSettings(entry), nil
+		settings, err := configureSettings(preferred, entry)
+		return preferred, settings, err
 	}
 
 	paths, err := pluginapi.Secrets().List(pluginName)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 	pluginrpc.RPCLog("resolvePluginConfig: listed %d paths", len(paths))
 
@@ -660,9 +859,21 @@ func resolvePluginConfigWithReload(pluginName string, reload bool) (map[string]s
 			continue
 		}
 		pluginrpc.RPCLog("resolvePluginConfig: using %s host=%s user=%s", p, entry.URL, entry.UserName)
-		return entryToSettings(entry), nil
+		settings, err := configureSettings(p, entry)
+		return p, settings, err
+	}
+	return "", nil, fmt.Errorf("no KeePass entries under %s/", pluginName)
+}
+
+// resolveEntrySettings applies extends and ref:// values (see
+// pluginapi.ResolveSecretEntry) before flattening the entry for Configure.
+func resolveEntrySettings(path string, entry *pluginapi.SecretEntry) (map[string]string, error) {
+	resolved, err := pluginapi.ResolveSecretEntry(pluginapi.Secrets(), path, entry)
+	if err != nil {
+		pluginrpc.RPCLog("resolvePluginConfig: %s: %v", path, err)
+		return nil, err
 	}
-	return nil, fmt.Errorf("no KeePass entries under %s/", pluginName)
+	return entryToSettings(resolved), nil
 }
 
 func entryToSettings(entry *pluginapi.SecretEntry) map[string]string {
@@ -1,9 +1,12 @@
 package host
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"omo/pkg/pluginrpc"
 	"omo/pkg/ui"
@@ -14,17 +17,31 @@ import (
 
 // RPCRenderer is a host-owned CoreView driven by pluginrpc.ViewData.
 type RPCRenderer struct {
-	app         *tview.Application
-	pages       *tview.Pages
-	name        string
-	plugin      pluginrpc.Plugin
-	core        *ui.CoreView
-	root        *tview.Pages
-	currentView string
-	homeView    string // first/default view id for breadcrumbs + ESC
-	onActions   func([]pluginrpc.KeyBinding, func(string))
-	onMood      func(phase string, ok bool, action, reaction string)
-	onHome      func()
+	app          *tview.Application
+	pages        *tview.Pages
+	name         string
+	plugin       pluginrpc.Plugin
+	core         *ui.CoreView
+	root         *tview.Pages
+	currentView  string
+	homeView     string // first/default view id for breadcrumbs + ESC
+	targetPath   string // KeePass entry the plugin is configured with
+	accentTarget string // targetPath last sent to the host frame accent
+	onAccent     func(*RPCRenderer)
+	onActions    func([]pluginrpc.KeyBinding, func(string))
+	onMood       func(phase string, ok bool, action, reaction string)
+	onHome       func()
+	keymap       *Keymap
+	conflicts    map[keyConflict]bool // already logged
+	batch        map[string]string    // action → label of the view's batch actions
+	columns      ColumnPrefs          // shared; nil when columns.yaml is broken
+	layoutView   string               // view whose column layout is applied
+
+	detailAction string // view's DetailAction for the detail pane
+	detailCache  map[string]detailEntry
+	detailSeq    atomic.Int64 // drops detail results for rows no longer highlighted
+	keySearch    [2]string    // last search_keys pattern and type
+	poll         *time.Timer  // pending RefreshAfter fetch
 }
 
 // NewRPCRenderer builds a CoreView shell for an RPC plugin.
@@ -44,6 +61,7 @@ func NewRPCRenderer(app *tview.Application, pages *tview.Pages, name string, p p
 	r.core.SetModalPages(pages)
 	r.core.SetRefreshCallback(r.fetchRows)
 	r.core.SetActionCallback(r.handleCoreAction)
+	r.core.SetLayoutChangedFunc(r.saveLayout)
 	r.core.AddKeyBinding("R", "Refresh", r.refresh)
 	r.core.SetViewStack([]string{name, "keys"})
 	r.core.RegisterHandlers()
@@ -62,6 +80,23 @@ func (r *RPCRenderer) SetActionsHook(fn func([]pluginrpc.KeyBinding, func(string
 	r.onActions = fn
 }
 
+// SetKeymap sets the user key remaps applied from the next Apply.
+func (r *RPCRenderer) SetKeymap(k *Keymap) {
+	r.keymap = k
+}
+
+// SetColumnPrefs sets the saved table layouts, applied from the next paint.
+func (r *RPCRenderer) SetColumnPrefs(prefs ColumnPrefs) {
+	r.columns = prefs
+	r.layoutView = ""
+}
+
+// SetAccentHook is called after each paint so the host can color its frame
+// for the plugin's target.
+func (r *RPCRenderer) SetAccentHook(fn func(*RPCRenderer)) {
+	r.onAccent = fn
+}
+
 // SetMoodHook wires logo mood flashes for action pending/result beats.
 func (r *RPCRenderer) SetMoodHook(fn func(phase string, ok bool, action, reaction string)) {
 	r.onMood = fn
@@ -120,14 +155,20 @@ func (r *RPCRenderer) Apply(view pluginrpc.ViewData) tview.Primitive {
 	if title == "" {
 		title = r.name
 	}
+	keymap := r.keymap
+	if keymap == nil {
+		keymap = defaultKeymap()
+	}
+	view, aliases, conflicts := keymap.applyView(r.name, view)
+	r.logConflicts(conflicts)
+	r.detailAction = view.DetailAction
+	r.detailCache = nil
+
 	r.core.ClearKeyBindings()
 	r.core.ClearHelpSections()
 
 	// Globals always live in the Keys column (former logs).
-	r.core.AddKeyBinding("R", "Refresh", r.refresh)
-	r.core.AddKeyBinding("?", "Help", func() { r.core.ShowHelpModal() })
-	r.core.AddKeyBinding("/", "Filter", nil)
-	r.core.AddKeyBinding("^t", "Target", nil) // handled globally in main (Ctrl+t)
+	r.bindHostKeys(keymap)
 
 	// Middle column: explicit view switches (0-9).
 	for _, kb := range view.ViewBindings {
@@ -153,9 +194,25 @@ func (r *RPCRenderer) Apply(view pluginrpc.ViewData) tview.Primitive {
 	for _, kb := range view.Actions {
 		r.bindKeyShortcut(kb)
 	}
+	r.batch = map[string]string{}
+	for _, list := range [][]pluginrpc.KeyBinding{view.KeyBindings, view.Actions} {
+		for _, kb := range list {
+			if kb.Batch && kb.Action != "" {
+				r.batch[kb.Action] = kb.Label
+			}
+		}
+	}
+	// Extra keys from the keymap: bound, listed in "?" only.
+	for _, kb := range aliases {
+		action := kb.Action
+		r.core.BindKey(kb.Key, func() { r.dispatchAction(action) })
+	}
 	r.core.SetActiveView(r.currentView)
 
 	if len(view.HelpSections) > 0 {
+		if problems, ok := keymap.problemsHelp(conflicts); ok {
+			view.HelpSections = append(view.HelpSections, problems)
+		}
 		sections := make([]ui.HelpSection, 0, len(view.HelpSections))
 		for _, s := range view.HelpSections {
 			bindings := make([]ui.KeyBindingHelp, 0, len(s.Bindings))
@@ -174,6 +231,10 @@ func (r *RPCRenderer) Apply(view pluginrpc.ViewData) tview.Primitive {
 	if view.SelectionKey != "" {
 		r.core.SetSelectionKey(view.SelectionKey)
 	}
+	if r.layoutView != r.currentView {
+		r.layoutView = r.currentView
+		r.core.SetTableLayout(r.columns.Layout(r.name, r.currentView))
+	}
 	if len(view.Headers) > 0 {
 		r.core.SetTableHeaders(view.Headers)
 	}
@@ -207,7 +268,10 @@ func (r *RPCRenderer) Apply(view pluginrpc.ViewData) tview.Primitive {
 		r.core.CloseLogs()
 		r.core.SetTableData(view.Rows)
 	}
+	r.setPager(view)
+	r.schedulePoll(view)
 
+	var onHighlight []func()
 	// Highlighted zone row is the active zone — no Enter required.
 	if r.currentView == "zones" {
 		syncZone := func() {
@@ -226,9 +290,20 @@ func (r *RPCRenderer) Apply(view pluginrpc.ViewData) tview.Primitive {
 				})
 			}()
 		}
-		r.core.SetHighlightChangedCallback(func(_ int) { syncZone() })
+		onHighlight = append(onHighlight, syncZone)
 		syncZone() // current highlight on load / refresh
 	}
+	if view.LogsBody == "" {
+		onHighlight = append(onHighlight, r.updateDetail)
+		r.updateDetail()
+	}
+	if len(onHighlight) > 0 {
+		r.core.SetHighlightChangedCallback(func(_ int) {
+			for _, fn := range onHighlight {
+				fn()
+			}
+		})
+	}
 
 	// Enter: view key / peek pubsub channel
 	if table := r.core.GetTable(); table != nil && view.LogsBody == "" {
@@ -238,9 +313,21 @@ func (r *RPCRenderer) Apply(view pluginrpc.ViewData) tview.Primitive {
 			}
 			switch r.currentView {
 			case "keys":
-				r.dispatchAction("view_key")
+				r.dispatchAction("open_key")
+			case "value":
+				r.dispatchAction("value_edit")
+			case "streams":
+				r.dispatchAction("open_stream")
+			case "stream_groups":
+				r.dispatchAction("open_group")
 			case "pubsub":
 				r.dispatchAction("subscribe")
+			case "keytree":
+				r.dispatchAction("open_prefix")
+			case "acl":
+				r.dispatchAction("acl_user")
+			case "functions":
+				r.dispatchAction("fn_library")
 			case "servers":
 				r.dispatchAction("shell")
 			case "buckets":
@@ -284,7 +371,7 @@ func (r *RPCRenderer) Apply(view pluginrpc.ViewData) tview.Primitive {
 
 	r.core.RegisterHandlers()
 	r.root.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEscape && r.currentView == r.homeView && r.onHome != nil {
+		if event.Key() == tcell.KeyEscape && r.currentView == r.homeView && r.onHome != nil && r.core.MarkCount() == 0 {
 			r.onHome()
 			return nil
 		}
@@ -293,6 +380,9 @@ func (r *RPCRenderer) Apply(view pluginrpc.ViewData) tview.Primitive {
 	if view.LogsBody != "" {
 		r.core.FocusContent()
 	}
+	if r.onAccent != nil {
+		r.onAccent(r)
+	}
 	pluginrpc.RPCLog("RPCRenderer.Apply done bindings=%d view=%s", len(view.KeyBindings), r.currentView)
 	return r.root
 }
@@ -330,9 +420,74 @@ func (r *RPCRenderer) bindViewSwitch(kb pluginrpc.KeyBinding) {
 	})
 }
 
+// bindHostKeys lists the host keys of the plugin pane in the Keys column.
+func (r *RPCRenderer) bindHostKeys(keymap *Keymap) {
+	handlers := map[string]func(){
+		"refresh":  r.refresh,
+		"help":     func() { r.core.ShowHelpModal() },
+		"filter":   func() { r.core.ShowFilterModal() },
+		"target":   nil, // handled globally in main
+		"export":   nil,
+		"mark":     r.core.ToggleMark,
+		"mark_all": r.core.ToggleMarkAll,
+		"sort":     r.core.ShowSortModal,
+		"columns":  r.core.ShowColumnsModal,
+		"detail":   r.toggleDetail,
+	}
+	// Listed in "?" only; the detail pane key shows where the view has details.
+	silent := map[string]bool{"mark": true, "mark_all": true, "sort": true, "columns": true, "detail": r.detailAction == ""}
+	labels := map[string]string{"target": "Target", "export": "Export"}
+	for _, a := range hostActions {
+		handler, ok := handlers[a.id]
+		if !ok {
+			continue
+		}
+		for _, key := range a.keys {
+			r.core.UnbindKey(key) // kept by ClearKeyBindings
+		}
+		label := a.label
+		if l, ok := labels[a.id]; ok {
+			label = l
+		}
+		for i, key := range keymap.HostKeys(a.id) {
+			if i == 0 && !silent[a.id] {
+				r.core.AddKeyBinding(key, label, handler)
+			} else if handler != nil {
+				r.core.BindKey(key, handler)
+			}
+		}
+	}
+}
+
+// saveLayout stores a column layout edit for the current view.
+func (r *RPCRenderer) saveLayout(l ui.TableLayout) {
+	if r.columns == nil {
+		return
+	}
+	r.columns.SetLayout(r.name, r.currentView, l)
+	if err := SaveColumnPrefs(r.columns); err != nil {
+		r.core.Log(fmt.Sprintf("[red]save columns: %v", err))
+		pluginrpc.RPCLog("save columns: %v", err)
+	}
+}
+
+// logConflicts writes keymap clashes first seen in this view to the RPC log.
+func (r *RPCRenderer) logConflicts(conflicts []keyConflict) {
+	for _, c := range conflicts {
+		if r.conflicts[c] {
+			continue
+		}
+		if r.conflicts == nil {
+			r.conflicts = map[keyConflict]bool{}
+		}
+		r.conflicts[c] = true
+		pluginrpc.RPCLog("keymap conflict in %s: %s: %s (host/remapped key kept)", r.name, c.Key, c.Detail)
+	}
+}
+
 func (r *RPCRenderer) bindKeyShortcut(kb pluginrpc.KeyBinding) {
 	key := kb.Key
-	if key == "" || key == "R" || key == "?" || key == "/" || key == "^t" {
+	if key == "" {
 		return
 	}
 	label := kb.Label
@@ -414,6 +569,10 @@ func (r *RPCRenderer) refresh() {
 }
 
 func (r *RPCRenderer) dispatchAction(action string) {
+	if label, ok := r.batch[action]; ok && r.core.MarkCount() > 0 {
+		r.confirmBatch(action, label)
+		return
+	}
 	switch action {
 	case "delete":
 		key := r.selectedKey()
@@ -451,6 +610,32 @@ func (r *RPCRenderer) dispatchAction(action string) {
 		r.runAction("select_database", r.selectionPayload())
 	case "publish":
 		r.promptPublish()
+	case "search_keys":
+		r.promptSearchKeys()
+	case "value_add_field", "value_add_member", "value_add_scored", "value_score",
+		"value_push", "value_pop", "value_remove", "value_ttl":
+		r.promptValueAction(action)
+	case "stream_add", "stream_trim", "stream_ack", "stream_claim", "stream_autoclaim":
+		r.promptStreamAction(action)
+	case "dump_keys", "restore_keys", "copy_keys":
+		r.promptKeyTransfer(action)
+	case "acl_create":
+		r.promptChain("New ACL User", []string{"User name:", "Rules (a password is generated):"}, []string{"", "on ~* &* -@all +@read"}, func(v []string) {
+			r.FocusTable()
+			r.runAction(action, map[string]string{"name": strings.TrimSpace(v[0]), "rules": v[1]})
+		})
+	case "memory_analyze":
+		r.promptChain("Memory Analysis", []string{"Keys to sample (0 = all):"}, []string{"100000"}, func(v []string) {
+			r.FocusTable()
+			r.runAction(action, map[string]string{"limit": strings.TrimSpace(v[0])})
+		})
+	case "fn_load", "fn_restore", "fn_eval":
+		r.promptFunctions(action)
+	case "watch_start", "watch_filter":
+		r.promptChain("Keyspace Watch", []string{"Key pattern:", "Events (blank = all):"}, []string{"*", ""}, func(v []string) {
+			r.FocusTable()
+			r.runAction(action, map[string]string{"pattern": strings.TrimSpace(v[0]), "events": strings.TrimSpace(v[1])})
+		})
 	case "start_forward":
 		r.promptStartForward()
 	case "stop_forward":
@@ -502,6 +687,8 @@ func (r *RPCRenderer) dispatchAction(action string) {
 		r.promptCreateIssue()
 	case "run_jql":
 		r.promptRunJQL()
+	case "run_command":
+		r.promptCommand()
 	default:
 		r.runAction(action, r.selectionPayload())
 	}
@@ -516,8 +703,20 @@ func (r *RPCRenderer) selectedKey() string {
 }
 
 func (r *RPCRenderer) selectionPayload() map[string]string {
+	payload := rowPayload(r.core.GetSelectedRowData())
+	if marked := r.core.MarkedRows(); len(marked) > 0 {
+		rows := make([]map[string]string, len(marked))
+		for i, row := range marked {
+			rows[i] = rowPayload(row)
+		}
+		payload[pluginrpc.PayloadMarked] = pluginrpc.EncodeMarkedRows(rows)
+	}
+	return payload
+}
+
+// rowPayload is the action payload for one table row.
+func rowPayload(row []string) map[string]string {
 	payload := map[string]string{}
-	row := r.core.GetSelectedRowData()
 	if len(row) == 0 {
 		return payload
 	}
@@ -626,6 +825,34 @@ func (r *RPCRenderer) promptPublish() {
 		})
 }
 
+// promptSearchKeys asks for a SCAN pattern and an optional type filter,
+// starting from the last search.
+func (r *RPCRenderer) promptSearchKeys() {
+	pattern := r.keySearch[0]
+	if pattern == "" {
+		pattern = "*"
+	}
+	ui.ShowCompactStyledInputModal(r.pages, r.app, "Search Keys", "Pattern:", pattern, 40, nil,
+		func(pattern string, cancelled bool) {
+			if cancelled {
+				r.FocusTable()
+				return
+			}
+			ui.ShowCompactStyledInputModal(r.pages, r.app, "Search Keys", "Type (blank = any):", r.keySearch[1], 40, nil,
+				func(typ string, cancelled bool) {
+					r.FocusTable()
+					if cancelled {
+						return
+					}
+					r.keySearch = [2]string{strings.TrimSpace(pattern), strings.TrimSpace(typ)}
+					r.runAction("search_keys", map[string]string{
+						"pattern": r.keySearch[0],
+						"type":    r.keySearch[1],
+					})
+				})
+		})
+}
+
 func (r *RPCRenderer) promptStartForward() {
 	payload := r.selectionPayload()
 	if len(payload) == 0 || (payload["col3"] == "" && payload["col2"] == "" && r.currentView != "forwards") {
@@ -781,6 +1008,16 @@ func (r *RPCRenderer) runAction(action string, payload map[string]string) {
 			View:    viewID,
 			Payload: payload,
 		})
+		if err == nil && result.Edit != nil {
+			edit := *result.Edit
+			r.app.QueueUpdate(func() {
+				if shouldMood(action) {
+					r.flashMood("ok", true, action, "")
+				}
+				r.editValue(edit)
+			})
+			return
+		}
 		if err == nil && result.ExternalSession != nil {
 			sess := *result.ExternalSession
 			r.app.QueueUpdate(func() {
@@ -802,6 +1039,19 @@ func (r *RPCRenderer) runAction(action string, payload map[string]string) {
 				}
 				return
 			}
+			if result.Confirm != nil {
+				if shouldMood(action) {
+					r.flashMood("ok", true, action, "")
+				}
+				c := *result.Confirm
+				ui.ShowStandardConfirmationModal(r.pages, r.app, c.Title, c.Body, func(ok bool) {
+					r.FocusTable()
+					if ok {
+						r.runAction(c.Action, c.Payload)
+					}
+				})
+				return
+			}
 			if result.Message != "" {
 				if result.OK {
 					r.core.Log("[green]" + result.Message)
@@ -816,21 +1066,37 @@ func (r *RPCRenderer) runAction(action string, payload map[string]string) {
 				}
 				r.flashMood(phase, result.OK, action, result.Reaction)
 			}
-			if result.ModalTitle != "" || result.ModalBody != "" {
+			afterModal := r.FocusTable
+			if result.OK && result.Credential != nil {
+				cred := *result.Credential
+				afterModal = func() { r.offerSaveCredential(cred) }
+			}
+			if result.Detail != nil {
 				title := result.ModalTitle
+				if title == "" {
+					title = result.Detail.Title
+				}
 				if title == "" {
 					title = "Detail"
 				}
-				ui.ShowInfoModal(r.pages, r.app, title, result.ModalBody, func() {
-					r.FocusTable()
-				})
+				d := *result.Detail
+				d.Title = ""
+				ui.ShowInfoModal(r.pages, r.app, title, d.Render(), afterModal)
+			} else if result.ModalTitle != "" || result.ModalBody != "" {
+				title := result.ModalTitle
+				if title == "" {
+					title = "Detail"
+				}
+				ui.ShowInfoModal(r.pages, r.app, title, result.ModalBody, afterModal)
+			} else if result.OK && result.Credential != nil {
+				defer afterModal()
 			}
 			if result.Next != nil {
 				r.Apply(*result.Next)
 				r.FocusTable()
 				return
 			}
-			if result.OK && result.ModalBody == "" {
+			if result.OK && result.ModalBody == "" && result.Detail == nil {
 				r.refresh()
 			}
 		})
@@ -1027,3 +1293,95 @@ func (r *RPCRenderer) promptRunJQL() {
 			r.runAction("run_jql", map[string]string{"jql": strings.TrimSpace(jql)})
 		})
 }
+
+// promptCommand reads a console command with per-target history (Up/Down).
+func (r *RPCRenderer) promptCommand() {
+	target := r.targetPath
+	if target == "" {
+		target = r.name
+	}
+	history, err := LoadConsoleHistory()
+	if err != nil {
+		r.core.Log(fmt.Sprintf("[yellow]console history: %v (not saving)", err))
+	}
+	ui.ShowHistoryInputModal(r.pages, r.app, "Command", ">", 64, history[target],
+		func(line string, cancelled bool) {
+			r.FocusTable()
+			line = strings.TrimSpace(line)
+			if cancelled || line == "" {
+				return
+			}
+			if err == nil && history.Add(target, line) {
+				if err := SaveConsoleHistory(history); err != nil {
+					r.core.Log(fmt.Sprintf("[yellow]console history: %v", err))
+				}
+			}
+			r.runAction("run_command", map[string]string{"command": line})
+		})
+}
+
+// schedulePoll fetches the view again after view.RefreshAfter, quietly and
+// only if it is still the one shown. Each Apply replaces the pending fetch.
+func (r *RPCRenderer) schedulePoll(view pluginrpc.ViewData) {
+	if r.poll != nil {
+		r.poll.Stop()
+		r.poll = nil
+	}
+	if view.RefreshAfter <= 0 || r.plugin == nil {
+		return
+	}
+	viewID := r.currentView
+	r.poll = time.AfterFunc(view.RefreshAfter, func() {
+		next, err := r.plugin.GetView(pluginrpc.ViewRequest{View: viewID})
+		if err != nil {
+			return
+		}
+		r.app.QueueUpdateDraw(func() {
+			if r.currentView == viewID {
+				r.Apply(next)
+			}
+		})
+	})
+}
+
+// pagerPageSize is the limit sent to LoadMoreAction; plugins may return
+// shorter or longer pages.
+const pagerPageSize = 500
+
+// setPager wires PgDn to the view's LoadMoreAction, or turns paging off.
+func (r *RPCRenderer) setPager(view pluginrpc.ViewData) {
+	if view.LoadMoreAction == "" || view.LogsBody != "" {
+		r.core.SetPagedLoader(0, nil)
+		return
+	}
+	action, viewID := view.LoadMoreAction, r.currentView
+	r.core.SetPagedLoader(pagerPageSize, func(offset, limit int) ([][]string, bool, error) {
+		if r.plugin == nil {
+			return nil, false, fmt.Errorf("plugin not loaded")
+		}
+		result, err := r.plugin.DoAction(pluginrpc.ActionRequest{
+			Action: action,
+			View:   viewID,
+			Payload: map[string]string{
+				"offset": strconv.Itoa(offset),
+				"limit":  strconv.Itoa(limit),
+			},
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		if !result.OK {
+			return nil, false, errors.New(result.Message)
+		}
+		if result.Next == nil {
+			return nil, false, nil
+		}
+		if info := result.Next.Info; info != "" {
+			r.app.QueueUpdateDraw(func() {
+				r.core.SetInfoText(pluginrpc.ColorizeInfoPanel(info))
+			})
+		}
+		return result.Next.Rows, result.Next.More, nil
+	})
+	r.core.SetLazyState(len(view.Rows), view.More)
+}
@@ -0,0 +1,66 @@
+package host
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"omo/pkg/ui"
+)
+
+// promptStreamAction asks for what a stream action needs; trims and acks
+// confirm first.
+func (r *RPCRenderer) promptStreamAction(action string) {
+	selected := r.selectedKey()
+	payload := r.selectionPayload()
+	run := func(extra map[string]string) {
+		r.FocusTable()
+		maps.Copy(payload, extra)
+		r.runAction(action, payload)
+	}
+	switch action {
+	case "stream_add":
+		r.promptChain("Add Entry", []string{"Fields (field=value …):"}, []string{"test=1"}, func(v []string) {
+			if strings.TrimSpace(v[0]) == "" {
+				r.FocusTable()
+				return
+			}
+			run(map[string]string{"fields": v[0]})
+		})
+	case "stream_trim":
+		r.promptChain("Trim Stream", []string{"Keep about N entries:"}, []string{"1000"}, func(v []string) {
+			n, err := strconv.ParseInt(strings.TrimSpace(v[0]), 10, 64)
+			if err != nil || n < 0 {
+				r.core.Log("[red]max length must be a number >= 0")
+				r.FocusTable()
+				return
+			}
+			ui.ShowStandardConfirmationModal(r.pages, r.app, "Confirm Trim",
+				fmt.Sprintf("Trim the stream to about %d entries? Older entries are deleted.", n),
+				func(ok bool) {
+					r.FocusTable()
+					if ok {
+						run(map[string]string{"maxlen": strconv.FormatInt(n, 10)})
+					}
+				})
+		})
+	case "stream_ack":
+		ui.ShowStandardConfirmationModal(r.pages, r.app, "Confirm Ack",
+			fmt.Sprintf("Acknowledge %s? It leaves the pending list.", selected),
+			func(ok bool) {
+				r.FocusTable()
+				if ok {
+					run(nil)
+				}
+			})
+	case "stream_claim", "stream_autoclaim":
+		title := "Claim Entries"
+		if action == "stream_autoclaim" {
+			title = "Autoclaim Entries"
+		}
+		r.promptChain(title, []string{"Consumer:", "Min idle (ms):"}, []string{"", "60000"}, func(v []string) {
+			run(map[string]string{"consumer": strings.TrimSpace(v[0]), "idle": strings.TrimSpace(v[1])})
+		})
+	}
+}
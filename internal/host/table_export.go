@@ -0,0 +1,275 @@
+package host
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/ui"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Table export (Ctrl+e) writes the table as shown — after the active filter,
+// in the current sort order and with the column layout applied — to
+// ~/.omo/exports/<plugin>/ or the clipboard. tview color tags are stripped
+// from headers and cells.
+
+type tableExportFormat struct {
+	Name string
+	Ext  string
+	Enc  func(headers []string, rows [][]string) ([]byte, error)
+}
+
+var tableExportFormats = []tableExportFormat{
+	{Name: "CSV", Ext: "csv", Enc: encodeTableCSV},
+	{Name: "JSON", Ext: "json", Enc: encodeTableJSON},
+	{Name: "NDJSON", Ext: "ndjson", Enc: encodeTableNDJSON},
+	{Name: "Markdown", Ext: "md", Enc: encodeTableMarkdown},
+}
+
+// ExportActiveTable opens the export menu for the active RPC plugin (Ctrl+e).
+func (m *PluginManager) ExportActiveTable() {
+	m.mu.Lock()
+	sess := m.sessions[m.active]
+	m.mu.Unlock()
+	if sess == nil || sess.Renderer == nil || sess.Renderer.core == nil {
+		return
+	}
+	sess.Renderer.showExportMenu()
+}
+
+func (r *RPCRenderer) showExportMenu() {
+	headers, rows := r.core.GetShownTable()
+	if len(headers) == 0 || len(rows) == 0 {
+		r.core.Log("[yellow]nothing to export")
+		return
+	}
+	headers, rows = cleanTable(headers, rows)
+
+	items := make([][]string, 0, 2*len(tableExportFormats))
+	for _, f := range tableExportFormats {
+		items = append(items, []string{f.Name + " file", "~/.omo/exports/" + r.name + "/"})
+	}
+	for _, f := range tableExportFormats {
+		items = append(items, []string{"Copy as " + f.Name, "clipboard"})
+	}
+	title := fmt.Sprintf("Export %d row(s)", len(rows))
+	if q := r.core.GetFilterQuery(); q != "" {
+		title += " matching " + q
+	}
+	ui.ShowStandardListSelectorModal(r.pages, r.app, title, items, func(index int, _ string, cancelled bool) {
+		r.FocusTable()
+		if cancelled || index < 0 || index >= len(items) {
+			return
+		}
+		format := tableExportFormats[index%len(tableExportFormats)]
+		data, err := format.Enc(headers, rows)
+		if err != nil {
+			r.core.Log("[red]export: " + err.Error())
+			return
+		}
+		if index >= len(tableExportFormats) {
+			if err := ui.CopyToClipboard(string(data)); err != nil {
+				r.core.Log("[red]copy: " + err.Error())
+				return
+			}
+			r.core.Log(fmt.Sprintf("[green]copied %d row(s) as %s", len(rows), format.Name))
+			return
+		}
+		path, err := writeTableExport(r.name, r.currentView, format.Ext, data)
+		if err != nil {
+			r.core.Log("[red]export: " + err.Error())
+			return
+		}
+		r.core.Log(fmt.Sprintf("[green]exported %d row(s) to %s", len(rows), path))
+	})
+}
+
+func writeTableExport(plugin, view, ext string, data []byte) (string, error) {
+	if plugin == "" {
+		plugin = "plugin"
+	}
+	dir := pluginapi.PluginExportsDir(plugin)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	if view == "" {
+		view = "table"
+	}
+	name := fmt.Sprintf("%s-%s.%s", exportFileSafe(view), time.Now().Format("20060102-150405"), ext)
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func exportFileSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '-'
+	}, s)
+}
+
+// reColorTag matches tview color/style tags ([red], [#ff8800::b], [-:-:-])
+// and region tags (["id"]) without touching ordinary bracketed text such as
+// [1 2 3] or [draft].
+var reColorTag = regexp.MustCompile(`\[(?:(?:[a-zA-Z]+|#[0-9a-fA-F]{6}|-)?(?::(?:[a-zA-Z]+|#[0-9a-fA-F]{6}|-)?(?::[lbidrusLBIDRUS-]*)?)?|"[^"\]]*")\]`)
+
+// stripColorTags removes tview tags. Semantic slots ([ok], [warn], …) are
+// resolved to their colors first so Tint'ed cells export clean; any other
+// bare word only counts as a tag when it is a known color.
+func stripColorTags(s string) string {
+	return reColorTag.ReplaceAllStringFunc(ui.SlotColors(s), func(tag string) string {
+		inner := tag[1 : len(tag)-1]
+		if inner == "" {
+			return tag
+		}
+		if !strings.ContainsAny(inner, ":#-\"") && !isTcellColorName(inner) {
+			return tag
+		}
+		return ""
+	})
+}
+
+func isTcellColorName(name string) bool {
+	_, ok := tcell.ColorNames[strings.ToLower(name)]
+	return ok || strings.EqualFold(name, "default")
+}
+
+func cleanTable(headers []string, rows [][]string) ([]string, [][]string) {
+	h := make([]string, len(headers))
+	for i, v := range headers {
+		h[i] = strings.TrimSpace(stripColorTags(v))
+	}
+	out := make([][]string, len(rows))
+	for i, row := range rows {
+		cells := make([]string, len(h))
+		for j := range cells {
+			if j < len(row) {
+				cells[j] = stripColorTags(row[j])
+			}
+		}
+		out[i] = cells
+	}
+	return h, out
+}
+
+func encodeTableCSV(headers []string, rows [][]string) ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	if err := w.Write(headers); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// jsonKeys turns headers into unique object keys; blank headers become
+// column_N.
+func jsonKeys(headers []string) []string {
+	keys := make([]string, len(headers))
+	seen := map[string]int{}
+	for i, h := range headers {
+		k := h
+		if k == "" {
+			k = fmt.Sprintf("column_%d", i+1)
+		}
+		if n := seen[k]; n > 0 {
+			seen[k] = n + 1
+			k = fmt.Sprintf("%s_%d", k, n+1)
+		} else {
+			seen[k] = 1
+		}
+		keys[i] = k
+	}
+	return keys
+}
+
+// tableObject is a row as a JSON object that keeps the column order.
+type tableObject struct {
+	keys  []string
+	cells []string
+}
+
+func (o tableObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		kb, _ := json.Marshal(k)
+		vb, _ := json.Marshal(o.cells[i])
+		b.Write(kb)
+		b.WriteByte(':')
+		b.Write(vb)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func encodeTableJSON(headers []string, rows [][]string) ([]byte, error) {
+	keys := jsonKeys(headers)
+	objs := make([]tableObject, len(rows))
+	for i, row := range rows {
+		objs[i] = tableObject{keys: keys, cells: row}
+	}
+	data, err := json.MarshalIndent(objs, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func encodeTableNDJSON(headers []string, rows [][]string) ([]byte, error) {
+	keys := jsonKeys(headers)
+	var b bytes.Buffer
+	for _, row := range rows {
+		line, err := json.Marshal(tableObject{keys: keys, cells: row})
+		if err != nil {
+			return nil, err
+		}
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	return b.Bytes(), nil
+}
+
+func encodeTableMarkdown(headers []string, rows [][]string) ([]byte, error) {
+	cell := func(s string) string {
+		s = strings.ReplaceAll(s, "|", `\|`)
+		return strings.ReplaceAll(strings.ReplaceAll(s, "\r", ""), "\n", "<br>")
+	}
+	var b bytes.Buffer
+	line := func(cells []string) {
+		b.WriteString("|")
+		for _, c := range cells {
+			b.WriteString(" " + cell(c) + " |")
+		}
+		b.WriteByte('\n')
+	}
+	line(headers)
+	b.WriteString("|")
+	for range headers {
+		b.WriteString(" --- |")
+	}
+	b.WriteByte('\n')
+	for _, row := range rows {
+		line(row)
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,40 @@
+package host
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStripColorTagsKeepsPlainBrackets(t *testing.T) {
+	for in, want := range map[string]string{
+		"[red]down[-]":          "down",
+		"[#ff8800::b]42[white]": "42",
+		`["row1"]x[""]`:         "x",
+		"[draft] [1 2 3]":       "[draft] [1 2 3]",
+		"[ok]Clean[-]":          "Clean",
+		"[warn:-:b]slow[-:-:-]": "slow",
+	} {
+		if got := stripColorTags(in); got != want {
+			t.Fatalf("stripColorTags(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestEncodeTableFormats(t *testing.T) {
+	headers, rows := cleanTable([]string{"Name", "Name", ""}, [][]string{
+		{"[green]a|b", "x"},
+	})
+
+	md, _ := encodeTableMarkdown(headers, rows)
+	if !strings.Contains(string(md), `| a\|b | x |  |`) {
+		t.Fatalf("markdown = %q", md)
+	}
+	nd, _ := encodeTableNDJSON(headers, rows)
+	if got := strings.TrimSpace(string(nd)); got != `{"Name":"a|b","Name_2":"x","column_3":""}` {
+		t.Fatalf("ndjson = %s", got)
+	}
+	csv, _ := encodeTableCSV(headers, rows)
+	if got := string(csv); got != "Name,Name,\na|b,x,\n" {
+		t.Fatalf("csv = %q", got)
+	}
+}
@@ -14,6 +14,7 @@ type secretTarget struct {
 	Settings map[string]string
 	Label    string
 	Detail   string
+	Err      error // unresolved ref:// or extends; the target cannot be applied
 }
 
 // ShowTargetSelector lists KeePass targets for the active RPC plugin (Ctrl+t).
@@ -65,12 +66,34 @@ func (m *PluginManager) applyTarget(name string, target secretTarget) {
 	if sess == nil || sess.Plugin == nil {
 		return
 	}
+	if target.Err != nil {
+		if sess.Renderer != nil && sess.Renderer.core != nil {
+			sess.Renderer.core.Log("[red]" + target.Path + ": " + target.Err.Error())
+		}
+		return
+	}
 
 	go func() {
 		pluginrpc.RPCLog("SelectTarget: Configure %s path=%s", name, target.Path)
 		sess.pulseMu.Lock()
 		defer sess.pulseMu.Unlock()
-		err := sess.Plugin.Configure(pluginrpc.ConfigureRequest{Settings: target.Settings})
+		settings := target.Settings
+		if settings[credentialHelperAttr] != "" {
+			entry, err := pluginapi.Secrets().Get(target.Path)
+			if err == nil {
+				settings, err = configureSettings(target.Path, entry)
+			}
+			if err != nil {
+				m.app.QueueUpdateDraw(func() {
+					if sess.Renderer != nil && sess.Renderer.core != nil {
+						sess.Renderer.core.Log("[red]" + err.Error())
+						sess.Renderer.FocusTable()
+					}
+				})
+				return
+			}
+		}
+		err := sess.Plugin.Configure(pluginrpc.ConfigureRequest{Settings: settings})
 		if err != nil {
 			m.app.QueueUpdateDraw(func() {
 				if sess.Renderer != nil && sess.Renderer.core != nil {
@@ -80,7 +103,7 @@ func (m *PluginManager) applyTarget(name string, target secretTarget) {
 			})
 			return
 		}
-		sess.Configured = true
+		m.markConfigured(sess, target.Path)
 		viewID := ""
 		if sess.Renderer != nil {
 			viewID = sess.Renderer.currentView
@@ -125,7 +148,10 @@ func listSecretTargets(pluginName string) ([]secretTarget, error) {
 		if err != nil || entry == nil || pluginapi.IsReferenceEntry(entry) {
 			continue
 		}
-		settings := entryToSettings(entry)
+		settings, resolveErr := resolveEntrySettings(p, entry)
+		if resolveErr != nil {
+			settings = entryToSettings(entry)
+		}
 		label := entry.Title
 		if label == "" {
 			parts := strings.Split(p, "/")
@@ -143,11 +169,15 @@ func listSecretTargets(pluginName string) ([]secretTarget, error) {
 		if env != "" {
 			detail = fmt.Sprintf("%s · %s", env, host)
 		}
+		if resolveErr != nil {
+			detail += " · " + resolveErr.Error()
+		}
 		out = append(out, secretTarget{
 			Path:     p,
 			Settings: settings,
 			Label:    label,
 			Detail:   detail,
+			Err:      resolveErr,
 		})
 	}
 	return out, nil
@@ -7,31 +7,44 @@ import (
 	"github.com/gdamore/tcell/v2"
 )
 
-// OpenThemes lists bundled palettes (Omo + Omarchy) and applies the selection.
+// OpenThemes lists Omo, user and bundled palettes. Moving the highlight
+// previews a theme; Enter keeps and saves it, Esc restores the previous one.
 func (h *Host) OpenThemes() {
 	if h == nil || h.Pages == nil {
 		return
 	}
 	themes := ui.ListThemes()
 	items := make([][]string, 0, len(themes))
-	current := ui.ActiveThemeID()
-	for _, th := range themes {
+	original := ui.ActiveThemeID()
+	start := 0
+	for i, th := range themes {
 		desc := th.Source
-		if th.ID == current {
+		if th.ID == original {
 			desc = "current · " + desc
+			start = i
 		}
 		items = append(items, []string{th.Name, desc})
 	}
-	ui.ShowStandardListSelectorModal(h.Pages, h.App, "Themes", items, func(index int, _ string, cancelled bool) {
-		if cancelled || index < 0 || index >= len(themes) {
-			if h.PluginsList != nil {
-				h.App.SetFocus(h.PluginsList)
-			}
-			return
+	apply := func(id string, save bool) {
+		if save {
+			ui.ApplyAndSaveTheme(id)
+		} else {
+			ui.ApplyNamedTheme(id)
 		}
-		ui.ApplyAndSaveTheme(themes[index].ID)
 		pluginrpc.SetInfoColors(ui.HexInfoKey, ui.HexValue)
 		h.restyle()
+	}
+	preview := func(index int) {
+		if index >= 0 && index < len(themes) {
+			apply(themes[index].ID, false)
+		}
+	}
+	ui.ShowPreviewListSelectorModal(h.Pages, h.App, "Themes", items, start, preview, func(index int, _ string, cancelled bool) {
+		if cancelled || index < 0 || index >= len(themes) {
+			apply(original, false)
+		} else {
+			apply(themes[index].ID, true)
+		}
 		if h.PluginsList != nil {
 			h.App.SetFocus(h.PluginsList)
 		}
@@ -76,7 +89,7 @@ func (h *Host) restyleShell() {
 	}
 	if h.Body != nil {
 		h.Body.SetBackgroundColor(bg)
-		h.Body.SetBordersColor(ui.ColorBorder)
+		h.Body.SetBordersColor(h.frameColor())
 	}
 	if h.Footer != nil {
 		h.Footer.SetBackgroundColor(bg)
@@ -0,0 +1,68 @@
+package host
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/pluginrpc"
+	"omo/pkg/ui"
+)
+
+// themeWatchInterval is how often user themes and accents.yaml are checked
+// for edits.
+const themeWatchInterval = 2 * time.Second
+
+// watchThemes re-applies the theme and target accents whenever a file in
+// ~/.omo/themes or ~/.omo/accents.yaml changes, so edits show live.
+func (h *Host) watchThemes() {
+	last := themeStamp()
+	tick := time.NewTicker(themeWatchInterval)
+	defer tick.Stop()
+	for range tick.C {
+		stamp := themeStamp()
+		if stamp == last {
+			continue
+		}
+		last = stamp
+		h.App.QueueUpdateDraw(h.reloadThemes)
+	}
+}
+
+// themeStamp fingerprints the watched files by name, size and mtime.
+func themeStamp() string {
+	paths, _ := filepath.Glob(filepath.Join(ui.UserThemesDir(), "*.toml"))
+	paths = append(paths, pluginapi.AccentsPath())
+	var b strings.Builder
+	for _, p := range paths {
+		if info, err := os.Stat(p); err == nil {
+			fmt.Fprintf(&b, "%s %d %d\n", p, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	return b.String()
+}
+
+// reloadThemes re-reads user themes and accents and repaints.
+func (h *Host) reloadThemes() {
+	_, errs := ui.LoadUserThemes()
+	for _, err := range errs {
+		h.log("theme: %v", err)
+	}
+	h.loadAccents()
+	ui.ApplyNamedTheme(ui.ActiveThemeID())
+	pluginrpc.SetInfoColors(ui.HexInfoKey, ui.HexValue)
+	h.restyle()
+}
+
+// loadAccents reads accents.yaml; a broken file is logged and disables
+// accents until it is fixed.
+func (h *Host) loadAccents() {
+	rules, err := LoadAccentRules()
+	if err != nil {
+		h.log("accents: %v", err)
+	}
+	h.rpcManager.SetAccentRules(rules)
+}
@@ -0,0 +1,197 @@
+package host
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"omo/pkg/pluginrpc"
+	"omo/pkg/ui"
+)
+
+// editorCommand is $VISUAL, then $EDITOR, then vi, split into argv.
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if argv := strings.Fields(os.Getenv(env)); len(argv) > 0 {
+			return argv
+		}
+	}
+	return []string{"vi"}
+}
+
+// editedText drops the one trailing newline most editors add on save when
+// the original value had none.
+func editedText(original, edited string) string {
+	if !strings.HasSuffix(original, "\n") {
+		edited = strings.TrimSuffix(edited, "\n")
+		edited = strings.TrimSuffix(edited, "\r")
+	}
+	return edited
+}
+
+// editValue suspends the TUI, opens e.Content in the user's editor and,
+// when the text changed, confirms and runs e.Action with the new value.
+// Must be called from the tview thread (e.g. inside QueueUpdate).
+func (r *RPCRenderer) editValue(e pluginrpc.Edit) {
+	f, err := os.CreateTemp("", "omo-edit-*"+e.Suffix)
+	if err != nil {
+		r.core.Log(fmt.Sprintf("[red]edit: %v", err))
+		return
+	}
+	path := f.Name()
+	defer os.Remove(path)
+	_, err = f.WriteString(e.Content)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		r.core.Log(fmt.Sprintf("[red]edit: %v", err))
+		return
+	}
+
+	var runErr error
+	r.app.Suspend(func() {
+		argv := append(editorCommand(), path)
+		cmd := exec.Command(argv[0], argv[1:]...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		runErr = cmd.Run()
+	})
+	r.FocusTable()
+	if runErr != nil {
+		r.core.Log(fmt.Sprintf("[red]editor exited: %v", runErr))
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		r.core.Log(fmt.Sprintf("[red]edit: %v", err))
+		return
+	}
+	value := editedText(e.Content, string(data))
+	if value == e.Content {
+		r.core.Log("[yellow]no changes")
+		return
+	}
+	ui.ShowStandardConfirmationModal(r.pages, r.app, "Save Changes",
+		fmt.Sprintf("Save changes to %s?", e.Title),
+		func(ok bool) {
+			r.FocusTable()
+			if !ok {
+				return
+			}
+			payload := maps.Clone(e.Payload)
+			if payload == nil {
+				payload = map[string]string{}
+			}
+			payload["value"] = value
+			r.runAction(e.Action, payload)
+		})
+}
+
+// promptValueAction asks for what a value_* action needs before running
+// it; destructive ones confirm first.
+func (r *RPCRenderer) promptValueAction(action string) {
+	selected := r.selectedKey()
+	payload := r.selectionPayload()
+	run := func(extra map[string]string) {
+		r.FocusTable()
+		maps.Copy(payload, extra)
+		r.runAction(action, payload)
+	}
+	switch action {
+	case "value_add_field":
+		r.promptChain("Add Field", []string{"Field:", "Value:"}, []string{"", ""}, func(v []string) {
+			run(map[string]string{"field": v[0], "value": v[1]})
+		})
+	case "value_add_member":
+		r.promptChain("Add Member", []string{"Member:"}, []string{""}, func(v []string) {
+			run(map[string]string{"member": v[0]})
+		})
+	case "value_add_scored":
+		r.promptChain("Add Member", []string{"Member:", "Score:"}, []string{"", "0"}, func(v []string) {
+			run(map[string]string{"member": v[0], "score": v[1]})
+		})
+	case "value_score":
+		score := ""
+		if row := r.core.GetSelectedRowData(); len(row) > 1 {
+			score = row[1]
+		}
+		r.promptChain("Set Score", []string{"Score for " + selected + ":"}, []string{score}, func(v []string) {
+			run(map[string]string{"member": selected, "score": v[0]})
+		})
+	case "value_push":
+		r.promptChain("Push", []string{"Value:", "End (head/tail):"}, []string{"", "tail"}, func(v []string) {
+			run(map[string]string{"value": v[0], "end": v[1]})
+		})
+	case "value_pop":
+		r.promptChain("Pop", []string{"End (head/tail):"}, []string{"tail"}, func(v []string) {
+			end := strings.ToLower(strings.TrimSpace(v[0]))
+			ui.ShowStandardConfirmationModal(r.pages, r.app, "Confirm Pop",
+				fmt.Sprintf("Remove the %s element of the list?", end),
+				func(ok bool) {
+					r.FocusTable()
+					if ok {
+						run(map[string]string{"end": end})
+					}
+				})
+		})
+	case "value_remove":
+		ui.ShowStandardConfirmationModal(r.pages, r.app, "Confirm Remove",
+			fmt.Sprintf("Remove %q from the key?", selected),
+			func(ok bool) {
+				r.FocusTable()
+				if ok {
+					run(nil)
+				}
+			})
+	case "value_ttl":
+		r.promptChain("Set TTL", []string{"TTL seconds (blank = none):"}, []string{""}, func(v []string) {
+			ttl := strings.TrimSpace(v[0])
+			if ttl != "" {
+				if n, err := strconv.Atoi(ttl); err != nil || n <= 0 {
+					r.core.Log("[red]TTL must be a positive number of seconds")
+					r.FocusTable()
+					return
+				}
+				run(map[string]string{"ttl": ttl})
+				return
+			}
+			ui.ShowStandardConfirmationModal(r.pages, r.app, "Remove TTL",
+				"Remove the expiry so the key never expires?",
+				func(ok bool) {
+					r.FocusTable()
+					if ok {
+						run(map[string]string{"ttl": ""})
+					}
+				})
+		})
+	}
+}
+
+// promptChain asks each label in turn and calls done with the answers,
+// unless one is cancelled. A blank first answer cancels a multi-step
+// prompt (it names the field, member or value to write).
+func (r *RPCRenderer) promptChain(title string, labels, defaults []string, done func([]string)) {
+	answers := make([]string, 0, len(labels))
+	var ask func(i int)
+	ask = func(i int) {
+		if i == len(labels) {
+			done(answers)
+			return
+		}
+		ui.ShowCompactStyledInputModal(r.pages, r.app, title, labels[i], defaults[i], 40, nil,
+			func(text string, cancelled bool) {
+				if cancelled || (i == 0 && len(labels) > 1 && strings.TrimSpace(text) == "") {
+					r.FocusTable()
+					return
+				}
+				answers = append(answers, text)
+				ask(i + 1)
+			})
+	}
+	ask(0)
+}
@@ -0,0 +1,31 @@
+package host
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditedText(t *testing.T) {
+	for _, tc := range []struct{ original, edited, want string }{
+		{"abc", "abd\n", "abd"},
+		{"abc", "abd\r\n", "abd"},
+		{"abc", "abd\n\n", "abd\n"},
+		{"line\n", "line2\n", "line2\n"},
+	} {
+		if got := editedText(tc.original, tc.edited); got != tc.want {
+			t.Fatalf("editedText(%q, %q) = %q, want %q", tc.original, tc.edited, got, tc.want)
+		}
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	if got, want := editorCommand(), []string{"code", "--wait"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("editorCommand = %v, want %v", got, want)
+	}
+	t.Setenv("EDITOR", "")
+	if got := editorCommand(); got[0] != "vi" {
+		t.Fatalf("editorCommand = %v, want vi", got)
+	}
+}
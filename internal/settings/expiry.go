@@ -0,0 +1,35 @@
+package settings
+
+import (
+	"time"
+
+	"omo/pkg/pluginapi"
+)
+
+// rowsExpiry lists every vault entry with a known credential expiry
+// (expires_at or a certificate's NotAfter), soonest first.
+func rowsExpiry() [][]string {
+	if !pluginapi.HasSecrets() {
+		return [][]string{{"(secrets)", "—", "provider not loaded"}}
+	}
+	list, err := pluginapi.ListCredentialExpiries(pluginapi.Secrets(), "")
+	if err != nil {
+		return [][]string{{"(secrets)", "error", err.Error()}}
+	}
+	if len(list) == 0 {
+		return [][]string{{"(none)", "—", "no entry has expires_at or a certificate"}}
+	}
+	now := time.Now()
+	rows := make([][]string, 0, len(list))
+	for _, c := range list {
+		badge := pluginapi.ExpiryBadge(c.ExpiresAt, now)
+		switch {
+		case c.ExpiresAt.Before(now):
+			badge = "[red]" + badge + "[-]"
+		case c.Soon(now):
+			badge = "[yellow]" + badge + "[-]"
+		}
+		rows = append(rows, []string{c.Path, badge, c.ExpiresAt.Local().Format("2006-01-02 15:04") + " · " + c.Source})
+	}
+	return rows
+}
@@ -0,0 +1,117 @@
+package settings
+
+import (
+	"fmt"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/secrets/importer"
+	"omo/pkg/ui"
+)
+
+// startImport asks for a source and file, then shows a dry-run preview in
+// the import view. Nothing is written until W confirms.
+func (m *Manager) startImport() {
+	if !pluginapi.HasSecrets() {
+		m.setStatus("[red]Secrets provider not loaded")
+		return
+	}
+	items := make([][]string, len(importer.Sources))
+	for i, src := range importer.Sources {
+		items[i] = []string{src.Name, src.Description}
+	}
+	ui.ShowStandardListSelectorModal(m.pages, m.app, "Import from", items, func(index int, _ string, cancelled bool) {
+		if cancelled || index < 0 || index >= len(importer.Sources) {
+			m.app.SetFocus(m.core.GetTable())
+			return
+		}
+		src := importer.Sources[index]
+		ui.ShowCompactStyledInputModal(m.pages, m.app, "Import "+src.Name, "File", src.DefaultPath(), 48, nil,
+			func(path string, cancelled bool) {
+				m.app.SetFocus(m.core.GetTable())
+				if cancelled {
+					return
+				}
+				m.previewImport(src.Name, path)
+			})
+	})
+}
+
+func (m *Manager) previewImport(source, path string) {
+	cands, err := importer.Load(source, path, importer.Options{})
+	if err != nil {
+		m.setStatus(fmt.Sprintf("[red]Import %s: %v", source, err))
+		return
+	}
+	plan, err := importer.Plan(pluginapi.Secrets(), cands)
+	if err != nil {
+		m.setStatus(fmt.Sprintf("[red]Import %s: %v", source, err))
+		return
+	}
+	m.importSource, m.importPlan = source, plan
+	m.setView(viewImport)
+	hint := ""
+	if importPending(plan) > 0 {
+		hint = " · W to write"
+	}
+	m.setStatus(fmt.Sprintf("[yellow]Preview %s: %s%s", source, importer.Summary(plan), hint))
+}
+
+func (m *Manager) writeImport() {
+	n := importPending(m.importPlan)
+	if n == 0 {
+		m.setStatus("[yellow]Nothing to import")
+		return
+	}
+	ui.ShowStandardConfirmationModal(m.pages, m.app, "Import",
+		fmt.Sprintf("Create %d KeePass entr%s from %s?", n, pluralY(n), m.importSource),
+		func(confirmed bool) {
+			m.app.SetFocus(m.core.GetTable())
+			if !confirmed {
+				m.setStatus("[yellow]Import cancelled")
+				return
+			}
+			saved, err := importer.Apply(pluginapi.Secrets(), m.importPlan)
+			if err != nil {
+				m.setStatus(fmt.Sprintf("[red]Import: %v (%d saved)", err, saved))
+				return
+			}
+			m.importPlan = nil
+			m.setStatus(fmt.Sprintf("[green]Imported %d entr%s from %s", saved, pluralY(saved), m.importSource))
+			m.setView(viewSecrets)
+		})
+}
+
+func rowsImport(plan []importer.PlanItem) [][]string {
+	if len(plan) == 0 {
+		return [][]string{{"(no preview)", "—", "press I to pick a source"}}
+	}
+	rows := make([][]string, 0, len(plan))
+	for _, item := range plan {
+		detail := importer.Describe(item.Entry)
+		switch item.Status {
+		case importer.StatusExists:
+			detail = "already in vault"
+		case importer.StatusDuplicate:
+			detail = "same endpoint as " + item.Existing
+		}
+		rows = append(rows, []string{item.Path, string(item.Status), detail})
+	}
+	return rows
+}
+
+func importPending(plan []importer.PlanItem) int {
+	n := 0
+	for _, item := range plan {
+		if item.Status == importer.StatusNew {
+			n++
+		}
+	}
+	return n
+}
+
+func pluralY(n int) string {
+	if n == 1 {
+		return "y"
+	}
+	return "ies"
+}
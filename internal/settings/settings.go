@@ -11,6 +11,7 @@ import (
 
 	"omo/pkg/pluginapi"
 	"omo/pkg/secrets"
+	"omo/pkg/secrets/importer"
 	"omo/pkg/ui"
 
 	"github.com/rivo/tview"
@@ -23,6 +24,8 @@ const (
 	viewSecrets  = "secrets"
 	viewLogs     = "logs"
 	viewEnv      = "env"
+	viewImport   = "import"
+	viewExpiry   = "expiry"
 )
 
 // Manager is the host Settings / Info UI (sibling of Package Manager).
@@ -36,6 +39,9 @@ type Manager struct {
 	onRefresh func() // host RefreshPlugins
 	statusMsg string
 	closed    bool
+
+	importSource string              // last previewed import source
+	importPlan   []importer.PlanItem // dry-run result shown in the import view
 }
 
 // New builds Settings. onClose should restore MainFrame / focus plugins list.
@@ -130,13 +136,15 @@ func (m *Manager) setStatus(msg string) {
 
 func (m *Manager) installHelp() {
 	m.core.SetHelpSections([]ui.HelpSection{
-		{Title: "Views (0-5)", Bindings: []ui.KeyBindingHelp{
+		{Title: "Views (0-7)", Bindings: []ui.KeyBindingHelp{
 			{Key: "0", Label: "Overview"},
 			{Key: "1", Label: "Paths"},
 			{Key: "2", Label: "Plugins"},
 			{Key: "3", Label: "Secrets"},
 			{Key: "4", Label: "Logs"},
 			{Key: "5", Label: "Env"},
+			{Key: "6", Label: "Import preview"},
+			{Key: "7", Label: "Credential expiry"},
 		}},
 		{Title: "Actions", Bindings: []ui.KeyBindingHelp{
 			{Key: "S", Label: "Sync plugin index"},
@@ -144,6 +152,8 @@ func (m *Manager) installHelp() {
 			{Key: "K", Label: "Reload secrets"},
 			{Key: "L", Label: "Clear logs"},
 			{Key: "X", Label: "Reset secrets help"},
+			{Key: "I", Label: "Import targets (ssh, kube, aws, pgpass, docker, .env)"},
+			{Key: "W", Label: "Write previewed import"},
 			{Key: "E", Label: "Row detail"},
 			{Key: "Q", Label: "Back"},
 		}},
@@ -163,6 +173,8 @@ func (m *Manager) rebindChrome() {
 	m.core.AddViewBinding("3", "Secrets", viewSecrets, func() { m.setView(viewSecrets) })
 	m.core.AddViewBinding("4", "Logs", viewLogs, func() { m.setView(viewLogs) })
 	m.core.AddViewBinding("5", "Env", viewEnv, func() { m.setView(viewEnv) })
+	m.core.AddViewBinding("6", "Import", viewImport, func() { m.setView(viewImport) })
+	m.core.AddViewBinding("7", "Expiry", viewExpiry, func() { m.setView(viewExpiry) })
 	m.core.SetActiveView(m.viewID)
 
 	m.core.AddKeyBinding("R", "Refresh", m.refreshLocal)
@@ -172,6 +184,10 @@ func (m *Manager) rebindChrome() {
 	m.core.AddKeyBinding("K", "Reload Sec", m.reloadSecrets)
 	m.core.AddKeyBinding("L", "Clear Logs", m.clearLogs)
 	m.core.AddKeyBinding("X", "Reset Help", m.resetSecretsHelp)
+	m.core.AddKeyBinding("I", "Import", m.startImport)
+	if m.viewID == viewImport && importPending(m.importPlan) > 0 {
+		m.core.AddKeyBinding("W", "Write Import", m.writeImport)
+	}
 	m.core.AddKeyBinding("E", "Detail", func() {
 		m.showRowDetail(m.core.GetSelectedRow())
 	})
@@ -210,6 +226,10 @@ func (m *Manager) refreshRows() ([][]string, error) {
 		return rowsLogs(), nil
 	case viewEnv:
 		return rowsEnv(), nil
+	case viewImport:
+		return rowsImport(m.importPlan), nil
+	case viewExpiry:
+		return rowsExpiry(), nil
 	default:
 		return rowsOverview(m.version), nil
 	}
@@ -292,6 +312,13 @@ func rowsPaths() [][]string {
 		{"keys/omo.key", secrets.DefaultKeyPath(), "master key — back up"},
 		{"index.yaml", pluginapi.IndexPath(), "plugin catalog cache"},
 		{"installed.yaml", pluginapi.InstalledManifestPath(), "installed versions"},
+		{"dashboards.yaml", pluginapi.DashboardsPath(), "dashboard layouts"},
+		{"metrics.json", pluginapi.MetricsPath(), "dashboard metric history"},
+		{"alerts.yaml", pluginapi.AlertsPath(), "dashboard alert rules"},
+		{"keymap.yaml", pluginapi.KeymapPath(), "key remaps"},
+		{"columns.yaml", pluginapi.ColumnsPath(), "table column layouts"},
+		{"themes", ui.UserThemesDir(), "user themes (*.toml)"},
+		{"accents.yaml", pluginapi.AccentsPath(), "frame colors per target"},
 		{"logs", pluginapi.LogsDir(), "host + plugin logs"},
 	}
 	rows := make([][]string, 0, len(items))
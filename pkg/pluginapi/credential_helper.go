@@ -0,0 +1,9 @@
+package pluginapi
+
+// CredentialHelperAttr names a command the host runs through sh to fetch a
+// short-lived credential for an entry; CredentialHelperTimeoutAttr bounds
+// how long it may run.
+const (
+	CredentialHelperAttr        = "credential_helper"
+	CredentialHelperTimeoutAttr = "credential_helper_timeout"
+)
@@ -0,0 +1,168 @@
+package pluginapi
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ExpiresAtAttr is the optional custom attribute recording when an entry's
+// credential stops working (RFC 3339 or YYYY-MM-DD). Plugins that issue
+// credentials fill it in via pluginrpc.IssuedCredential.
+const ExpiresAtAttr = "expires_at"
+
+// ExpiryWarning is how far ahead omo starts warning about a credential.
+const ExpiryWarning = 14 * 24 * time.Hour
+
+var expiryLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseExpiry accepts the layouts omo writes and people commonly type.
+func ParseExpiry(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range expiryLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised expiry %q (want RFC 3339 or YYYY-MM-DD)", s)
+}
+
+// FormatExpiry is the canonical stored form of an expiry.
+func FormatExpiry(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// CredentialExpiry returns the earliest known expiry of e: the expires_at
+// attribute or the NotAfter of any certificate held in (or pointed at by) a
+// custom attribute. source names the attribute it came from.
+func CredentialExpiry(e *SecretEntry) (at time.Time, source string, ok bool) {
+	if e == nil {
+		return time.Time{}, "", false
+	}
+	consider := func(t time.Time, src string) {
+		if !ok || t.Before(at) {
+			at, source, ok = t, src, true
+		}
+	}
+	keys := make([]string, 0, len(e.CustomAttributes))
+	for k := range e.CustomAttributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := e.CustomAttributes[k]
+		if k == ExpiresAtAttr {
+			if t, err := ParseExpiry(v); err == nil {
+				consider(t, k)
+			}
+			continue
+		}
+		if t, found := certNotAfter(k, v); found {
+			consider(t, k)
+		}
+	}
+	return at, source, ok
+}
+
+// certNotAfter reads the first certificate from an inline PEM value, or from
+// a file when the attribute name says it is a certificate path.
+func certNotAfter(name, value string) (time.Time, bool) {
+	data := []byte(value)
+	if !strings.Contains(value, "-----BEGIN CERTIFICATE-----") {
+		n := strings.ToLower(name)
+		if !strings.Contains(n, "cert") || strings.ContainsAny(value, "\n") || value == "" {
+			return time.Time{}, false
+		}
+		raw, err := os.ReadFile(expandTilde(value))
+		if err != nil {
+			return time.Time{}, false
+		}
+		data = raw
+	}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return time.Time{}, false
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return cert.NotAfter, true
+	}
+}
+
+func expandTilde(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return home + path[1:]
+		}
+	}
+	return path
+}
+
+// ExpiringCredential is one vault entry with a known expiry.
+type ExpiringCredential struct {
+	Path      string
+	ExpiresAt time.Time
+	Source    string
+}
+
+// Soon reports whether the credential is expired or within ExpiryWarning.
+func (c ExpiringCredential) Soon(now time.Time) bool {
+	return c.ExpiresAt.Sub(now) <= ExpiryWarning
+}
+
+// ListCredentialExpiries returns every non-reference entry under prefix that
+// has a known expiry, soonest first. extends and ref:// values are resolved
+// so shared certificates count for every entry that uses them.
+func ListCredentialExpiries(p SecretsProvider, prefix string) ([]ExpiringCredential, error) {
+	paths, err := p.List(prefix)
+	if err != nil {
+		return nil, err
+	}
+	var out []ExpiringCredential
+	for _, path := range paths {
+		e, err := p.Get(path)
+		if err != nil || e == nil || IsReferenceEntry(e) {
+			continue
+		}
+		if resolved, err := ResolveSecretEntry(p, path, e); err == nil {
+			e = resolved
+		}
+		if at, src, ok := CredentialExpiry(e); ok {
+			out = append(out, ExpiringCredential{Path: path, ExpiresAt: at, Source: src})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ExpiresAt.Before(out[j].ExpiresAt) })
+	return out, nil
+}
+
+// ExpiryBadge is a short label such as "expired", "3d" or "5h".
+func ExpiryBadge(at, now time.Time) string {
+	d := at.Sub(now)
+	switch {
+	case d <= 0:
+		return "expired"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes())+1)
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,80 @@
+package pluginapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func selfSignedPEM(t *testing.T, notAfter time.Time) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "omo-test"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestCredentialExpiryPicksEarliest(t *testing.T) {
+	certEnd := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	e := &SecretEntry{CustomAttributes: map[string]string{
+		ExpiresAtAttr: "2031-06-01",
+		"client_cert": selfSignedPEM(t, certEnd),
+	}}
+	at, src, ok := CredentialExpiry(e)
+	if !ok || !at.Equal(certEnd) || src != "client_cert" {
+		t.Fatalf("CredentialExpiry = %v, %q, %v; want %v from client_cert", at, src, ok, certEnd)
+	}
+
+	delete(e.CustomAttributes, "client_cert")
+	at, src, ok = CredentialExpiry(e)
+	if !ok || src != ExpiresAtAttr || at.Year() != 2031 {
+		t.Fatalf("CredentialExpiry = %v, %q, %v; want 2031 from expires_at", at, src, ok)
+	}
+
+	if _, _, ok := CredentialExpiry(&SecretEntry{CustomAttributes: map[string]string{"port": "22"}}); ok {
+		t.Fatal("entry without expiry reported one")
+	}
+}
+
+func TestParseExpiryRoundTrip(t *testing.T) {
+	want := time.Date(2027, 3, 4, 5, 6, 7, 0, time.UTC)
+	got, err := ParseExpiry(FormatExpiry(want))
+	if err != nil || !got.Equal(want) {
+		t.Fatalf("ParseExpiry = %v, %v; want %v", got, err, want)
+	}
+	if _, err := ParseExpiry("next tuesday"); err == nil {
+		t.Fatal("want error for free text")
+	}
+}
+
+func TestExpiryBadge(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for d, want := range map[time.Duration]string{
+		-time.Hour:          "expired",
+		30 * time.Minute:    "31m",
+		5 * time.Hour:       "5h",
+		3 * 24 * time.Hour:  "3d",
+		20 * 24 * time.Hour: "20d",
+	} {
+		if got := ExpiryBadge(now.Add(d), now); got != want {
+			t.Fatalf("ExpiryBadge(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -77,6 +77,47 @@ func PluginExportsDir(pluginName string) string {
 	return filepath.Join(ExportsDir(), pluginName)
 }
 
+// DashboardsPath returns ~/.omo/dashboards.yaml (named dashboard layouts).
+func DashboardsPath() string {
+	return filepath.Join(OmoDir(), "dashboards.yaml")
+}
+
+// AlertsPath returns ~/.omo/alerts.yaml (dashboard alert rules and hooks).
+func AlertsPath() string {
+	return filepath.Join(OmoDir(), "alerts.yaml")
+}
+
+// KeymapPath returns ~/.omo/keymap.yaml (user key remaps for host and plugins).
+func KeymapPath() string {
+	return filepath.Join(OmoDir(), "keymap.yaml")
+}
+
+// ColumnsPath returns ~/.omo/columns.yaml (per plugin view table column layouts).
+func ColumnsPath() string {
+	return filepath.Join(OmoDir(), "columns.yaml")
+}
+
+// AccentsPath returns ~/.omo/accents.yaml (frame colors per KeePass target glob).
+func AccentsPath() string {
+	return filepath.Join(OmoDir(), "accents.yaml")
+}
+
+// ConsoleHistoryPath returns ~/.omo/console_history.yaml (plugin console
+// command history per KeePass target).
+func ConsoleHistoryPath() string {
+	return filepath.Join(OmoDir(), "console_history.yaml")
+}
+
+// DaemonSocketPath returns ~/.omo/daemon.sock (the `omo daemon` RPC socket).
+func DaemonSocketPath() string {
+	return filepath.Join(OmoDir(), "daemon.sock")
+}
+
+// MetricsPath returns ~/.omo/metrics.json (persisted dashboard metric history).
+func MetricsPath() string {
+	return filepath.Join(OmoDir(), "metrics.json")
+}
+
 // IndexPath returns the absolute path to ~/.omo/index.yaml.
 func IndexPath() string {
 	return filepath.Join(OmoDir(), "index.yaml")
@@ -0,0 +1,179 @@
+package pluginapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Shared credentials are stored once and referenced from other entries:
+//
+//	jump_key = ref://ssh/shared/bastion#private_key   (one value)
+//	extends  = ssh/shared/bastion                      (whole entry)
+//
+// A ref:// value copies one field of another entry. The fragment names the
+// field (username, password, url, notes, title or any custom attribute);
+// without it the referencing attribute's own name is used.
+//
+// extends (comma-separated paths, applied left to right) copies every
+// non-empty field of the base entries; the entry's own non-empty fields win.
+const (
+	SecretRefScheme = "ref://"
+	ExtendsAttr     = "extends"
+)
+
+// IsSecretRef reports whether v is a ref:// value.
+func IsSecretRef(v string) bool {
+	return strings.HasPrefix(v, SecretRefScheme)
+}
+
+// ParseSecretRef splits ref://plugin/env/name#field into path and field.
+func ParseSecretRef(v string) (path, field string, err error) {
+	if !IsSecretRef(v) {
+		return "", "", fmt.Errorf("not a %s reference: %q", SecretRefScheme, v)
+	}
+	rest := strings.TrimPrefix(v, SecretRefScheme)
+	if i := strings.Index(rest, "#"); i >= 0 {
+		rest, field = rest[:i], rest[i+1:]
+	}
+	if strings.Count(rest, "/") != 2 {
+		return "", "", fmt.Errorf("reference %q: path must be plugin/env/name", v)
+	}
+	return rest, field, nil
+}
+
+// ResolveSecretEntry returns a copy of entry (stored at path) with extends
+// applied and every ref:// value replaced. Cycles and missing targets are
+// errors naming the chain that caused them.
+func ResolveSecretEntry(p SecretsProvider, path string, entry *SecretEntry) (*SecretEntry, error) {
+	r := &secretResolver{p: p, cache: map[string]*SecretEntry{}}
+	return r.resolve(path, entry, nil)
+}
+
+type secretResolver struct {
+	p     SecretsProvider
+	cache map[string]*SecretEntry
+}
+
+func (r *secretResolver) resolve(path string, entry *SecretEntry, stack []string) (*SecretEntry, error) {
+	stack = append(stack, path)
+
+	out := &SecretEntry{CustomAttributes: map[string]string{}}
+
+	// Bases first, so the entry's own fields override them.
+	if ext := strings.TrimSpace(entry.CustomAttributes[ExtendsAttr]); ext != "" {
+		for _, base := range strings.Split(ext, ",") {
+			base = strings.TrimPrefix(strings.TrimSpace(base), SecretRefScheme)
+			if base == "" {
+				continue
+			}
+			resolved, err := r.load(base, stack)
+			if err != nil {
+				return nil, fmt.Errorf("%s extends %s: %w", path, base, err)
+			}
+			overlay(out, resolved)
+		}
+	}
+	overlay(out, entry)
+	out.Title = entry.Title
+	delete(out.CustomAttributes, ExtendsAttr)
+
+	var err error
+	field := func(name string, v *string) {
+		if err != nil || !IsSecretRef(*v) {
+			return
+		}
+		*v, err = r.lookup(path, name, *v, stack)
+	}
+	field("username", &out.UserName)
+	field("password", &out.Password)
+	field("url", &out.URL)
+	field("notes", &out.Notes)
+	for k, v := range out.CustomAttributes {
+		field(k, &v)
+		if err != nil {
+			return nil, err
+		}
+		out.CustomAttributes[k] = v
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if path != "" {
+		r.cache[path] = out
+	}
+	return out, nil
+}
+
+func (r *secretResolver) load(path string, stack []string) (*SecretEntry, error) {
+	if done, ok := r.cache[path]; ok {
+		return done, nil
+	}
+	for _, s := range stack {
+		if s == path {
+			return nil, fmt.Errorf("reference cycle: %s", strings.Join(append(stack, path), " → "))
+		}
+	}
+	e, err := r.p.Get(path)
+	if err != nil || e == nil {
+		return nil, fmt.Errorf("entry %s not found", path)
+	}
+	return r.resolve(path, e, stack)
+}
+
+// lookup resolves one ref:// value found in field name of entry at path.
+func (r *secretResolver) lookup(path, name, ref string, stack []string) (string, error) {
+	target, field, err := ParseSecretRef(ref)
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %w", path, name, err)
+	}
+	if field == "" {
+		field = name
+	}
+	e, err := r.load(target, stack)
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %w", path, name, err)
+	}
+	v, ok := secretField(e, field)
+	if !ok {
+		return "", fmt.Errorf("%s %s: %s has no field %q", path, name, target, field)
+	}
+	return v, nil
+}
+
+func secretField(e *SecretEntry, field string) (string, bool) {
+	switch strings.ToLower(field) {
+	case "username", "user":
+		return e.UserName, true
+	case "password":
+		return e.Password, true
+	case "url", "host":
+		return e.URL, true
+	case "notes":
+		return e.Notes, true
+	case "title", "name":
+		return e.Title, true
+	}
+	v, ok := e.CustomAttributes[field]
+	return v, ok
+}
+
+// overlay copies the non-empty fields of src onto dst. Reference template
+// markers are never inherited.
+func overlay(dst, src *SecretEntry) {
+	set := func(d *string, v string) {
+		if v != "" {
+			*d = v
+		}
+	}
+	set(&dst.UserName, src.UserName)
+	set(&dst.Password, src.Password)
+	set(&dst.URL, src.URL)
+	set(&dst.Notes, src.Notes)
+	for k, v := range src.CustomAttributes {
+		if k == ReferenceEntryAttr || v == "" {
+			continue
+		}
+		dst.CustomAttributes[k] = v
+	}
+}
@@ -0,0 +1,66 @@
+package pluginapi
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type memSecrets map[string]*SecretEntry
+
+func (m memSecrets) Get(path string) (*SecretEntry, error) {
+	if e, ok := m[path]; ok {
+		return e, nil
+	}
+	return nil, fmt.Errorf("not found")
+}
+func (m memSecrets) Put(path string, e *SecretEntry) error { m[path] = e; return nil }
+func (m memSecrets) Delete(path string) error              { delete(m, path); return nil }
+func (m memSecrets) List(string) ([]string, error)         { return nil, nil }
+func (m memSecrets) Reload() error                         { return nil }
+func (m memSecrets) Close() error                          { return nil }
+
+func TestResolveSecretEntry(t *testing.T) {
+	vault := memSecrets{
+		"ssh/shared/bastion": {Title: "bastion", URL: "10.0.0.1", UserName: "ops",
+			CustomAttributes: map[string]string{"private_key": "KEY", "port": "2222"}},
+	}
+	entry := &SecretEntry{Title: "web", URL: "10.0.1.5", CustomAttributes: map[string]string{
+		ExtendsAttr: "ssh/shared/bastion",
+		"jump_key":  "ref://ssh/shared/bastion#private_key",
+		"jump_host": "ref://ssh/shared/bastion#url",
+	}}
+	got, err := ResolveSecretEntry(vault, "ssh/prod/web", entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.URL != "10.0.1.5" || got.UserName != "ops" || got.Title != "web" {
+		t.Fatalf("fields = %+v", got)
+	}
+	ca := got.CustomAttributes
+	if ca["jump_key"] != "KEY" || ca["jump_host"] != "10.0.0.1" || ca["port"] != "2222" {
+		t.Fatalf("attributes = %v", ca)
+	}
+	if _, ok := ca[ExtendsAttr]; ok {
+		t.Fatalf("extends should be consumed, got %v", ca)
+	}
+}
+
+func TestResolveSecretEntryCycle(t *testing.T) {
+	vault := memSecrets{
+		"ssh/a/a": {CustomAttributes: map[string]string{ExtendsAttr: "ssh/b/b"}},
+		"ssh/b/b": {CustomAttributes: map[string]string{"key": "ref://ssh/a/a#key"}},
+	}
+	_, err := ResolveSecretEntry(vault, "ssh/a/a", vault["ssh/a/a"])
+	if err == nil || !strings.Contains(err.Error(), "reference cycle: ssh/a/a → ssh/b/b → ssh/a/a") {
+		t.Fatalf("err = %v, want reference cycle", err)
+	}
+}
+
+func TestResolveSecretEntryMissingField(t *testing.T) {
+	vault := memSecrets{"ssh/shared/bastion": {CustomAttributes: map[string]string{}}}
+	entry := &SecretEntry{CustomAttributes: map[string]string{"ssl_ca_cert": "ref://ssh/shared/bastion"}}
+	if _, err := ResolveSecretEntry(vault, "postgres/prod/db", entry); err == nil {
+		t.Fatal("want error for missing field")
+	}
+}
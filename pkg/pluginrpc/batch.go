@@ -0,0 +1,32 @@
+package pluginrpc
+
+import "encoding/json"
+
+// PayloadMarked is the ActionRequest payload key holding the marked rows as
+// a JSON list. Each entry has the same keys as a single-row payload (key,
+// col0, col1, …). It is set whenever rows are marked, so any action can act
+// on the whole selection; actions declared with KeyBinding.Batch are instead
+// called once per marked row.
+const PayloadMarked = "marked"
+
+// EncodeMarkedRows encodes row payloads for PayloadMarked.
+func EncodeMarkedRows(rows []map[string]string) string {
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return "[]"
+	}
+	return string(data)
+}
+
+// MarkedRows decodes PayloadMarked; nil when no rows are marked.
+func MarkedRows(payload map[string]string) []map[string]string {
+	raw := payload[PayloadMarked]
+	if raw == "" {
+		return nil
+	}
+	var rows []map[string]string
+	if err := json.Unmarshal([]byte(raw), &rows); err != nil {
+		return nil
+	}
+	return rows
+}
@@ -0,0 +1,16 @@
+package pluginrpc
+
+// Semantic color slots. Use them as tview color tags in rows, info text and
+// details ("[ok]running[-]", "[error::b]down[-]"); the host paints them with
+// the active theme's colors, so they stay readable on light and dark themes.
+const (
+	ColorOK    = "ok"
+	ColorWarn  = "warn"
+	ColorError = "error"
+	ColorMuted = "muted"
+)
+
+// Tint wraps s in a semantic color tag: Tint(ColorOK, "running").
+func Tint(slot, s string) string {
+	return "[" + slot + "]" + s + "[-]"
+}
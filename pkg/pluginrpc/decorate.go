@@ -160,6 +160,20 @@ func Widget(title, status, info string, pairs [][2]string) ViewData {
 	}
 }
 
+// WithMetrics attaches numeric samples to a dashboard widget so the host can
+// keep their history.
+func WithMetrics(v ViewData, metrics ...Metric) ViewData {
+	v.Metrics = append(v.Metrics, metrics...)
+	return v
+}
+
+// WithDetail sets the read-only action the host calls to fill the detail
+// pane for the highlighted row.
+func WithDetail(v ViewData, action string) ViewData {
+	v.DetailAction = action
+	return v
+}
+
 // Logs builds a decorated connected logs view.
 func (u ViewUI) Logs(viewID, title, info, body string, actions ...KeyBinding) ViewData {
 	return u.Decorate(Logs(viewID, title, info, "connected", body), actions...)
@@ -0,0 +1,211 @@
+package pluginrpc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Detail is a structured document about one row (inspect output, an issue,
+// a resource). The host renders it in the detail pane next to the table, or
+// in a modal when an action returns it.
+type Detail struct {
+	Title    string
+	Sections []DetailSection
+}
+
+// DetailSection is a titled block. Any mix of its parts may be set; they
+// render in field, text, table, code, subsection order. Subsections nest
+// with an indent.
+type DetailSection struct {
+	Title    string
+	Fields   []DetailField
+	Text     string
+	Table    *DetailTable
+	Code     *DetailCode
+	Sections []DetailSection
+}
+
+// DetailField is one key/value line.
+type DetailField struct {
+	Key   string
+	Value string
+}
+
+// DetailTable is a small nested table (ports, mounts, comments, …).
+type DetailTable struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// DetailCode is a preformatted block. Lang ("json", "yaml", "sh", …) picks
+// the highlighting; unknown languages render plain.
+type DetailCode struct {
+	Lang string
+	Body string
+}
+
+// Fields builds key/value pairs from alternating arguments, skipping pairs
+// with an empty value: Fields("Image", img, "Status", st).
+func Fields(kv ...string) []DetailField {
+	out := make([]DetailField, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		if kv[i+1] != "" {
+			out = append(out, DetailField{Key: kv[i], Value: kv[i+1]})
+		}
+	}
+	return out
+}
+
+// Render formats the document with tview color tags. Plugin text is escaped.
+func (d Detail) Render() string {
+	var b strings.Builder
+	if d.Title != "" {
+		fmt.Fprintf(&b, "[%s::b]%s[-::-]\n", infoOrange, escapeTags(d.Title))
+	}
+	for i, s := range d.Sections {
+		if i > 0 || d.Title != "" {
+			b.WriteString("\n")
+		}
+		renderSection(&b, s, "")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func renderSection(b *strings.Builder, s DetailSection, indent string) {
+	if s.Title != "" {
+		fmt.Fprintf(b, "%s[%s::bu]%s[-::-]\n", indent, infoOrange, escapeTags(s.Title))
+	}
+	width := 0
+	for _, f := range s.Fields {
+		width = max(width, len([]rune(f.Key)))
+	}
+	for _, f := range s.Fields {
+		pad := strings.Repeat(" ", width-len([]rune(f.Key)))
+		lines := strings.Split(f.Value, "\n")
+		fmt.Fprintf(b, "%s[%s]%s%s[-] : [%s]%s[-]\n", indent, infoOrange, escapeTags(f.Key), pad, infoValue, escapeTags(lines[0]))
+		for _, line := range lines[1:] {
+			fmt.Fprintf(b, "%s%s   [%s]%s[-]\n", indent, strings.Repeat(" ", width), infoValue, escapeTags(line))
+		}
+	}
+	if s.Text != "" {
+		for _, line := range strings.Split(strings.TrimRight(s.Text, "\n"), "\n") {
+			fmt.Fprintf(b, "%s[%s]%s[-]\n", indent, infoValue, escapeTags(line))
+		}
+	}
+	if s.Table != nil {
+		renderDetailTable(b, *s.Table, indent)
+	}
+	if s.Code != nil {
+		for _, line := range strings.Split(strings.TrimRight(s.Code.Body, "\n"), "\n") {
+			fmt.Fprintf(b, "%s[muted]│[-] %s\n", indent, highlightLine(s.Code.Lang, line))
+		}
+	}
+	for _, sub := range s.Sections {
+		renderSection(b, sub, indent+"  ")
+	}
+}
+
+func renderDetailTable(b *strings.Builder, t DetailTable, indent string) {
+	widths := make([]int, len(t.Headers))
+	for i, h := range t.Headers {
+		widths[i] = len([]rune(h))
+	}
+	for _, row := range t.Rows {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], len([]rune(cell)))
+			}
+		}
+	}
+	line := func(cells []string, color string) {
+		parts := make([]string, len(widths))
+		for i := range widths {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			parts[i] = escapeTags(cell) + strings.Repeat(" ", widths[i]-len([]rune(cell)))
+		}
+		fmt.Fprintf(b, "%s[%s]%s[-]\n", indent, color, strings.TrimRight(strings.Join(parts, "  "), " "))
+	}
+	line(t.Headers, infoOrange)
+	for _, row := range t.Rows {
+		line(row, infoValue)
+	}
+	if len(t.Rows) == 0 {
+		fmt.Fprintf(b, "%s[muted](none)[-]\n", indent)
+	}
+}
+
+// Code highlighting is line based and deliberately small: keys, strings,
+// numbers, literals and comments.
+var (
+	reJSONToken = regexp.MustCompile(`"(?:[^"\\]|\\.)*"(\s*:)?|-?\d+(?:\.\d+)?(?:[eE][+-]?\d+)?|\btrue\b|\bfalse\b|\bnull\b`)
+	reYAMLKey   = regexp.MustCompile(`^(\s*(?:- )?)([^:#\s][^:#]*?):(\s|$)`)
+	codeKey     = "#7aa2f7"
+	codeString  = ColorOK
+	codeNumber  = ColorWarn
+	codeComment = ColorMuted
+)
+
+func highlightLine(lang, line string) string {
+	switch strings.ToLower(lang) {
+	case "json":
+		return highlightJSON(line)
+	case "yaml", "yml":
+		body, comment := splitComment(line, "#")
+		if m := reYAMLKey.FindStringSubmatchIndex(body); m != nil {
+			body = escapeTags(body[:m[4]]) + fmt.Sprintf("[%s]%s[-]", codeKey, escapeTags(body[m[4]:m[5]])) + escapeTags(body[m[5]:])
+		} else {
+			body = escapeTags(body)
+		}
+		return body + comment
+	case "sh", "bash", "shell", "toml", "ini", "conf":
+		body, comment := splitComment(line, "#")
+		return escapeTags(body) + comment
+	case "sql", "lua":
+		body, comment := splitComment(line, "--")
+		return escapeTags(body) + comment
+	}
+	return escapeTags(line)
+}
+
+func highlightJSON(line string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range reJSONToken.FindAllStringSubmatchIndex(line, -1) {
+		b.WriteString(escapeTags(line[last:m[0]]))
+		tok := line[m[0]:m[1]]
+		color := codeNumber
+		switch {
+		case m[2] >= 0: // "key":
+			color = codeKey
+		case strings.HasPrefix(tok, `"`):
+			color = codeString
+		}
+		fmt.Fprintf(&b, "[%s]%s[-]", color, escapeTags(tok))
+		last = m[1]
+	}
+	b.WriteString(escapeTags(line[last:]))
+	return b.String()
+}
+
+// splitComment returns the line before marker and the comment as a muted
+// tagged string. Markers inside quotes are not detected.
+func splitComment(line, marker string) (string, string) {
+	i := strings.Index(line, marker)
+	if i < 0 || strings.Count(line[:i], `"`)%2 == 1 || strings.Count(line[:i], `'`)%2 == 1 {
+		return line, ""
+	}
+	return line[:i], fmt.Sprintf("[%s]%s[-]", codeComment, escapeTags(line[i:]))
+}
+
+// reTagLike matches text tview would read as a color or region tag.
+var reTagLike = regexp.MustCompile(`(\[[a-zA-Z0-9_,;: \-\."#]+\[*)\]`)
+
+// escapeTags keeps plugin text literal in tview (same rule as tview.Escape,
+// without linking tview into plugin binaries).
+func escapeTags(s string) string {
+	return reTagLike.ReplaceAllString(s, "$1[]")
+}
@@ -0,0 +1,59 @@
+package pluginrpc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFieldsSkipsEmptyValues(t *testing.T) {
+	got := Fields("Image", "nginx", "IP", "", "Status", "running", "dangling")
+	if len(got) != 2 || got[0].Key != "Image" || got[1].Key != "Status" {
+		t.Fatalf("Fields = %+v, want Image and Status", got)
+	}
+}
+
+func TestDetailRenderAlignsFieldsAndEscapes(t *testing.T) {
+	d := Detail{
+		Title: "web",
+		Sections: []DetailSection{
+			{Fields: []DetailField{{Key: "ID", Value: "abc"}, {Key: "Status", Value: "[red]up"}}},
+			{Title: "Ports", Table: &DetailTable{Headers: []string{"Host", "Container"}, Rows: [][]string{{"8080", "80/tcp"}}}},
+			{Title: "Nested", Sections: []DetailSection{{Text: "inner"}}},
+		},
+	}
+	out := d.Render()
+	for _, want := range []string{
+		"[" + infoOrange + "]ID    [-] : ",
+		"[red[]up",
+		"Host  Container",
+		"8080  80/tcp",
+		"  [" + infoValue + "]inner",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestDetailRenderEmptyTable(t *testing.T) {
+	out := Detail{Sections: []DetailSection{{Table: &DetailTable{Headers: []string{"A"}}}}}.Render()
+	if !strings.Contains(out, "(none)") {
+		t.Fatalf("empty table not marked:\n%s", out)
+	}
+}
+
+func TestHighlightLine(t *testing.T) {
+	cases := []struct{ lang, line, want string }{
+		{"json", `  "name": "web",`, "[" + codeKey + `]"name":[-] [` + codeString + `]"web"[-],`},
+		{"json", `"n": 42`, "[" + codeNumber + "]42[-]"},
+		{"yaml", "image: nginx # pinned", "[" + codeKey + "]image[-]: nginx [muted]# pinned[-]"},
+		{"sh", `A="x # y"`, `A="x # y"`},
+		{"sql", "SELECT 1 -- one", "SELECT 1 [muted]-- one[-]"},
+		{"", "[blue]", "[blue[]"},
+	}
+	for _, c := range cases {
+		if got := highlightLine(c.lang, c.line); !strings.Contains(got, c.want) {
+			t.Fatalf("highlightLine(%q, %q) = %q, want %q", c.lang, c.line, got, c.want)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package pluginrpc
 
+import "time"
+
 // DashboardView is the shared lightweight summary view requested by the host
 // dashboard. Plugins should keep this view fast, read-only, and side-effect free.
 const DashboardView = "dashboard"
@@ -21,6 +23,10 @@ type KeyBinding struct {
 	Key    string
 	Label  string
 	Action string
+	// Batch marks an action the host may run once per marked row, with a
+	// progress modal of per-row results. Leave it off for actions that
+	// prompt or open a view.
+	Batch bool
 }
 
 // HelpSection is one titled group in the "?" help modal (usually a view).
@@ -49,6 +55,29 @@ type ViewData struct {
 	// LogsBody, when non-empty, asks the host to render the in-place Logs view
 	// (content area) instead of the table. Same header chrome as every other view.
 	LogsBody string
+	// Metrics mark dashboard widget values as numbers the host samples over
+	// time and draws as sparklines. Name matches the widget row key.
+	Metrics []Metric
+	// DetailAction, when set, is the read-only action the host calls with the
+	// highlighted row to fill the detail pane (its ActionResult.Detail, or
+	// ModalBody as plain text). It must be cheap and side-effect free.
+	DetailAction string
+	// LoadMoreAction, when set, pages the table: PgDn calls it with the
+	// number of rows shown as "offset" and appends its Next.Rows (Next.Info
+	// refreshes the info panel). More says whether another page exists, on
+	// the view and on every page.
+	LoadMoreAction string
+	More           bool
+	// RefreshAfter asks the host to fetch the view again after this long
+	// while it stays on screen, for views that follow background work.
+	RefreshAfter time.Duration
+}
+
+// Metric is one numeric sample of a dashboard widget value.
+type Metric struct {
+	Name  string  // widget row key, e.g. "Memory"
+	Value float64 // raw value (bytes, count, percent …)
+	Unit  string  // "B" (bytes), "%", "/s" or empty for plain counts
 }
 
 // ActionRequest invokes a plugin-side action (refresh, delete, …).
@@ -66,15 +95,56 @@ type ExternalSession struct {
 	Banner   string
 }
 
+// IssuedCredential is a credential an action just created (Argo CD token,
+// client certificate, …). The host offers to save it to KeePass next to the
+// active target, recording ExpiresAt as the expires_at attribute so rotation
+// reminders can track it.
+type IssuedCredential struct {
+	Name       string // suggested entry name (last path segment)
+	UserName   string
+	Password   string // token / password; may be empty for certificate logins
+	Attributes map[string]string
+	ExpiresAt  time.Time // zero = does not expire
+}
+
+// Confirm asks the host to confirm before running Action with Payload, for
+// actions whose risk only the plugin can judge (e.g. a write command typed
+// into a console on a protected target).
+type Confirm struct {
+	Title   string
+	Body    string
+	Action  string
+	Payload map[string]string
+}
+
+// Edit asks the host to open Content in the user's editor ($VISUAL, then
+// $EDITOR). If the text changed, the host confirms and runs Action with
+// Payload plus the edited text under "value". Suffix (e.g. ".json") is the
+// temp file extension, so editors pick a syntax mode.
+type Edit struct {
+	Title   string
+	Content string
+	Suffix  string
+	Action  string
+	Payload map[string]string
+}
+
 // ActionResult is returned after DoAction; optional Next replaces cached view.
-// ModalTitle/ModalBody ask the host to show an info modal (key content, doctor, etc.).
+// ModalTitle/ModalBody ask the host to show an info modal (key content, doctor, etc.);
+// Detail is the structured form, preferred over ModalBody when set.
 // Reaction is an optional 1–2 word label for the host logo mood flash (e.g. "yay!", "nope").
+// Confirm, when set, replaces the rest of the result with a confirmation prompt;
+// Edit likewise replaces it with an editor session.
 type ActionResult struct {
 	OK              bool
 	Message         string
 	Next            *ViewData
 	ModalTitle      string
 	ModalBody       string
+	Detail          *Detail
 	Reaction        string
 	ExternalSession *ExternalSession
+	Credential      *IssuedCredential
+	Confirm         *Confirm
+	Edit            *Edit
 }
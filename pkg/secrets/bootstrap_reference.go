@@ -80,7 +80,7 @@ var pluginReferenceDefinitions = []struct {
 		plugin: "s3",
 		notes: "Reference path: s3/<environment>/<instance>\n\n" +
 			"URL — endpoint\nUserName — access key ID\nPassword — secret key\n" +
-			"Custom: region, role_arn, tags",
+			"Custom: profile (AWS shared config; defaults to Title), region, role_arn, tags",
 	},
 	{
 		plugin: "git",
@@ -0,0 +1,403 @@
+package secrets
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"omo/pkg/pluginapi"
+
+	"filippo.io/age"
+	"filippo.io/age/agessh"
+	"filippo.io/age/armor"
+)
+
+// BundleVersion is the format version written into new bundles.
+const BundleVersion = 1
+
+// Bundle is the plaintext payload of an encrypted team export: a set of
+// entries under a common prefix, ready to merge into another vault.
+type Bundle struct {
+	Version           int           `json:"version"`
+	Created           time.Time     `json:"created"`
+	Prefix            string        `json:"prefix,omitempty"`
+	PasswordsStripped bool          `json:"passwords_stripped,omitempty"`
+	Entries           []BundleEntry `json:"entries"`
+}
+
+// BundleEntry is one vault entry inside a Bundle.
+type BundleEntry struct {
+	Path       string            `json:"path"`
+	UserName   string            `json:"username,omitempty"`
+	Password   string            `json:"password,omitempty"`
+	URL        string            `json:"url,omitempty"`
+	Notes      string            `json:"notes,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Entry converts a bundle entry back into a vault Entry.
+func (b BundleEntry) Entry() *Entry {
+	ca := make(map[string]string, len(b.Attributes))
+	for k, v := range b.Attributes {
+		ca[k] = v
+	}
+	return &Entry{
+		UserName:         b.UserName,
+		Password:         b.Password,
+		URL:              b.URL,
+		Notes:            b.Notes,
+		CustomAttributes: ca,
+	}
+}
+
+// CheckImportPath rejects an entry path that is not plugin/env/name or lies
+// outside prefix. Bundles are not signed, so prefix must be chosen by the
+// importing user rather than taken from the bundle's own Prefix, and the
+// paths are checked before anything is written. An empty prefix allows any
+// path.
+func CheckImportPath(path, prefix string) error {
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 {
+		return fmt.Errorf("entry path %q is not plugin/env/name", path)
+	}
+	for _, p := range parts {
+		if p == "" || strings.TrimSpace(p) != p {
+			return fmt.Errorf("entry path %q has an empty or padded segment", path)
+		}
+	}
+	trimmed := strings.Trim(prefix, "/")
+	if trimmed != "" && path != trimmed && !strings.HasPrefix(path, trimmed+"/") {
+		return fmt.Errorf("entry path %q is outside the import prefix %q", path, prefix)
+	}
+	return nil
+}
+
+// CredentialHelper returns the helper command the entry would have the host
+// run, if any.
+func (b BundleEntry) CredentialHelper() string {
+	return b.Attributes[pluginapi.CredentialHelperAttr]
+}
+
+// StripCredentialHelper removes the helper command and its timeout, so an
+// imported entry cannot make the host run a command it was not given by
+// the user.
+func (b *BundleEntry) StripCredentialHelper() {
+	delete(b.Attributes, pluginapi.CredentialHelperAttr)
+	delete(b.Attributes, pluginapi.CredentialHelperTimeoutAttr)
+}
+
+// References lists the entry's ref:// values and extends attribute as
+// "field=value", sorted. They pull other entries of the importing vault into
+// this one when it is resolved.
+func (b BundleEntry) References() []string {
+	var out []string
+	for _, f := range [][2]string{{"username", b.UserName}, {"password", b.Password}, {"url", b.URL}, {"notes", b.Notes}} {
+		if pluginapi.IsSecretRef(f[1]) {
+			out = append(out, f[0]+"="+f[1])
+		}
+	}
+	for k, v := range b.Attributes {
+		if k == pluginapi.ExtendsAttr || pluginapi.IsSecretRef(v) {
+			out = append(out, k+"="+v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// StripReferences removes every ref:// value and the extends attribute, so
+// an imported entry cannot copy local credentials to a host the bundle
+// chose.
+func (b *BundleEntry) StripReferences() {
+	for _, v := range []*string{&b.UserName, &b.Password, &b.URL, &b.Notes} {
+		if pluginapi.IsSecretRef(*v) {
+			*v = ""
+		}
+	}
+	for k, v := range b.Attributes {
+		if k == pluginapi.ExtendsAttr || pluginapi.IsSecretRef(v) {
+			delete(b.Attributes, k)
+		}
+	}
+}
+
+// BuildBundle collects every non-reference entry under prefix. With
+// stripPasswords the Password field and secret-looking attributes
+// (keys, tokens, passphrases) are left out.
+func BuildBundle(p Provider, prefix string, stripPasswords bool) (*Bundle, error) {
+	paths, err := p.List(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("list %q: %w", prefix, err)
+	}
+	sort.Strings(paths)
+
+	b := &Bundle{
+		Version:           BundleVersion,
+		Created:           time.Now().UTC(),
+		Prefix:            prefix,
+		PasswordsStripped: stripPasswords,
+	}
+	for _, path := range paths {
+		e, err := p.Get(path)
+		if err != nil {
+			return nil, fmt.Errorf("get %s: %w", path, err)
+		}
+		if e.CustomAttributes[pluginapi.ReferenceEntryAttr] == pluginapi.ReferenceEntryValue {
+			continue
+		}
+		be := BundleEntry{
+			Path:       path,
+			UserName:   e.UserName,
+			Password:   e.Password,
+			URL:        e.URL,
+			Notes:      e.Notes,
+			Attributes: map[string]string{},
+		}
+		for k, v := range e.CustomAttributes {
+			if stripPasswords && IsSensitiveAttribute(k) {
+				continue
+			}
+			be.Attributes[k] = v
+		}
+		if stripPasswords {
+			be.Password = ""
+		}
+		b.Entries = append(b.Entries, be)
+	}
+	return b, nil
+}
+
+// IsSensitiveAttribute reports whether a custom attribute name looks like it
+// holds key material or a credential rather than connection metadata.
+// Inline kubeconfigs carry client keys and tokens, and helper command lines
+// often carry tokens as arguments.
+func IsSensitiveAttribute(name string) bool {
+	n := strings.ToLower(name)
+	switch n {
+	case "kubeconfig_data", pluginapi.CredentialHelperAttr:
+		return true
+	}
+	if strings.HasSuffix(n, "_path") {
+		return false
+	}
+	for _, s := range []string{"password", "passphrase", "secret", "token", "private_key"} {
+		if strings.Contains(n, s) {
+			return true
+		}
+	}
+	return strings.HasSuffix(n, "_key")
+}
+
+// ── encryption ───────────────────────────────────────────────────────────────
+
+// EncryptBundle writes b as an ASCII-armored age file readable by any of the
+// recipients (age1… X25519 keys or ssh-ed25519/ssh-rsa public keys).
+func EncryptBundle(w io.Writer, b *Bundle, recipients []string) error {
+	if len(recipients) == 0 {
+		return errors.New("at least one recipient is required")
+	}
+	rs := make([]age.Recipient, 0, len(recipients))
+	for _, s := range recipients {
+		r, err := parseRecipient(s)
+		if err != nil {
+			return err
+		}
+		rs = append(rs, r)
+	}
+
+	payload, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode bundle: %w", err)
+	}
+	aw := armor.NewWriter(w)
+	enc, err := age.Encrypt(aw, rs...)
+	if err != nil {
+		return fmt.Errorf("encrypt bundle: %w", err)
+	}
+	if _, err := enc.Write(payload); err != nil {
+		return fmt.Errorf("encrypt bundle: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("encrypt bundle: %w", err)
+	}
+	return aw.Close()
+}
+
+// DecryptBundle reads an armored or binary age file and decodes the bundle.
+func DecryptBundle(r io.Reader, identities []age.Identity) (*Bundle, error) {
+	br := bufio.NewReader(r)
+	var src io.Reader = br
+	if head, _ := br.Peek(len(armor.Header)); string(head) == armor.Header {
+		src = armor.NewReader(br)
+	}
+	dec, err := age.Decrypt(src, identities...)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt bundle: %w", err)
+	}
+	var b Bundle
+	if err := json.NewDecoder(dec).Decode(&b); err != nil {
+		return nil, fmt.Errorf("decode bundle: %w", err)
+	}
+	if b.Version > BundleVersion {
+		return nil, fmt.Errorf("bundle version %d is newer than this omo supports (%d)", b.Version, BundleVersion)
+	}
+	return &b, nil
+}
+
+func parseRecipient(s string) (age.Recipient, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "ssh-") {
+		r, err := agessh.ParseRecipient(s)
+		if err != nil {
+			return nil, fmt.Errorf("recipient %q: %w", s, err)
+		}
+		return r, nil
+	}
+	r, err := age.ParseX25519Recipient(s)
+	if err != nil {
+		return nil, fmt.Errorf("recipient %q: %w", s, err)
+	}
+	return r, nil
+}
+
+// ── identity ─────────────────────────────────────────────────────────────────
+
+// DefaultAgeIdentityPath returns ~/.omo/keys/age.txt, the identity used to
+// open bundles shared with this machine.
+func DefaultAgeIdentityPath() string {
+	return filepath.Join(pluginapi.OmoDir(), "keys", "age.txt")
+}
+
+// EnsureAgeIdentity loads the X25519 identity at path, generating it on
+// first use, and returns it with its public recipient string.
+func EnsureAgeIdentity(path string) (*age.X25519Identity, error) {
+	if data, err := os.ReadFile(path); err == nil {
+		ids, err := age.ParseIdentities(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+		for _, id := range ids {
+			if x, ok := id.(*age.X25519Identity); ok {
+				return x, nil
+			}
+		}
+		return nil, fmt.Errorf("%s holds no X25519 identity", path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		return nil, fmt.Errorf("generate age identity: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	body := fmt.Sprintf("# created: %s\n# public key: %s\n%s\n",
+		time.Now().Format(time.RFC3339), id.Recipient(), id)
+	if err := os.WriteFile(path, []byte(body), 0600); err != nil {
+		return nil, fmt.Errorf("write %s: %w", path, err)
+	}
+	return id, nil
+}
+
+// LoadAgeIdentities parses identity files (age keys or unencrypted ssh
+// private keys) for DecryptBundle.
+func LoadAgeIdentities(paths []string) ([]age.Identity, error) {
+	var out []age.Identity
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Contains(data, []byte("PRIVATE KEY-----")) {
+			id, err := agessh.ParseIdentity(data)
+			if err != nil {
+				return nil, fmt.Errorf("parse %s: %w", path, err)
+			}
+			out = append(out, id)
+			continue
+		}
+		ids, err := age.ParseIdentities(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+		out = append(out, ids...)
+	}
+	return out, nil
+}
+
+// ── merge ────────────────────────────────────────────────────────────────────
+
+// MergePolicy decides what happens when a bundle entry's path already exists.
+type MergePolicy string
+
+const (
+	MergeSkip      MergePolicy = "skip"      // keep the local entry untouched
+	MergeOverwrite MergePolicy = "overwrite" // bundle values win where set
+	MergeFill      MergePolicy = "merge"     // local values win; bundle fills gaps
+)
+
+// EntriesEqual reports whether two entries hold the same fields.
+func EntriesEqual(a, b *Entry) bool {
+	if a.UserName != b.UserName || a.Password != b.Password || a.URL != b.URL || a.Notes != b.Notes {
+		return false
+	}
+	if len(a.CustomAttributes) != len(b.CustomAttributes) {
+		return false
+	}
+	for k, v := range a.CustomAttributes {
+		if bv, ok := b.CustomAttributes[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// MergeEntry combines local and incoming according to policy. Empty incoming
+// fields never erase local values, so a bundle exported with stripped
+// passwords keeps the passwords already in the vault.
+func MergeEntry(local, incoming *Entry, policy MergePolicy) *Entry {
+	out := &Entry{
+		Title:            local.Title,
+		UserName:         local.UserName,
+		Password:         local.Password,
+		URL:              local.URL,
+		Notes:            local.Notes,
+		CustomAttributes: map[string]string{},
+	}
+	for k, v := range local.CustomAttributes {
+		out.CustomAttributes[k] = v
+	}
+	if policy == MergeSkip {
+		return out
+	}
+	pick := func(dst *string, v string) {
+		if v == "" {
+			return
+		}
+		if policy == MergeOverwrite || *dst == "" {
+			*dst = v
+		}
+	}
+	pick(&out.UserName, incoming.UserName)
+	pick(&out.Password, incoming.Password)
+	pick(&out.URL, incoming.URL)
+	pick(&out.Notes, incoming.Notes)
+	for k, v := range incoming.CustomAttributes {
+		cur := out.CustomAttributes[k]
+		pick(&cur, v)
+		if cur != "" {
+			out.CustomAttributes[k] = cur
+		}
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := &Bundle{Version: BundleVersion, Prefix: "redis/staging", Entries: []BundleEntry{
+		{Path: "redis/staging/cache", URL: "cache.local", Attributes: map[string]string{"port": "6380"}},
+	}}
+	var buf bytes.Buffer
+	if err := EncryptBundle(&buf, in, []string{id.Recipient().String()}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := DecryptBundle(&buf, []age.Identity{id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Entries) != 1 || out.Entries[0].Attributes["port"] != "6380" {
+		t.Fatalf("entries = %+v", out.Entries)
+	}
+}
+
+func TestIsSensitiveAttribute(t *testing.T) {
+	for name, want := range map[string]bool{
+		"private_key":               true,
+		"jump_key":                  true,
+		"auth_token":                true,
+		"passphrase":                true,
+		"key_path":                  false,
+		"jump_key_path":             false,
+		"port":                      false,
+		"ssl_ca_cert":               false,
+		"kubeconfig_data":           true,
+		"credential_helper":         true,
+		"credential_helper_timeout": false,
+	} {
+		if got := IsSensitiveAttribute(name); got != want {
+			t.Fatalf("IsSensitiveAttribute(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestCheckImportPath(t *testing.T) {
+	for path, ok := range map[string]bool{
+		"redis/staging/cache":  true,
+		"redis/staging":        false,
+		"redis/stagingx/cache": false,
+		"ssh/staging/bastion":  false,
+		"redis/staging/a/b":    false,
+		"redis//cache":         false,
+		"redis/staging/ cache": false,
+	} {
+		if err := CheckImportPath(path, "redis/staging"); (err == nil) != ok {
+			t.Fatalf("CheckImportPath(%q) = %v, want ok=%v", path, err, ok)
+		}
+	}
+	if err := CheckImportPath("postgres/prod/db", "redis/"); err == nil {
+		t.Fatalf("CheckImportPath accepted a path outside a plugin-wide prefix")
+	}
+	be := BundleEntry{Attributes: map[string]string{"credential_helper": "curl x", "credential_helper_timeout": "5s", "port": "1"}}
+	if be.CredentialHelper() != "curl x" {
+		t.Fatalf("CredentialHelper = %q", be.CredentialHelper())
+	}
+	be.StripCredentialHelper()
+	if len(be.Attributes) != 1 || be.CredentialHelper() != "" {
+		t.Fatalf("after strip attributes = %v", be.Attributes)
+	}
+}
+
+func TestBundleEntryReferences(t *testing.T) {
+	be := BundleEntry{
+		URL:        "evil.example.com",
+		Password:   "ref://postgres/prod/db#password",
+		Attributes: map[string]string{"extends": "redis/prod/cache", "tls_cert": "ref://redis/prod/cache", "port": "6379"},
+	}
+	want := []string{"extends=redis/prod/cache", "password=ref://postgres/prod/db#password", "tls_cert=ref://redis/prod/cache"}
+	if got := be.References(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("References = %q, want %q", got, want)
+	}
+	be.StripReferences()
+	if be.Password != "" || be.URL != "evil.example.com" || len(be.Attributes) != 1 || be.References() != nil {
+		t.Fatalf("after strip = %+v", be)
+	}
+}
+
+func TestMergeEntry(t *testing.T) {
+	local := &Entry{URL: "old", Password: "pw", CustomAttributes: map[string]string{"port": "1"}}
+	incoming := &Entry{URL: "new", CustomAttributes: map[string]string{"port": "2", "database": "3"}}
+
+	got := MergeEntry(local, incoming, MergeOverwrite)
+	if got.URL != "new" || got.Password != "pw" || got.CustomAttributes["port"] != "2" {
+		t.Fatalf("overwrite = %+v", got)
+	}
+	got = MergeEntry(local, incoming, MergeFill)
+	if got.URL != "old" || got.CustomAttributes["port"] != "1" || got.CustomAttributes["database"] != "3" {
+		t.Fatalf("merge = %+v", got)
+	}
+	if got = MergeEntry(local, incoming, MergeSkip); !EntriesEqual(got, local) {
+		t.Fatalf("skip = %+v", got)
+	}
+}
@@ -0,0 +1,118 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func awsCredentialsPath() string {
+	if p := os.Getenv("AWS_SHARED_CREDENTIALS_FILE"); p != "" {
+		return p
+	}
+	return homePath(".aws", "credentials")
+}
+
+// awsConfigPathFor finds the config file that pairs with a credentials file.
+func awsConfigPathFor(credentials string) string {
+	if p := os.Getenv("AWS_CONFIG_FILE"); p != "" {
+		return p
+	}
+	return filepath.Join(filepath.Dir(credentials), "config")
+}
+
+// parseAWSFiles creates one s3 entry per profile found in the credentials or
+// config file. Keys stay in ~/.aws; the entry only records profile and region
+// so the SDK resolves credentials the usual way.
+func parseAWSFiles(path string, opts Options) ([]Candidate, error) {
+	creds, credErr := readINIFile(path)
+	cfgPath := awsConfigPathFor(path)
+	cfg, cfgErr := readINIFile(cfgPath)
+	if credErr != nil && cfgErr != nil {
+		return nil, fmt.Errorf("read aws credentials: %w", credErr)
+	}
+
+	profiles := map[string]map[string]string{}
+	for name, kv := range creds {
+		profiles[name] = kv
+	}
+	for section, kv := range cfg {
+		if strings.HasPrefix(section, "sso-session ") || strings.HasPrefix(section, "services ") {
+			continue
+		}
+		name := strings.TrimSpace(strings.TrimPrefix(section, "profile "))
+		if profiles[name] == nil {
+			profiles[name] = map[string]string{}
+		}
+		for k, v := range kv {
+			if _, ok := profiles[name][k]; !ok {
+				profiles[name][k] = v
+			}
+		}
+	}
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var out []Candidate
+	for _, name := range names {
+		kv := profiles[name]
+		e := newEntry()
+		e.Notes = "Imported from " + path
+		setAttr(e, "profile", name)
+		setAttr(e, "region", kv["region"])
+		setAttr(e, "role_arn", kv["role_arn"])
+		if ep := kv["endpoint_url"]; ep != "" {
+			e.URL = ep
+		}
+		out = append(out, newCandidate("s3", opts, name, path, e))
+	}
+	return out, nil
+}
+
+func readINIFile(path string) (map[string]map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseINI(f)
+}
+
+// parseINI is a minimal reader for the AWS shared config format: [section]
+// headers and key = value pairs; comments start with # or ;.
+func parseINI(r io.Reader) (map[string]map[string]string, error) {
+	out := map[string]map[string]string{}
+	section := ""
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if out[section] == nil {
+				out[section] = map[string]string{}
+			}
+			continue
+		}
+		if section == "" {
+			continue
+		}
+		idx := strings.Index(line, "=")
+		if idx < 0 {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(line[:idx]))
+		out[section][key] = strings.TrimSpace(line[idx+1:])
+	}
+	return out, sc.Err()
+}
@@ -0,0 +1,66 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func dockerContextsPath() string {
+	if cfg := os.Getenv("DOCKER_CONFIG"); cfg != "" {
+		return filepath.Join(cfg, "contexts")
+	}
+	return homePath(".docker", "contexts")
+}
+
+type dockerContextMeta struct {
+	Name      string `json:"Name"`
+	Endpoints map[string]struct {
+		Host          string `json:"Host"`
+		SkipTLSVerify bool   `json:"SkipTLSVerify"`
+	} `json:"Endpoints"`
+}
+
+// parseDockerContexts reads <dir>/meta/<id>/meta.json for every context the
+// docker CLI knows about. TLS material, when present, lives in
+// <dir>/tls/<id>/docker and is referenced via cert_path.
+func parseDockerContexts(dir string, opts Options) ([]Candidate, error) {
+	metaDir := filepath.Join(dir, "meta")
+	ids, err := os.ReadDir(metaDir)
+	if err != nil {
+		return nil, fmt.Errorf("read docker contexts: %w", err)
+	}
+	var out []Candidate
+	for _, id := range ids {
+		if !id.IsDir() {
+			continue
+		}
+		metaPath := filepath.Join(metaDir, id.Name(), "meta.json")
+		data, err := os.ReadFile(metaPath)
+		if err != nil {
+			continue
+		}
+		var meta dockerContextMeta
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", metaPath, err)
+		}
+		ep, ok := meta.Endpoints["docker"]
+		if !ok || ep.Host == "" || meta.Name == "" {
+			continue
+		}
+		e := newEntry()
+		e.URL = ep.Host
+		e.Notes = "Imported from " + metaPath
+		tlsDir := filepath.Join(dir, "tls", id.Name(), "docker")
+		if info, err := os.Stat(tlsDir); err == nil && info.IsDir() {
+			setAttr(e, "cert_path", tlsDir)
+			setAttr(e, "tls", "true")
+			if !ep.SkipTLSVerify {
+				setAttr(e, "tls_verify", "true")
+			}
+		}
+		out = append(out, newCandidate("docker", opts, meta.Name, metaPath, e))
+	}
+	return out, nil
+}
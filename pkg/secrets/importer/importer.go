@@ -0,0 +1,289 @@
+// Package importer turns existing tool configs (~/.ssh/config, kubeconfig
+// contexts, AWS profiles, ~/.pgpass, Docker contexts, .env files) into
+// plugin/env/name KeePass entries.
+//
+// Importers only read their source files. Plan compares the candidates with
+// what is already in the vault so callers can show a dry-run preview, and
+// Apply writes the new entries.
+package importer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"omo/pkg/pluginapi"
+)
+
+// DefaultEnv is the environment segment used when Options.Env is empty.
+const DefaultEnv = "imported"
+
+// Options tune how candidates are named.
+type Options struct {
+	// Env is the environment segment of every generated path.
+	Env string
+	// Plugin overrides the target plugin (e.g. k8suser instead of
+	// k8sportforward for kube contexts). Empty keeps the source default.
+	Plugin string
+}
+
+// Candidate is one entry an importer would create.
+type Candidate struct {
+	Path   string
+	Entry  *pluginapi.SecretEntry
+	Origin string // file the candidate was read from
+}
+
+// Source describes one importer.
+type Source struct {
+	Name        string
+	Plugin      string // default target plugin
+	Description string
+	DefaultPath func() string
+	Parse       func(path string, opts Options) ([]Candidate, error)
+}
+
+// Sources lists the supported importers in display order.
+var Sources = []Source{
+	{Name: "ssh", Plugin: "ssh", Description: "Host blocks from ~/.ssh/config", DefaultPath: sshConfigPath, Parse: parseSSHConfigFile},
+	{Name: "kube", Plugin: "k8sportforward", Description: "Contexts from ~/.kube/config (or $KUBECONFIG)", DefaultPath: kubeConfigPath, Parse: parseKubeConfigFile},
+	{Name: "aws", Plugin: "s3", Description: "Profiles from ~/.aws/credentials and ~/.aws/config", DefaultPath: awsCredentialsPath, Parse: parseAWSFiles},
+	{Name: "pgpass", Plugin: "postgres", Description: "Lines from ~/.pgpass", DefaultPath: pgpassPath, Parse: parsePgpassFile},
+	{Name: "docker", Plugin: "docker", Description: "Contexts from ~/.docker/contexts", DefaultPath: dockerContextsPath, Parse: parseDockerContexts},
+	{Name: "env", Plugin: "", Description: "Connection URLs from a .env file", DefaultPath: func() string { return ".env" }, Parse: parseDotEnvFile},
+}
+
+// Lookup returns the source registered under name.
+func Lookup(name string) (Source, bool) {
+	for _, s := range Sources {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Source{}, false
+}
+
+// SourceNames returns the importer names in display order.
+func SourceNames() []string {
+	names := make([]string, 0, len(Sources))
+	for _, s := range Sources {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+// Load runs the named importer against path (empty = the source default).
+func Load(name, path string, opts Options) ([]Candidate, error) {
+	src, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown source %q (want one of: %s)", name, strings.Join(SourceNames(), ", "))
+	}
+	if path == "" {
+		path = src.DefaultPath()
+	}
+	if opts.Env == "" {
+		opts.Env = DefaultEnv
+	}
+	cands, err := src.Parse(expandHome(path), opts)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(cands, func(i, j int) bool { return cands[i].Path < cands[j].Path })
+	return cands, nil
+}
+
+// ── plan / apply ─────────────────────────────────────────────────────────────
+
+// Status is the outcome Plan predicts for a candidate.
+type Status string
+
+const (
+	StatusNew       Status = "new"
+	StatusExists    Status = "exists"    // same path already in the vault
+	StatusDuplicate Status = "duplicate" // same endpoint under another path
+)
+
+// PlanItem pairs a candidate with its predicted outcome.
+type PlanItem struct {
+	Candidate
+	Status   Status
+	Existing string // path of the matching entry for exists/duplicate
+}
+
+// Plan de-duplicates candidates against the vault and against each other.
+// Reference templates are ignored when matching endpoints.
+func Plan(p pluginapi.SecretsProvider, cands []Candidate) ([]PlanItem, error) {
+	paths, err := p.List("")
+	if err != nil {
+		return nil, fmt.Errorf("list entries: %w", err)
+	}
+	existing := make(map[string]bool, len(paths))
+	byKey := make(map[string]string)
+	for _, path := range paths {
+		existing[path] = true
+		e, err := p.Get(path)
+		if err != nil || e == nil || pluginapi.IsReferenceEntry(e) {
+			continue
+		}
+		if k := endpointKey(path, e); k != "" {
+			if _, ok := byKey[k]; !ok {
+				byKey[k] = path
+			}
+		}
+	}
+
+	plan := make([]PlanItem, 0, len(cands))
+	for _, c := range cands {
+		item := PlanItem{Candidate: c, Status: StatusNew}
+		k := endpointKey(c.Path, c.Entry)
+		switch {
+		case existing[c.Path]:
+			item.Status, item.Existing = StatusExists, c.Path
+		case k != "" && byKey[k] != "":
+			item.Status, item.Existing = StatusDuplicate, byKey[k]
+		default:
+			existing[c.Path] = true
+			if k != "" {
+				byKey[k] = c.Path
+			}
+		}
+		plan = append(plan, item)
+	}
+	return plan, nil
+}
+
+// Apply writes every StatusNew item and returns how many were saved.
+func Apply(p pluginapi.SecretsProvider, plan []PlanItem) (int, error) {
+	n := 0
+	for _, item := range plan {
+		if item.Status != StatusNew {
+			continue
+		}
+		if err := p.Put(item.Path, item.Entry); err != nil {
+			return n, fmt.Errorf("put %s: %w", item.Path, err)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// Summary returns a one-line count of the plan outcomes.
+func Summary(plan []PlanItem) string {
+	var newN, existsN, dupN int
+	for _, item := range plan {
+		switch item.Status {
+		case StatusNew:
+			newN++
+		case StatusExists:
+			existsN++
+		case StatusDuplicate:
+			dupN++
+		}
+	}
+	return fmt.Sprintf("%d new · %d already present · %d duplicate", newN, existsN, dupN)
+}
+
+// Describe returns a short human-readable summary of what an entry holds,
+// without secrets.
+func Describe(e *pluginapi.SecretEntry) string {
+	var parts []string
+	if e.URL != "" {
+		parts = append(parts, e.URL)
+	}
+	if e.UserName != "" {
+		parts = append(parts, "user="+e.UserName)
+	}
+	if e.Password != "" {
+		parts = append(parts, "password=***")
+	}
+	keys := make([]string, 0, len(e.CustomAttributes))
+	for k := range e.CustomAttributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, k+"="+e.CustomAttributes[k])
+	}
+	return strings.Join(parts, " ")
+}
+
+// endpointKey identifies "the same target" across differently named entries.
+// It returns "" when the entry has nothing endpoint-like to compare.
+func endpointKey(path string, e *pluginapi.SecretEntry) string {
+	plugin := path
+	if i := strings.Index(path, "/"); i >= 0 {
+		plugin = path[:i]
+	}
+	ca := e.CustomAttributes
+	fields := []string{
+		strings.ToLower(strings.TrimSpace(e.URL)),
+		e.UserName,
+		ca["port"],
+		ca["database"],
+		ca["context"],
+		ca["profile"],
+	}
+	if strings.Join(fields, "") == "" {
+		return ""
+	}
+	return plugin + "|" + strings.Join(fields, "|")
+}
+
+// ── helpers ──────────────────────────────────────────────────────────────────
+
+func newCandidate(plugin string, opts Options, name, origin string, e *pluginapi.SecretEntry) Candidate {
+	if opts.Plugin != "" {
+		plugin = opts.Plugin
+	}
+	if e.CustomAttributes == nil {
+		e.CustomAttributes = map[string]string{}
+	}
+	name = SanitizeName(name)
+	return Candidate{
+		Path:   plugin + "/" + SanitizeName(opts.Env) + "/" + name,
+		Entry:  e,
+		Origin: origin,
+	}
+}
+
+// SanitizeName makes s usable as a single path segment.
+func SanitizeName(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', ' ', '\t':
+			return '-'
+		}
+		return r
+	}, s)
+	s = strings.Trim(s, "-")
+	if s == "" {
+		return "default"
+	}
+	return s
+}
+
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	return path
+}
+
+func homePath(parts ...string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(append([]string{home}, parts...)...)
+}
+
+func setAttr(e *pluginapi.SecretEntry, key, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		e.CustomAttributes[key] = value
+	}
+}
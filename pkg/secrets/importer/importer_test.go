@@ -0,0 +1,152 @@
+package importer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"omo/pkg/pluginapi"
+)
+
+func TestParseSSHConfig(t *testing.T) {
+	cfg := `
+Host bastion
+  HostName 10.0.0.1
+  User ops
+
+Host web-01 web-01.alias
+  HostName=10.0.1.50
+  User deploy
+  Port 2222
+  IdentityFile ~/.ssh/id_ed25519
+  ProxyJump ops@bastion,other
+
+Host *
+  User nobody
+`
+	hosts, err := parseSSHConfig(strings.NewReader(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 3 {
+		t.Fatalf("len(hosts) = %d, want 3", len(hosts))
+	}
+	web := hosts[1]
+	if web.alias != "web-01" || web.hostName != "10.0.1.50" || web.port != "2222" {
+		t.Fatalf("web-01 = %+v", web)
+	}
+	if web.proxyJump != "ops@bastion,other" {
+		t.Fatalf("proxyJump = %q", web.proxyJump)
+	}
+	if hosts[2].alias != "web-01.alias" || hosts[2].user != "deploy" {
+		t.Fatalf("second alias = %+v", hosts[2])
+	}
+}
+
+func TestSplitPgpass(t *testing.T) {
+	got := splitPgpass(`db.example.com:5432:app:admin:pa\:ss\\word`)
+	want := []string{"db.example.com", "5432", "app", "admin", `pa:ss\word`}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("splitPgpass = %q, want %q", got, want)
+	}
+}
+
+func TestPgpassCandidatesKeepEveryUser(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pgpass")
+	lines := "db.local:5432:app:admin:one\ndb.local:5432:app:report:two\ndb.local:5433:app:report:three\n"
+	if err := os.WriteFile(path, []byte(lines), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cands, err := parsePgpassFile(path, Options{Env: "dev"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range cands {
+		got = append(got, c.Path)
+	}
+	want := []string{"postgres/dev/db.local-app-admin", "postgres/dev/db.local-app-report", "postgres/dev/db.local-app-report-2"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("paths = %q, want %q", got, want)
+	}
+	plan, err := Plan(memVault{}, cands)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range plan {
+		if item.Status != StatusNew {
+			t.Fatalf("%s = %s, want new", item.Path, item.Status)
+		}
+	}
+}
+
+func TestDotEnvCandidates(t *testing.T) {
+	vars, err := parseDotEnv(strings.NewReader(`
+export REDIS_URL="redis://:secret@cache.local:6380/2"
+DATABASE_URL=postgres://app:pw@db.local:5433/appdb?sslmode=require # primary
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cands := dotEnvCandidates(vars, "shop", ".env", Options{Env: "dev"})
+	if len(cands) != 2 {
+		t.Fatalf("len(cands) = %d, want 2", len(cands))
+	}
+	pg, redis := cands[0], cands[1]
+	if pg.Path != "postgres/dev/shop" || pg.Entry.CustomAttributes["database"] != "appdb" ||
+		pg.Entry.CustomAttributes["sslmode"] != "require" || pg.Entry.CustomAttributes["port"] != "5433" {
+		t.Fatalf("postgres candidate = %s %+v", pg.Path, pg.Entry)
+	}
+	if redis.Path != "redis/dev/shop" || redis.Entry.Password != "secret" || redis.Entry.CustomAttributes["database"] != "2" {
+		t.Fatalf("redis candidate = %s %+v", redis.Path, redis.Entry)
+	}
+}
+
+type memVault map[string]*pluginapi.SecretEntry
+
+func (v memVault) Get(path string) (*pluginapi.SecretEntry, error) {
+	if e, ok := v[path]; ok {
+		return e, nil
+	}
+	return nil, fmt.Errorf("not found")
+}
+func (v memVault) Put(path string, e *pluginapi.SecretEntry) error { v[path] = e; return nil }
+func (v memVault) Delete(path string) error                        { delete(v, path); return nil }
+func (v memVault) Reload() error                                   { return nil }
+func (v memVault) Close() error                                    { return nil }
+func (v memVault) List(prefix string) ([]string, error) {
+	var out []string
+	for k := range v {
+		if strings.HasPrefix(k, prefix) {
+			out = append(out, k)
+		}
+	}
+	return out, nil
+}
+
+func TestPlanDeduplicates(t *testing.T) {
+	vault := memVault{
+		"ssh/production/web": {URL: "10.0.1.50", UserName: "deploy", CustomAttributes: map[string]string{"port": "2222"}},
+		"ssh/imported/db":    {URL: "10.0.2.10"},
+	}
+	cands := []Candidate{
+		{Path: "ssh/imported/web-01", Entry: &pluginapi.SecretEntry{URL: "10.0.1.50", UserName: "deploy", CustomAttributes: map[string]string{"port": "2222"}}},
+		{Path: "ssh/imported/db", Entry: &pluginapi.SecretEntry{URL: "10.0.2.10"}},
+		{Path: "ssh/imported/new", Entry: &pluginapi.SecretEntry{URL: "10.0.3.1"}},
+	}
+	plan, err := Plan(vault, cands)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Status{StatusDuplicate, StatusExists, StatusNew}
+	for i, item := range plan {
+		if item.Status != want[i] {
+			t.Fatalf("plan[%d] = %s, want %s", i, item.Status, want[i])
+		}
+	}
+	if n, _ := Apply(vault, plan); n != 1 || vault["ssh/imported/new"] == nil {
+		t.Fatalf("Apply wrote %d entries", n)
+	}
+}
@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// kubeConfigPath honours $KUBECONFIG (first element) before ~/.kube/config.
+func kubeConfigPath() string {
+	if env := os.Getenv("KUBECONFIG"); env != "" {
+		return filepath.SplitList(env)[0]
+	}
+	return homePath(".kube", "config")
+}
+
+type kubeConfig struct {
+	Clusters []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server string `yaml:"server"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster   string `yaml:"cluster"`
+			User      string `yaml:"user"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+}
+
+// parseKubeConfigFile creates one entry per context. The entry points at the
+// kubeconfig file rather than copying credentials out of it.
+func parseKubeConfigFile(path string, opts Options) ([]Candidate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read kubeconfig: %w", err)
+	}
+	return parseKubeConfig(data, path, opts)
+}
+
+func parseKubeConfig(data []byte, path string, opts Options) ([]Candidate, error) {
+	var cfg kubeConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	servers := make(map[string]string, len(cfg.Clusters))
+	for _, c := range cfg.Clusters {
+		servers[c.Name] = c.Cluster.Server
+	}
+	abs := path
+	if a, err := filepath.Abs(path); err == nil {
+		abs = a
+	}
+	var out []Candidate
+	for _, ctx := range cfg.Contexts {
+		if ctx.Name == "" {
+			continue
+		}
+		e := newEntry()
+		e.URL = servers[ctx.Context.Cluster]
+		e.Notes = "Imported from " + path
+		setAttr(e, "kubeconfig", abs)
+		setAttr(e, "context", ctx.Name)
+		setAttr(e, "namespace", ctx.Context.Namespace)
+		out = append(out, newCandidate("k8sportforward", opts, ctx.Name, path, e))
+	}
+	return out, nil
+}
@@ -0,0 +1,117 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+func pgpassPath() string {
+	if p := os.Getenv("PGPASSFILE"); p != "" {
+		return p
+	}
+	return homePath(".pgpass")
+}
+
+type pgpassLine struct {
+	host, port, database, user, password string
+}
+
+func parsePgpassFile(path string, opts Options) ([]Candidate, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open pgpass: %w", err)
+	}
+	defer f.Close()
+
+	lines, err := parsePgpass(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	var out []Candidate
+	seen := map[string]int{}
+	for _, l := range lines {
+		e := newEntry()
+		e.URL = wildcardless(l.host)
+		e.UserName = wildcardless(l.user)
+		e.Password = l.password
+		e.Notes = "Imported from " + path
+		setAttr(e, "port", wildcardless(l.port))
+		setAttr(e, "database", wildcardless(l.database))
+
+		name := e.URL
+		if name == "" {
+			name = "localhost"
+		}
+		if db := e.CustomAttributes["database"]; db != "" {
+			name += "-" + db
+		}
+		if e.UserName != "" {
+			name += "-" + e.UserName
+		}
+		// Lines that differ only in port (or a wildcard) would share a
+		// path, and Plan would skip all but the first as already present.
+		if seen[name]++; seen[name] > 1 {
+			name += fmt.Sprintf("-%d", seen[name])
+		}
+		out = append(out, newCandidate("postgres", opts, name, path, e))
+	}
+	return out, nil
+}
+
+// parsePgpass reads hostname:port:database:username:password lines. A
+// backslash escapes ':' and '\' inside a field.
+func parsePgpass(r io.Reader) ([]pgpassLine, error) {
+	var out []pgpassLine
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		fields := splitPgpass(line)
+		if len(fields) != 5 {
+			continue
+		}
+		out = append(out, pgpassLine{
+			host:     fields[0],
+			port:     fields[1],
+			database: fields[2],
+			user:     fields[3],
+			password: fields[4],
+		})
+	}
+	return out, sc.Err()
+}
+
+func splitPgpass(line string) []string {
+	var (
+		fields []string
+		cur    strings.Builder
+	)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line):
+			i++
+			cur.WriteByte(line[i])
+		case c == ':':
+			fields = append(fields, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	return append(fields, cur.String())
+}
+
+// wildcardless drops the pgpass "*" wildcard, which has no meaning for a
+// single connection entry.
+func wildcardless(s string) string {
+	if s == "*" {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,144 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"omo/pkg/pluginapi"
+)
+
+func sshConfigPath() string { return homePath(".ssh", "config") }
+
+// sshHost is one concrete alias from a Host block.
+type sshHost struct {
+	alias        string
+	hostName     string
+	user         string
+	port         string
+	identityFile string
+	proxyJump    string
+	proxyCommand string
+}
+
+func parseSSHConfigFile(path string, opts Options) ([]Candidate, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open ssh config: %w", err)
+	}
+	defer f.Close()
+
+	hosts, err := parseSSHConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	var out []Candidate
+	for _, h := range hosts {
+		e := newEntry()
+		e.URL = h.hostName
+		if e.URL == "" {
+			e.URL = h.alias
+		}
+		e.UserName = h.user
+		e.Notes = "Imported from " + path
+		setAttr(e, "port", h.port)
+		if h.identityFile != "" {
+			setAttr(e, "key_path", expandHome(h.identityFile))
+			setAttr(e, "auth_method", "key")
+		}
+		if h.proxyJump != "" && !strings.EqualFold(h.proxyJump, "none") {
+			// The ssh plugin dials a single jump host; keep the first hop.
+			setAttr(e, "jump_host", strings.Split(h.proxyJump, ",")[0])
+		}
+		if h.proxyCommand != "" && !strings.EqualFold(h.proxyCommand, "none") {
+			setAttr(e, "proxy_command", h.proxyCommand)
+		}
+		out = append(out, newCandidate("ssh", opts, h.alias, path, e))
+	}
+	return out, nil
+}
+
+// parseSSHConfig reads Host blocks from an OpenSSH client config. Wildcard
+// and negated patterns are skipped, as are Match blocks; for every concrete
+// alias the first value of each keyword wins, as in ssh(1).
+func parseSSHConfig(r io.Reader) ([]sshHost, error) {
+	var (
+		hosts   []*sshHost
+		current []*sshHost
+	)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value := splitSSHLine(line)
+		if key == "" {
+			continue
+		}
+		switch key {
+		case "host":
+			current = nil
+			for _, alias := range strings.Fields(value) {
+				if strings.ContainsAny(alias, "*?!") {
+					continue
+				}
+				h := &sshHost{alias: alias}
+				hosts = append(hosts, h)
+				current = append(current, h)
+			}
+			continue
+		case "match":
+			current = nil
+			continue
+		}
+		for _, h := range current {
+			switch key {
+			case "hostname":
+				setOnce(&h.hostName, value)
+			case "user":
+				setOnce(&h.user, value)
+			case "port":
+				setOnce(&h.port, value)
+			case "identityfile":
+				setOnce(&h.identityFile, strings.Trim(value, `"`))
+			case "proxyjump":
+				setOnce(&h.proxyJump, value)
+			case "proxycommand":
+				setOnce(&h.proxyCommand, value)
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	out := make([]sshHost, 0, len(hosts))
+	for _, h := range hosts {
+		out = append(out, *h)
+	}
+	return out, nil
+}
+
+// splitSSHLine accepts both "Key value" and "Key=value" forms.
+func splitSSHLine(line string) (string, string) {
+	idx := strings.IndexAny(line, " \t=")
+	if idx < 0 {
+		return strings.ToLower(line), ""
+	}
+	key := strings.ToLower(line[:idx])
+	value := strings.TrimLeft(line[idx:], " \t")
+	value = strings.TrimPrefix(value, "=")
+	return key, strings.TrimSpace(value)
+}
+
+func setOnce(dst *string, v string) {
+	if *dst == "" {
+		*dst = v
+	}
+}
+
+func newEntry() *pluginapi.SecretEntry {
+	return &pluginapi.SecretEntry{CustomAttributes: map[string]string{}}
+}
@@ -44,6 +44,10 @@ type Entry struct {
 	CustomAttributes map[string]string // e.g. "tls_cert", "private_key"
 }
 
+// ErrNotFound is wrapped by Get when no entry exists at the path, as
+// opposed to a malformed path or an unreadable database.
+var ErrNotFound = errors.New("not found")
+
 // Provider is the interface every secrets backend must implement.
 // Plugins receive a read/write Provider to resolve secret: paths from
 // their YAML configs.
@@ -215,7 +219,7 @@ func (kp *KeePassProvider) Delete(path string) error {
 	}
 
 	if removed := kp.removeEntriesByTitle(parts[0], parts[1], parts[2]); removed == 0 {
-		return fmt.Errorf("secrets: entry %q not found", path)
+		return fmt.Errorf("secrets: entry %q %w", path, ErrNotFound)
 	}
 
 	kp.dirty = true
@@ -462,11 +466,11 @@ func (kp *KeePassProvider) findGroup(parts []string) (*gkp.Group, error) {
 	root := kp.rootGroup()
 	pluginGroup := findSubGroup(root, parts[0])
 	if pluginGroup == nil {
-		return nil, fmt.Errorf("secrets: plugin group %q not found", parts[0])
+		return nil, fmt.Errorf("secrets: plugin group %q %w", parts[0], ErrNotFound)
 	}
 	envGroup := findSubGroup(pluginGroup, parts[1])
 	if envGroup == nil {
-		return nil, fmt.Errorf("secrets: environment group %q not found in %q", parts[1], parts[0])
+		return nil, fmt.Errorf("secrets: environment group %q %w in %q", parts[1], ErrNotFound, parts[0])
 	}
 	return envGroup, nil
 }
@@ -485,7 +489,7 @@ func (kp *KeePassProvider) findEntry(parts []string) (gkp.Entry, error) {
 		}
 	}
 
-	return gkp.Entry{}, fmt.Errorf("secrets: entry %q not found in %s/%s", entryTitle, parts[0], parts[1])
+	return gkp.Entry{}, fmt.Errorf("secrets: entry %q %w in %s/%s", entryTitle, ErrNotFound, parts[0], parts[1])
 }
 
 // ensureGroups creates the plugin/environment group hierarchy if needed, returning
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Keys in binding maps are spelled the way the header shows them: a single
+// character ("d", "G", "?"), "^x" for Ctrl+letter, "space", or a chord of
+// those separated by spaces ("g g").
+
+// KeyName returns the binding spelling of event, or "" for keys that cannot
+// be bound (Enter, Tab, arrows, ESC, …).
+func KeyName(event *tcell.EventKey) string {
+	switch k := event.Key(); {
+	case k == tcell.KeyRune:
+		if event.Rune() == ' ' {
+			return "space"
+		}
+		return string(event.Rune())
+	case k >= tcell.KeyCtrlA && k <= tcell.KeyCtrlZ:
+		letter := rune('a' + k - tcell.KeyCtrlA)
+		if strings.ContainsRune(reservedCtrlLetters, letter) {
+			return ""
+		}
+		return "^" + string(letter)
+	}
+	return ""
+}
+
+// Terminals send Ctrl+h, Ctrl+i and Ctrl+m as Backspace, Tab and Enter.
+const reservedCtrlLetters = "him"
+
+// NormalizeKeySpec parses a user-written key or chord ("ctrl+t", "C-t",
+// "^T", "g g", "space") into its binding spelling.
+func NormalizeKeySpec(spec string) (string, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty key")
+	}
+	for i, f := range fields {
+		key, err := normalizeKeyToken(f)
+		if err != nil {
+			return "", err
+		}
+		fields[i] = key
+	}
+	return strings.Join(fields, " "), nil
+}
+
+func normalizeKeyToken(tok string) (string, error) {
+	if len([]rune(tok)) == 1 {
+		return tok, nil
+	}
+	lower := strings.ToLower(tok)
+	if lower == "space" || lower == "spc" {
+		return "space", nil
+	}
+	for _, prefix := range []string{"ctrl+", "ctrl-", "c-", "^"} {
+		if !strings.HasPrefix(lower, prefix) {
+			continue
+		}
+		rest := lower[len(prefix):]
+		if len(rest) != 1 || rest[0] < 'a' || rest[0] > 'z' {
+			return "", fmt.Errorf("unknown key %q (ctrl takes a letter)", tok)
+		}
+		if strings.Contains(reservedCtrlLetters, rest) {
+			return "", fmt.Errorf("%q is indistinguishable from Backspace/Tab/Enter", tok)
+		}
+		return "^" + rest, nil
+	}
+	return "", fmt.Errorf("unknown key %q", tok)
+}
+
+// KeyChord assembles multi-key bindings ("g g") across key events.
+type KeyChord struct {
+	pending string
+}
+
+// Feed adds key to the chord in progress. lookup reports whether a sequence
+// is bound and whether it starts a longer binding. Feed returns the complete
+// bound sequence, or consumed=true with an empty seq while it waits for the
+// next key. An exact binding wins over a longer one sharing its prefix.
+func (k *KeyChord) Feed(key string, lookup func(seq string) (bound, prefix bool)) (seq string, consumed bool) {
+	if key == "" {
+		k.Reset()
+		return "", false
+	}
+	if k.pending != "" {
+		seq = k.pending + " " + key
+		k.pending = ""
+		if s, ok := k.step(seq, lookup); ok {
+			return s, true
+		}
+		// Not a continuation: start over with key on its own.
+	}
+	if s, ok := k.step(key, lookup); ok {
+		return s, true
+	}
+	return "", false
+}
+
+func (k *KeyChord) step(seq string, lookup func(string) (bool, bool)) (string, bool) {
+	bound, prefix := lookup(seq)
+	if bound {
+		return seq, true
+	}
+	if prefix {
+		k.pending = seq
+		return "", true
+	}
+	return "", false
+}
+
+// Pending returns the keys typed so far of an unfinished chord.
+func (k *KeyChord) Pending() string {
+	return k.pending
+}
+
+// Reset drops an unfinished chord.
+func (k *KeyChord) Reset() {
+	k.pending = ""
+}
+
+// lookupKey reports whether seq is bound in the view and whether it starts
+// a longer chord.
+func (c *CoreView) lookupKey(seq string) (bound, prefix bool) {
+	if handler, ok := c.keyHandlers[seq]; ok && handler != nil {
+		bound = true
+	}
+	if _, ok := c.keyBindings[seq]; ok {
+		bound = true
+	}
+	for key := range c.keyHandlers {
+		if strings.HasPrefix(key, seq+" ") {
+			return bound, true
+		}
+	}
+	for key := range c.keyBindings {
+		if strings.HasPrefix(key, seq+" ") {
+			return bound, true
+		}
+	}
+	return bound, false
+}
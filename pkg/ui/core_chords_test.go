@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestNormalizeKeySpec(t *testing.T) {
+	for spec, want := range map[string]string{
+		"d":        "d",
+		"ctrl+T":   "^t",
+		"C-e":      "^e",
+		"^x":       "^x",
+		"g  g":     "g g",
+		"Space":    "space",
+		"g ctrl+d": "g ^d",
+	} {
+		if got, err := NormalizeKeySpec(spec); err != nil || got != want {
+			t.Fatalf("NormalizeKeySpec(%q) = %q, %v; want %q", spec, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "ctrl+", "ctrl+m", "F5", "alt+x"} {
+		if _, err := NormalizeKeySpec(bad); err == nil {
+			t.Fatalf("NormalizeKeySpec(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestKeyChord(t *testing.T) {
+	bound := map[string]bool{"g g": true, "G": true, "x": true}
+	lookup := func(seq string) (bool, bool) {
+		return bound[seq], seq == "g"
+	}
+	var k KeyChord
+	if seq, consumed := k.Feed("g", lookup); seq != "" || !consumed || k.Pending() != "g" {
+		t.Fatalf("first g = %q, %v", seq, consumed)
+	}
+	if seq, _ := k.Feed("g", lookup); seq != "g g" {
+		t.Fatalf("second g = %q, want g g", seq)
+	}
+	k.Feed("g", lookup)
+	if seq, _ := k.Feed("x", lookup); seq != "x" || k.Pending() != "" {
+		t.Fatalf("g then x = %q, want x on its own", seq)
+	}
+	if seq, consumed := k.Feed("q", lookup); seq != "" || consumed {
+		t.Fatalf("unbound q = %q, %v", seq, consumed)
+	}
+	if name := KeyName(tcell.NewEventKey(tcell.KeyCtrlT, 0, tcell.ModCtrl)); name != "^t" {
+		t.Fatalf("KeyName(Ctrl+T) = %q", name)
+	}
+	if name := KeyName(tcell.NewEventKey(tcell.KeyTab, 0, 0)); name != "" {
+		t.Fatalf("KeyName(Tab) = %q, want unbindable", name)
+	}
+}
@@ -0,0 +1,193 @@
+package ui
+
+import "strings"
+
+// TableLayout is the user's choice of columns for one table. Columns are
+// named by header so the layout survives a plugin adding or moving columns;
+// names the table does not have are ignored.
+type TableLayout struct {
+	Hidden   []string       `yaml:"hidden,omitempty"`
+	Order    []string       `yaml:"order,omitempty"` // shown first, the rest keep plugin order
+	PinFirst bool           `yaml:"pin_first,omitempty"`
+	MaxWidth map[string]int `yaml:"max_width,omitempty"`
+	SortBy   string         `yaml:"sort_by,omitempty"`
+	SortType string         `yaml:"sort_type,omitempty"` // "" or "auto" detects it
+	SortDesc bool           `yaml:"sort_desc,omitempty"`
+}
+
+// IsZero reports whether the layout is the plugin default.
+func (l TableLayout) IsZero() bool {
+	return len(l.Hidden) == 0 && len(l.Order) == 0 && !l.PinFirst &&
+		len(l.MaxWidth) == 0 && l.SortBy == ""
+}
+
+// fullOrder returns every header, hidden ones included, in display order.
+func (l TableLayout) fullOrder(headers []string) []string {
+	placed := make(map[string]bool, len(headers))
+	out := make([]string, 0, len(headers))
+	for _, h := range l.Order {
+		if indexOf(headers, h) >= 0 && !placed[h] {
+			placed[h] = true
+			out = append(out, h)
+		}
+	}
+	for _, h := range headers {
+		if !placed[h] {
+			placed[h] = true
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// columnOrder maps shown columns to header indices.
+func (l TableLayout) columnOrder(headers []string) []int {
+	out := make([]int, 0, len(headers))
+	for _, h := range l.fullOrder(headers) {
+		if !l.isHidden(h) {
+			out = append(out, indexOf(headers, h))
+		}
+	}
+	if len(out) == 0 && len(headers) > 0 {
+		out = append(out, 0) // never hide everything
+	}
+	return out
+}
+
+func (l TableLayout) isHidden(header string) bool {
+	return indexOf(l.Hidden, header) >= 0
+}
+
+// matchHeaders rewrites the column names of l to the spelling of headers,
+// ignoring case, so a hand-written columns.yaml may say "ttl" for TTL. Names
+// no header matches are kept as written for when the plugin adds them.
+func (l TableLayout) matchHeaders(headers []string) TableLayout {
+	name := func(s string) string {
+		if s == "" || indexOf(headers, s) >= 0 {
+			return s
+		}
+		for _, h := range headers {
+			if strings.EqualFold(h, s) {
+				return h
+			}
+		}
+		return s
+	}
+	names := func(list []string) []string {
+		if list == nil {
+			return nil
+		}
+		out := make([]string, len(list))
+		for i, s := range list {
+			out[i] = name(s)
+		}
+		return out
+	}
+	l.Hidden = names(l.Hidden)
+	l.Order = names(l.Order)
+	l.SortBy = name(l.SortBy)
+	if l.MaxWidth != nil {
+		widths := make(map[string]int, len(l.MaxWidth))
+		for k, v := range l.MaxWidth {
+			widths[name(k)] = v
+		}
+		l.MaxWidth = widths
+	}
+	return l
+}
+
+// SetTableLayout applies a column layout and re-sorts the rows. Column names
+// match the headers regardless of case.
+func (c *CoreView) SetTableLayout(l TableLayout) *CoreView {
+	c.dataMutex.Lock()
+	defer c.dataMutex.Unlock()
+	c.layout = l.matchHeaders(c.tableHeaders)
+	c.tableData = c.applyFilter(c.rawTableData)
+	c.refreshTable()
+	c.updateTableTitle()
+	return c
+}
+
+// TableLayout returns the current column layout.
+func (c *CoreView) TableLayout() TableLayout {
+	return c.layout
+}
+
+// SetLayoutChangedFunc is called after the user edits columns or sorting.
+func (c *CoreView) SetLayoutChangedFunc(fn func(TableLayout)) *CoreView {
+	c.onLayout = fn
+	return c
+}
+
+// changeLayout applies an edit made in the UI and reports it.
+func (c *CoreView) changeLayout(l TableLayout) {
+	c.SetTableLayout(l)
+	if c.onLayout != nil {
+		c.onLayout(l)
+	}
+}
+
+// SortBy sorts the table by column; an empty column restores plugin order.
+func (c *CoreView) SortBy(column, kind string, desc bool) {
+	l := c.layout
+	l.SortBy, l.SortType, l.SortDesc = column, kind, desc
+	if column == "" {
+		l.SortType, l.SortDesc = "", false
+	}
+	c.changeLayout(l)
+}
+
+// cycleSort steps a column through ascending, descending and unsorted
+// (header click).
+func (c *CoreView) cycleSort(column string) {
+	switch {
+	case c.layout.SortBy != column:
+		c.SortBy(column, c.layout.SortType, false)
+	case !c.layout.SortDesc:
+		c.SortBy(column, c.layout.SortType, true)
+	default:
+		c.SortBy("", "", false)
+	}
+}
+
+// sortView orders filtered rows by the layout's sort column. indices are the
+// raw row indices, built when nil.
+func (c *CoreView) sortView(rows [][]string, indices []int) ([][]string, []int) {
+	col := indexOf(c.tableHeaders, c.layout.SortBy)
+	if c.layout.SortBy == "" || col < 0 || len(rows) == 0 {
+		return rows, indices
+	}
+	sorted := make([][]string, len(rows))
+	copy(sorted, rows)
+	if indices == nil {
+		indices = make([]int, len(rows))
+		for i := range indices {
+			indices[i] = i
+		}
+	} else {
+		indices = append([]int(nil), indices...)
+	}
+	sortRows(sorted, indices, col, c.layout.SortType, c.layout.SortDesc)
+	return sorted, indices
+}
+
+// GetShownTable returns the headers and rows as the table shows them:
+// filtered, sorted, and with the layout's columns in order.
+func (c *CoreView) GetShownTable() ([]string, [][]string) {
+	order := c.layout.columnOrder(c.tableHeaders)
+	headers := make([]string, len(order))
+	for i, col := range order {
+		headers[i] = c.tableHeaders[col]
+	}
+	rows := make([][]string, len(c.tableData))
+	for r, row := range c.tableData {
+		out := make([]string, len(order))
+		for i, col := range order {
+			if col < len(row) {
+				out[i] = row[col]
+			}
+		}
+		rows[r] = out
+	}
+	return headers, rows
+}
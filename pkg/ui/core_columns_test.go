@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rivo/tview"
+)
+
+func TestTableLayoutColumnOrder(t *testing.T) {
+	headers := []string{"name", "size", "age", "status"}
+	l := TableLayout{Order: []string{"status", "gone"}, Hidden: []string{"age"}}
+	if got, want := l.columnOrder(headers), []int{3, 0, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("columnOrder = %v, want %v", got, want)
+	}
+	all := TableLayout{Hidden: headers}
+	if got, want := all.columnOrder(headers), []int{0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("columnOrder all hidden = %v, want %v", got, want)
+	}
+}
+
+func TestTableLayoutMatchesHeadersIgnoringCase(t *testing.T) {
+	c := NewCoreView(tview.NewApplication(), "test")
+	c.SetTableLayout(TableLayout{Hidden: []string{"type"}, Order: []string{"ttl", "gone"}, MaxWidth: map[string]int{"key": 40}, SortBy: "size", SortDesc: true})
+	c.SetTableHeaders([]string{"Key", "Type", "TTL", "Size"})
+	c.SetTableData([][]string{{"a", "string", "-1", "2 kB"}, {"b", "hash", "60", "1 MB"}})
+
+	l := c.TableLayout()
+	want := TableLayout{Hidden: []string{"Type"}, Order: []string{"TTL", "gone"}, MaxWidth: map[string]int{"Key": 40}, SortBy: "Size", SortDesc: true}
+	if !reflect.DeepEqual(l, want) {
+		t.Fatalf("layout = %+v, want %+v", l, want)
+	}
+	headers, rows := c.GetShownTable()
+	if !reflect.DeepEqual(headers, []string{"TTL", "Key", "Size"}) || rows[0][1] != "b" {
+		t.Fatalf("shown table = %v %v, want TTL first, Type hidden, sorted by Size desc", headers, rows)
+	}
+}
+
+func TestCoreViewSortAndShownTable(t *testing.T) {
+	c := NewCoreView(tview.NewApplication(), "test")
+	c.SetTableHeaders([]string{"name", "size"})
+	c.SetTableData([][]string{{"a", "2 MB"}, {"b", "10 kB"}, {"c", "1 GB"}})
+
+	var saved TableLayout
+	c.SetLayoutChangedFunc(func(l TableLayout) { saved = l })
+	c.SortBy("size", SortAuto, false)
+	if saved.SortBy != "size" {
+		t.Fatalf("saved layout = %+v, want sort_by size", saved)
+	}
+	c.table.Select(1, 0)
+	if got := c.GetSelectedRowData()[0]; got != "b" {
+		t.Fatalf("first sorted row = %q, want b", got)
+	}
+	if got := c.GetSelectedRow(); got != 1 {
+		t.Fatalf("GetSelectedRow = %d, want raw index 1", got)
+	}
+
+	c.SetTableLayout(TableLayout{SortBy: "size", SortDesc: true, Order: []string{"size"}, Hidden: []string{"name"}})
+	headers, rows := c.GetShownTable()
+	if !reflect.DeepEqual(headers, []string{"size"}) || !reflect.DeepEqual(rows, [][]string{{"1 GB"}, {"2 MB"}, {"10 kB"}}) {
+		t.Fatalf("shown table = %v %v", headers, rows)
+	}
+
+	c.cycleSort("size") // desc → unsorted
+	if _, rows := c.GetShownTable(); rows[0][0] != "2 MB" {
+		t.Fatalf("unsorted first row = %v, want plugin order", rows[0])
+	}
+}
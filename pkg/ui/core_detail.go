@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// The detail pane sits right of the table and shows the highlighted row's
+// details. The table keeps focus; the pane only displays.
+
+// Table : pane width ratio while the pane is open.
+const (
+	detailTableShare = 3
+	detailPaneShare  = 2
+)
+
+func (c *CoreView) initDetailPane() {
+	c.detailPane = tview.NewTextView()
+	c.detailPane.SetDynamicColors(true)
+	c.detailPane.SetWrap(true)
+	c.detailPane.SetWordWrap(true)
+	c.detailPane.SetScrollable(true)
+	c.detailPane.SetBorder(true)
+	c.detailPane.SetBorderPadding(0, 0, 1, 1)
+	c.detailPane.SetTitleAlign(tview.AlignLeft)
+	c.styleDetailPane()
+
+	c.tableRow = tview.NewFlex()
+	c.tableRow.SetDirection(tview.FlexColumn)
+	c.tableRow.SetBackgroundColor(ColorAppBg)
+	c.tableRow.AddItem(c.table, 0, detailTableShare, true)
+}
+
+func (c *CoreView) styleDetailPane() {
+	c.detailPane.SetBackgroundColor(ColorAppBg)
+	c.detailPane.SetTextColor(ColorTableRow)
+	c.detailPane.SetBorderColor(ColorBorder)
+	c.detailPane.SetTitleColor(ColorBorder)
+}
+
+// SetDetailPaneVisible opens or closes the detail pane.
+func (c *CoreView) SetDetailPaneVisible(visible bool) *CoreView {
+	if c.tableRow == nil || visible == c.detailVisible {
+		return c
+	}
+	c.detailVisible = visible
+	if visible {
+		c.tableRow.AddItem(c.detailPane, 0, detailPaneShare, false)
+	} else {
+		c.tableRow.RemoveItem(c.detailPane)
+	}
+	return c
+}
+
+// ToggleDetailPane flips the detail pane and returns whether it is open.
+func (c *CoreView) ToggleDetailPane() bool {
+	c.SetDetailPaneVisible(!c.detailVisible)
+	return c.detailVisible
+}
+
+// DetailPaneVisible reports whether the detail pane is open.
+func (c *CoreView) DetailPaneVisible() bool {
+	return c.detailVisible
+}
+
+// SetDetail shows text (tview color tags allowed) in the detail pane.
+func (c *CoreView) SetDetail(title, text string) *CoreView {
+	if c.detailPane == nil {
+		return c
+	}
+	c.detailPane.SetTitle(fmt.Sprintf(" [%s]%s[-] ", HexBorder, title))
+	c.detailPane.SetText(SlotColors(text))
+	c.detailPane.ScrollToBeginning()
+	return c
+}
+
+// ScrollDetail scrolls the detail pane by lines (negative is up).
+func (c *CoreView) ScrollDetail(lines int) {
+	if c.detailPane == nil || !c.detailVisible {
+		return
+	}
+	row, _ := c.detailPane.GetScrollOffset()
+	c.detailPane.ScrollTo(max(0, row+lines), 0)
+}
+
+// handleDetailScroll scrolls the open pane on Shift+↑/↓ without moving the
+// table selection.
+func (c *CoreView) handleDetailScroll(event *tcell.EventKey) bool {
+	if !c.detailVisible || event.Modifiers()&tcell.ModShift == 0 {
+		return false
+	}
+	switch event.Key() {
+	case tcell.KeyUp:
+		c.ScrollDetail(-1)
+	case tcell.KeyDown:
+		c.ScrollDetail(1)
+	default:
+		return false
+	}
+	return true
+}
@@ -8,7 +8,7 @@ import (
 
 // SetInfoText updates the content of the info panel
 func (c *CoreView) SetInfoText(text string) *CoreView {
-	c.infoPanel.SetText(text)
+	c.infoPanel.SetText(SlotColors(text))
 	return c
 }
 
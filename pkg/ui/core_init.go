@@ -33,6 +33,10 @@ var (
 	HexLabel         = defaultPalette.Label
 	HexInfoKey       = defaultPalette.InfoKey
 	HexValue         = defaultPalette.Value
+	HexOK            = defaultPalette.OK
+	HexWarn          = defaultPalette.Warn
+	HexError         = defaultPalette.Error
+	HexMuted         = defaultPalette.Muted
 
 	// ColorAppBg is the full-screen OMO background.
 	ColorAppBg = tcell.GetColor(HexAppBg)
@@ -93,6 +97,8 @@ func (c *CoreView) initUI() {
 	c.table.SetTitleColor(ColorBorder)
 
 	c.tableContent = NewVirtualTableContent()
+	c.tableContent.SetMarkedFunc(c.isMarked)
+	c.tableContent.SetHeaderClickedFunc(c.cycleSort)
 	c.table.SetContent(c.tableContent)
 	c.table.SetFixed(1, 0)
 
@@ -130,10 +136,12 @@ func (c *CoreView) initUI() {
 		AddItem(headerSpacer(), headerColGap, 0, false).
 		AddItem(c.logoSlot, HeaderLogoWidth, 0, false)
 
-	// Content area swaps between the table and the in-place logs viewer.
+	// Content area swaps between the table (with the optional detail pane)
+	// and the in-place logs viewer.
+	c.initDetailPane()
 	c.contentPages = tview.NewPages()
 	c.contentPages.SetBackgroundColor(ColorAppBg)
-	c.contentPages.AddPage(tableContentPage, c.table, true, true)
+	c.contentPages.AddPage(tableContentPage, c.tableRow, true, true)
 
 	c.mainLayout = tview.NewFlex()
 	c.mainLayout.SetDirection(tview.FlexRow)
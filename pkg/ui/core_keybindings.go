@@ -57,6 +57,14 @@ func (c *CoreView) BindKey(key string, handler func()) *CoreView {
 	return c
 }
 
+// UnbindKey removes key from the Keys column and its handler.
+func (c *CoreView) UnbindKey(key string) *CoreView {
+	delete(c.keyBindings, key)
+	delete(c.keyHandlers, key)
+	c.refreshHeaderPanels()
+	return c
+}
+
 // ClearKeyBindings clears view + key columns while preserving standard globals in Keys.
 func (c *CoreView) ClearKeyBindings() *CoreView {
 	standardBindings := make(map[string]string)
@@ -17,8 +17,22 @@ type KeyAction struct {
 //  2. Action callback (onAction "keypress") — plugin-level routing
 //  3. Built-in default — only for standard keys (R=refresh, ?=help, /=filter)
 //
-// Non-rune keys (ESC, PgDn) are handled separately for navigation.
+// Bound keys may be chords ("g g"); the first key of a chord is held until
+// the next one arrives. Non-rune keys (ESC, PgDn) are handled separately for
+// navigation; ESC also cancels a half-typed chord.
 func (c *CoreView) StandardKeyHandler(event *tcell.EventKey, oldCapture func(*tcell.EventKey) *tcell.EventKey) *tcell.EventKey {
+	key := KeyName(event)
+	if key == "" && c.chord.Pending() != "" {
+		c.chord.Reset()
+		if event.Key() == tcell.KeyEscape {
+			return nil
+		}
+	}
+
+	if c.handleDetailScroll(event) {
+		return nil
+	}
+
 	switch event.Key() {
 	case tcell.KeyEscape:
 		return c.handleEscape(event, oldCapture)
@@ -28,41 +42,17 @@ func (c *CoreView) StandardKeyHandler(event *tcell.EventKey, oldCapture func(*tc
 			c.LoadMore()
 			return nil
 		}
+	}
 
-	case tcell.KeyRune:
-		key := string(event.Rune())
-
-		// Priority 1: direct handler (header bindings + silent action keys)
-		if handler, ok := c.keyHandlers[key]; ok && handler != nil {
-			handler()
+	if key != "" {
+		seq, consumed := c.chord.Feed(key, c.lookupKey)
+		if seq != "" {
+			c.runKey(seq)
 			return nil
 		}
-
-		// Only fall through to defaults for keys listed in the header matrix.
-		if _, registered := c.keyBindings[key]; !registered {
-			break
-		}
-
-		// Priority 2: action callback (lets plugin handle it)
-		if c.onAction != nil {
-			err := c.onAction("keypress", map[string]interface{}{
-				"key": key,
-			})
-			if err == nil {
-				return nil
-			}
-		}
-
-		// Priority 3: built-in defaults for standard keys
-		switch key {
-		case "R":
-			c.RefreshData()
-		case "/":
-			c.showFilterModal()
-		case "?":
-			c.ShowHelpModal()
+		if consumed {
+			return nil
 		}
-		return nil
 	}
 
 	if oldCapture != nil {
@@ -71,8 +61,42 @@ func (c *CoreView) StandardKeyHandler(event *tcell.EventKey, oldCapture func(*tc
 	return event
 }
 
-// handleEscape processes the ESC key for back-navigation through the view stack.
+// runKey dispatches a bound key or chord.
+func (c *CoreView) runKey(key string) {
+	// Priority 1: direct handler (header bindings + silent action keys)
+	if handler, ok := c.keyHandlers[key]; ok && handler != nil {
+		handler()
+		return
+	}
+
+	// Priority 2: action callback (lets plugin handle it)
+	if c.onAction != nil {
+		err := c.onAction("keypress", map[string]interface{}{
+			"key": key,
+		})
+		if err == nil {
+			return
+		}
+	}
+
+	// Priority 3: built-in defaults for standard keys
+	switch key {
+	case "R":
+		c.RefreshData()
+	case "/":
+		c.showFilterModal()
+	case "?":
+		c.ShowHelpModal()
+	}
+}
+
+// handleEscape processes the ESC key for back-navigation through the view
+// stack. With rows marked, the first ESC only clears the marks.
 func (c *CoreView) handleEscape(event *tcell.EventKey, oldCapture func(*tcell.EventKey) *tcell.EventKey) *tcell.EventKey {
+	if c.MarkCount() > 0 {
+		c.ClearMarks()
+		return nil
+	}
 	if len(c.navStack) > 1 {
 		currentView := c.GetCurrentView()
 		previousView := c.navStack[len(c.navStack)-2]
@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fmt"
+)
+
+// Rows can be marked for batch actions. Marks are keyed by the row
+// signature (selection key column), so they survive refresh and filtering;
+// marks of rows that no longer exist are dropped when new data arrives.
+
+const markGlyph = "● "
+
+// ToggleMark marks or unmarks the highlighted row and moves to the next one.
+func (c *CoreView) ToggleMark() {
+	row := c.GetSelectedRowData()
+	if row == nil {
+		return
+	}
+	sig := c.getRowSignature(row)
+	if c.marked[sig] {
+		delete(c.marked, sig)
+	} else {
+		if c.marked == nil {
+			c.marked = map[string]bool{}
+		}
+		c.marked[sig] = true
+	}
+	if next := c.selectedRow + 1; next < len(c.tableData) {
+		c.selectedRow = next
+		c.table.Select(next+1, 0) // +1 for header
+	}
+	c.updateTableTitle()
+}
+
+// ToggleMarkAll marks every row the filter shows, or unmarks them when all
+// of them are already marked.
+func (c *CoreView) ToggleMarkAll() {
+	if len(c.tableData) == 0 {
+		return
+	}
+	all := true
+	for _, row := range c.tableData {
+		if !c.marked[c.getRowSignature(row)] {
+			all = false
+			break
+		}
+	}
+	if c.marked == nil {
+		c.marked = map[string]bool{}
+	}
+	for _, row := range c.tableData {
+		if all {
+			delete(c.marked, c.getRowSignature(row))
+		} else {
+			c.marked[c.getRowSignature(row)] = true
+		}
+	}
+	c.updateTableTitle()
+}
+
+// ClearMarks unmarks every row.
+func (c *CoreView) ClearMarks() {
+	if len(c.marked) == 0 {
+		return
+	}
+	c.marked = nil
+	c.updateTableTitle()
+}
+
+// UnmarkRows unmarks the given rows (e.g. the ones a batch action handled).
+func (c *CoreView) UnmarkRows(rows [][]string) {
+	for _, row := range rows {
+		delete(c.marked, c.getRowSignature(row))
+	}
+	c.updateTableTitle()
+}
+
+// MarkCount returns the number of marked rows.
+func (c *CoreView) MarkCount() int {
+	return len(c.marked)
+}
+
+// MarkedRows returns the marked rows in table order, including rows the
+// current filter hides.
+func (c *CoreView) MarkedRows() [][]string {
+	if len(c.marked) == 0 {
+		return nil
+	}
+	out := make([][]string, 0, len(c.marked))
+	for _, row := range c.rawTableData {
+		if c.marked[c.getRowSignature(row)] {
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+// isMarked reports whether the shown row at dataRow is marked.
+func (c *CoreView) isMarked(dataRow int) bool {
+	if len(c.marked) == 0 || dataRow < 0 || dataRow >= len(c.tableData) {
+		return false
+	}
+	return c.marked[c.getRowSignature(c.tableData[dataRow])]
+}
+
+// pruneMarks drops marks whose rows are gone from the data.
+func (c *CoreView) pruneMarks() {
+	if len(c.marked) == 0 {
+		return
+	}
+	present := make(map[string]bool, len(c.rawTableData))
+	for _, row := range c.rawTableData {
+		present[c.getRowSignature(row)] = true
+	}
+	before := len(c.marked)
+	for sig := range c.marked {
+		if !present[sig] {
+			delete(c.marked, sig)
+		}
+	}
+	if len(c.marked) != before {
+		c.updateTableTitle()
+	}
+}
+
+// updateTableTitle shows the plugin title with the active filter and the
+// number of marked rows.
+func (c *CoreView) updateTableTitle() {
+	if c.table == nil {
+		return
+	}
+	title := fmt.Sprintf(" [%s]%s[%s] ", HexBorder, c.title, HexValue)
+	if c.filterQuery != "" {
+		title += fmt.Sprintf("[%s](filter: %s)[%s] ", HexLabel, c.filterQuery, HexValue)
+	}
+	if n := len(c.marked); n > 0 {
+		title += fmt.Sprintf("[%s](%d marked)[%s] ", HexActionKey, n, HexValue)
+	}
+	c.table.SetTitle(title)
+}
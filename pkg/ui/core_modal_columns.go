@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// unsortedLabel is the sort modal's "no sort" column.
+const unsortedLabel = "(plugin order)"
+
+// ShowSortModal picks the sort column, type and direction of the table.
+func (c *CoreView) ShowSortModal() {
+	if c.pages == nil || len(c.tableHeaders) == 0 {
+		return
+	}
+	columns := append([]string{unsortedLabel}, c.tableHeaders...)
+	current := &SortOptions{Column: unsortedLabel, SortType: SortAuto, Direction: "asc"}
+	if c.layout.SortBy != "" {
+		current.Column = c.layout.SortBy
+	}
+	if c.layout.SortType != "" {
+		current.SortType = c.layout.SortType
+	}
+	if c.layout.SortDesc {
+		current.Direction = "desc"
+	}
+	ShowSortModal(c.pages, c.app, columns, current, func(options *SortOptions, cancelled bool) {
+		c.app.SetFocus(c.table)
+		if cancelled {
+			return
+		}
+		if options.Column == unsortedLabel {
+			c.SortBy("", "", false)
+			return
+		}
+		c.SortBy(options.Column, options.SortType, options.Direction == "desc")
+	})
+}
+
+// Column width caps step by columnWidthStep; 0 means no cap.
+const (
+	columnWidthStep = 4
+	columnWidthMin  = 8
+)
+
+// ShowColumnsModal opens the column editor. Changes show in the table as
+// they are made and are reported to SetLayoutChangedFunc on close.
+func (c *CoreView) ShowColumnsModal() {
+	if c.pages == nil || len(c.tableHeaders) == 0 {
+		return
+	}
+	const pageID = "columns-modal"
+	l := c.layout
+	l.Hidden = append([]string(nil), l.Hidden...)
+	l.MaxWidth = make(map[string]int, len(c.layout.MaxWidth))
+	for k, v := range c.layout.MaxWidth {
+		l.MaxWidth[k] = v
+	}
+	order := l.fullOrder(c.tableHeaders)
+
+	list := tview.NewTable()
+	list.SetSelectable(true, false)
+	list.SetBackgroundColor(ColorAppBg)
+	list.SetBorder(true)
+	list.SetBorderColor(ColorBorder)
+	list.SetTitle(" Columns ")
+	list.SetTitleColor(tcell.ColorOrange)
+	list.SetTitleAlign(tview.AlignCenter)
+	list.SetBorderPadding(1, 1, 2, 2)
+	list.SetSelectedStyle(tcell.StyleDefault.Foreground(ColorHighlightText).Background(ColorHighlight))
+
+	draw := func() {
+		list.Clear()
+		for i, h := range order {
+			box := "[x]"
+			color := ColorTableRow
+			if l.isHidden(h) {
+				box = "[ ]"
+				color = tcell.ColorGray
+			}
+			width := "auto"
+			if w := l.MaxWidth[h]; w > 0 {
+				width = fmt.Sprintf("≤ %d", w)
+			}
+			name := h
+			if i == 0 && l.PinFirst {
+				name += " (pinned)"
+			}
+			list.SetCell(i, 0, tview.NewTableCell(tview.Escape(box)).SetTextColor(color))
+			list.SetCell(i, 1, tview.NewTableCell(name).SetTextColor(color).SetExpansion(1))
+			list.SetCell(i, 2, tview.NewTableCell(width).SetTextColor(color).SetAlign(tview.AlignRight))
+		}
+	}
+	apply := func() {
+		l.Order = append([]string(nil), order...)
+		draw()
+		c.SetTableLayout(l)
+	}
+	move := func(row, delta int) {
+		to := row + delta
+		if to < 0 || to >= len(order) {
+			return
+		}
+		order[row], order[to] = order[to], order[row]
+		apply()
+		list.Select(to, 0)
+	}
+	done := func() {
+		pages := c.pages
+		if pages.HasPage(pageID) {
+			pages.RemovePage(pageID)
+		}
+		if len(l.MaxWidth) == 0 {
+			l.MaxWidth = nil
+		}
+		if strings.Join(l.Order, "\x00") == strings.Join(c.tableHeaders, "\x00") {
+			l.Order = nil // plugin order
+		}
+		c.changeLayout(l)
+		c.app.SetFocus(c.table)
+	}
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		row, _ := list.GetSelection()
+		if row < 0 || row >= len(order) {
+			return event
+		}
+		h := order[row]
+		switch {
+		case event.Key() == tcell.KeyEnter:
+			done()
+			return nil
+		case event.Key() == tcell.KeyUp && event.Modifiers()&tcell.ModShift != 0,
+			event.Key() == tcell.KeyRune && event.Rune() == 'K':
+			move(row, -1)
+			return nil
+		case event.Key() == tcell.KeyDown && event.Modifiers()&tcell.ModShift != 0,
+			event.Key() == tcell.KeyRune && event.Rune() == 'J':
+			move(row, 1)
+			return nil
+		case event.Key() != tcell.KeyRune:
+			return event
+		}
+		switch event.Rune() {
+		case ' ':
+			if i := indexOf(l.Hidden, h); i >= 0 {
+				l.Hidden = append(l.Hidden[:i], l.Hidden[i+1:]...)
+			} else if len(l.Hidden) < len(order)-1 {
+				l.Hidden = append(l.Hidden, h)
+			}
+		case '+', '>':
+			if l.MaxWidth[h] == 0 {
+				l.MaxWidth[h] = columnWidthMin
+			} else {
+				l.MaxWidth[h] += columnWidthStep
+			}
+		case '-', '<':
+			if l.MaxWidth[h] <= columnWidthMin {
+				delete(l.MaxWidth, h)
+			} else {
+				l.MaxWidth[h] -= columnWidthStep
+			}
+		case 'p':
+			l.PinFirst = !l.PinFirst
+		case 'r':
+			l = TableLayout{SortBy: l.SortBy, SortType: l.SortType, SortDesc: l.SortDesc, MaxWidth: map[string]int{}}
+			order = l.fullOrder(c.tableHeaders)
+		default:
+			return event
+		}
+		apply()
+		return nil
+	})
+	draw()
+
+	help := tview.NewTextView()
+	help.SetDynamicColors(true)
+	help.SetTextAlign(tview.AlignCenter)
+	help.SetBackgroundColor(ColorAppBg)
+	help.SetText(fmt.Sprintf("[%s]Space[-] show/hide  [%s]K/J[-] move  [%s]+/-[-] width  [%s]p[-] pin first  [%s]r[-] reset  [%s]Enter[-] done",
+		HexActionKey, HexActionKey, HexActionKey, HexActionKey, HexActionKey, HexActionKey))
+
+	width := 64
+	height := len(order) + 4
+	if height > 20 {
+		height = 20
+	}
+	innerFlex := tview.NewFlex()
+	innerFlex.SetDirection(tview.FlexRow)
+	innerFlex.SetBackgroundColor(ColorAppBg)
+	innerFlex.AddItem(nil, 0, 1, false).
+		AddItem(list, height, 1, true).
+		AddItem(help, 1, 0, false).
+		AddItem(nil, 0, 1, false)
+
+	flex := tview.NewFlex()
+	flex.SetBackgroundColor(ColorAppBg)
+	flex.AddItem(nil, 0, 1, false).
+		AddItem(innerFlex, width, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	RemovePage(c.pages, c.app, pageID, done)
+	c.pages.AddPage(pageID, flex, true, true)
+	c.app.SetFocus(list)
+}
@@ -15,7 +15,7 @@ func ShowInfoModal(
 ) {
 	// Create text view for the information
 	textView := tview.NewTextView()
-	textView.SetText(text)
+	textView.SetText(SlotColors(text))
 	textView.SetTextColor(tcell.ColorWhite)
 	textView.SetDynamicColors(true)
 	textView.SetScrollable(true) // Make scrollable like help modal
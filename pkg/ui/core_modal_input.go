@@ -15,6 +15,70 @@ func ShowCompactStyledInputModal(
 	inputFieldWidth int,
 	fieldValidator func(textToCheck string, lastChar rune) bool,
 	callback func(text string, cancelled bool),
+) {
+	showInputModal(pages, app, title, inputLabel, placeholder, inputFieldWidth, fieldValidator, nil, callback)
+}
+
+// ShowHistoryInputModal is ShowCompactStyledInputModal with shell-style
+// recall: Up and Down walk history (oldest first) and come back to the draft.
+func ShowHistoryInputModal(
+	pages *tview.Pages,
+	app *tview.Application,
+	title string,
+	inputLabel string,
+	inputFieldWidth int,
+	history []string,
+	callback func(text string, cancelled bool),
+) {
+	showInputModal(pages, app, title, inputLabel, "", inputFieldWidth, nil, history, callback)
+}
+
+// historyCursor walks input history from the newest entry back.
+type historyCursor struct {
+	items []string
+	pos   int // len(items) while editing the draft
+	draft string
+}
+
+func newHistoryCursor(items []string) *historyCursor {
+	return &historyCursor{items: items, pos: len(items)}
+}
+
+// prev returns the next older entry; current is kept as the draft when
+// leaving it.
+func (h *historyCursor) prev(current string) (string, bool) {
+	if h.pos == 0 {
+		return "", false
+	}
+	if h.pos == len(h.items) {
+		h.draft = current
+	}
+	h.pos--
+	return h.items[h.pos], true
+}
+
+// next returns the next newer entry, or the draft past the newest.
+func (h *historyCursor) next() (string, bool) {
+	if h.pos >= len(h.items) {
+		return "", false
+	}
+	h.pos++
+	if h.pos == len(h.items) {
+		return h.draft, true
+	}
+	return h.items[h.pos], true
+}
+
+func showInputModal(
+	pages *tview.Pages,
+	app *tview.Application,
+	title string,
+	inputLabel string,
+	placeholder string,
+	inputFieldWidth int,
+	fieldValidator func(textToCheck string, lastChar rune) bool,
+	history []string,
+	callback func(text string, cancelled bool),
 ) {
 	const pageID = "compact-modal"
 
@@ -66,6 +130,28 @@ func ShowCompactStyledInputModal(
 		}
 	})
 
+	if len(history) > 0 {
+		cursor := newHistoryCursor(history)
+		inputField.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+			var (
+				text string
+				ok   bool
+			)
+			switch event.Key() {
+			case tcell.KeyUp:
+				text, ok = cursor.prev(inputField.GetText())
+			case tcell.KeyDown:
+				text, ok = cursor.next()
+			default:
+				return event
+			}
+			if ok {
+				inputField.SetText(text)
+			}
+			return nil
+		})
+	}
+
 	// Style the buttons with focus colors
 	for i := 0; i < form.GetButtonCount(); i++ {
 		if b := form.GetButton(i); b != nil {
@@ -0,0 +1,23 @@
+package ui
+
+import "testing"
+
+func TestHistoryCursor(t *testing.T) {
+	h := newHistoryCursor([]string{"GET a", "GET b"})
+	if got, ok := h.next(); ok {
+		t.Fatalf("next on draft = %q, want nothing", got)
+	}
+	if got, _ := h.prev("draft"); got != "GET b" {
+		t.Fatalf("prev = %q, want GET b", got)
+	}
+	if got, _ := h.prev("GET b"); got != "GET a" {
+		t.Fatalf("prev = %q, want GET a", got)
+	}
+	if _, ok := h.prev("GET a"); ok {
+		t.Fatalf("prev past oldest moved")
+	}
+	h.next()
+	if got, _ := h.next(); got != "draft" {
+		t.Fatalf("next past newest = %q, want the draft", got)
+	}
+}
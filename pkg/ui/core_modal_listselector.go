@@ -34,6 +34,33 @@ func ShowStandardListSelectorModal(
 	title string,
 	items [][]string, // Each item is [name, description]
 	callback func(index int, name string, cancelled bool),
+) {
+	showListSelector(pages, app, title, items, 0, nil, callback)
+}
+
+// ShowPreviewListSelectorModal is ShowStandardListSelectorModal starting at
+// current and calling preview as the highlight moves, e.g. to try themes.
+// The modal restyles itself after each preview.
+func ShowPreviewListSelectorModal(
+	pages *tview.Pages,
+	app *tview.Application,
+	title string,
+	items [][]string,
+	current int,
+	preview func(index int),
+	callback func(index int, name string, cancelled bool),
+) {
+	showListSelector(pages, app, title, items, current, preview, callback)
+}
+
+func showListSelector(
+	pages *tview.Pages,
+	app *tview.Application,
+	title string,
+	items [][]string,
+	current int,
+	preview func(index int),
+	callback func(index int, name string, cancelled bool),
 ) {
 	// Create list with items
 	list := tview.NewList()
@@ -86,6 +113,22 @@ func ShowStandardListSelectorModal(
 		AddItem(innerFlex, width, 1, true).
 		AddItem(nil, 0, 1, false)
 
+	if current > 0 && current < len(items) {
+		list.SetCurrentItem(current)
+	}
+	if preview != nil {
+		list.SetChangedFunc(func(index int, _, _ string, _ rune) {
+			preview(index)
+			list.SetSelectedBackgroundColor(ColorHighlight)
+			list.SetSelectedTextColor(ColorHighlightText)
+			list.SetBorderColor(ColorBorder)
+			list.SetBackgroundColor(ColorAppBg)
+			innerFlex.SetBackgroundColor(ColorAppBg)
+			flex.SetBackgroundColor(ColorAppBg)
+			helpText.SetBackgroundColor(ColorAppBg)
+		})
+	}
+
 	// Set the callback for when an item is selected
 	list.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
 		pages.RemovePage("list-selector-modal")
@@ -3,6 +3,7 @@
 package ui
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
@@ -19,6 +20,14 @@ type ProgressModal struct {
 	modal         *tview.Flex        // The main container for the modal
 	progressBar   *tview.TextView    // Visual progress bar component
 	statusText    *tview.TextView    // Status text component
+	results       *tview.TextView    // Per-item results, shown after the first AddResult
+	resultCount   int                // Number of results added
+	content       *tview.Flex        // Bar, status and results
+	body          *tview.Flex        // Content, spacer and button row
+	column        *tview.Flex        // Vertical centering around the frame
+	frame         *tview.Frame       // Bordered frame
+	form          *tview.Form        // Cancel / Close button
+	onClose       func()             // Callback when Close is pressed after Finish
 	progress      int                // Current progress value
 	maxProgress   int                // Maximum progress value
 	pageName      string             // Name of the page in the pages component
@@ -70,10 +79,24 @@ func NewProgressModal(pages *tview.Pages, app *tview.Application, title string,
 	contentFlex.AddItem(pm.progressBar, 1, 0, false).
 		AddItem(pm.statusText, 1, 0, false)
 
+	pm.results = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	pm.results.SetBackgroundColor(ColorAppBg)
+	contentFlex.AddItem(pm.results, 0, 0, false)
+	pm.content = contentFlex
+
 	// Create a form for the cancel button
 	form := tview.NewForm()
 	form.SetButtonsAlign(tview.AlignCenter)
 	form.AddButton("Cancel", func() {
+		if pm.done {
+			pm.Close()
+			if pm.onClose != nil {
+				pm.onClose()
+			}
+			return
+		}
 		if pm.onCancel != nil {
 			pm.onCancel()
 		}
@@ -82,6 +105,8 @@ func NewProgressModal(pages *tview.Pages, app *tview.Application, title string,
 	form.SetBackgroundColor(ColorAppBg)
 	form.SetButtonBackgroundColor(ColorAppBg)
 	form.SetButtonTextColor(tcell.ColorWhite)
+	form.SetBorderPadding(0, 0, 0, 0) // the button row is one line tall
+	pm.form = form
 
 	// Create the main flex layout
 	innerFlex := tview.NewFlex().
@@ -89,6 +114,7 @@ func NewProgressModal(pages *tview.Pages, app *tview.Application, title string,
 		AddItem(contentFlex, 3, 0, false).
 		AddItem(nil, 1, 0, false).
 		AddItem(form, 1, 0, false)
+	pm.body = innerFlex
 
 	// Add a border and title with more visible styling
 	frame := tview.NewFrame(innerFlex)
@@ -97,6 +123,7 @@ func NewProgressModal(pages *tview.Pages, app *tview.Application, title string,
 	frame.SetBackgroundColor(ColorAppBg)
 	frame.SetBorderPadding(1, 1, 2, 2)
 	frame.AddText(" "+title+" ", true, tview.AlignCenter, tcell.ColorYellow)
+	pm.frame = frame
 
 	// Create a centered flex
 	width := 60
@@ -108,6 +135,7 @@ func NewProgressModal(pages *tview.Pages, app *tview.Application, title string,
 	innerModalFlex.AddItem(nil, 0, 1, false).
 		AddItem(frame, height, 1, true).
 		AddItem(nil, 0, 1, false)
+	pm.column = innerModalFlex
 
 	pm.modal = tview.NewFlex()
 	pm.modal.SetBackgroundColor(ColorAppBg)
@@ -234,7 +262,64 @@ func (pm *ProgressModal) updateProgressBar() {
 	}
 	bar += "[white]"
 
-	pm.progressBar.SetText(bar + " " + string(rune(percent)) + "%")
+	pm.progressBar.SetText(fmt.Sprintf("%s %d%%", bar, percent))
+}
+
+// progressResultRows is how many result lines the modal shows before
+// scrolling.
+const progressResultRows = 10
+
+// AddResult appends a per-item outcome below the bar, growing the modal on
+// the first one. Safe to call from any goroutine.
+func (pm *ProgressModal) AddResult(item string, ok bool, detail string) *ProgressModal {
+	pm.app.QueueUpdateDraw(func() {
+		if pm.resultCount == 0 {
+			pm.content.ResizeItem(pm.results, progressResultRows, 0)
+			pm.body.ResizeItem(pm.content, 3+progressResultRows, 0)
+			pm.column.ResizeItem(pm.frame, 15+progressResultRows, 1) // frame chrome takes 10 rows
+			pm.modal.ResizeItem(pm.column, 80, 1)
+		}
+		pm.resultCount++
+		mark := "[green]✓[white]"
+		if !ok {
+			mark = "[red]✗[white]"
+		}
+		line := fmt.Sprintf("%s %s", mark, tview.Escape(item))
+		if detail != "" {
+			line += "  [gray]" + tview.Escape(detail) + "[white]"
+		}
+		if pm.resultCount > 1 {
+			line = "\n" + line
+		}
+		fmt.Fprint(pm.results, line)
+		pm.results.ScrollToEnd()
+	})
+	return pm
+}
+
+// SetOnClose sets a callback for the Close button shown after Finish.
+func (pm *ProgressModal) SetOnClose(callback func()) *ProgressModal {
+	pm.onClose = callback
+	return pm
+}
+
+// Focus moves keyboard focus to the modal's button.
+func (pm *ProgressModal) Focus() {
+	pm.app.SetFocus(pm.form)
+}
+
+// Finish fills the bar, shows status and turns Cancel into Close so the
+// results stay readable. Safe to call from any goroutine.
+func (pm *ProgressModal) Finish(status string) {
+	pm.app.QueueUpdateDraw(func() {
+		pm.done = true
+		pm.progress = pm.maxProgress
+		pm.updateProgressBar()
+		pm.statusText.SetText(status)
+		if button := pm.form.GetButton(0); button != nil {
+			button.SetLabel("Close")
+		}
+	})
 }
 
 // ShowProgressModal creates, configures, and shows a progress modal in one operation.
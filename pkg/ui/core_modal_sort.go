@@ -8,15 +8,17 @@ import (
 // SortOptions defines the configuration for sort operations
 type SortOptions struct {
 	Column    string // Column to sort by
-	SortType  string // Type of sort: "alphabet", "date", "number"
+	SortType  string // One of SortTypes: "auto", "alphabet", "number", "size", "duration", "date"
 	Direction string // "asc" or "desc"
 }
 
-// ShowSortModal displays a modal with sort options and returns the selected options
+// ShowSortModal displays a modal with sort options and returns the selected options.
+// current preselects the fields; nil starts from the first column, auto, ascending.
 func ShowSortModal(
 	pages *tview.Pages,
 	app *tview.Application,
 	columns []string,
+	current *SortOptions,
 	callback func(options *SortOptions, cancelled bool),
 ) {
 	// Create form for inputs
@@ -35,28 +37,32 @@ func ShowSortModal(
 	// Default options
 	options := &SortOptions{
 		Column:    columns[0],
-		SortType:  "alphabet",
+		SortType:  SortAuto,
 		Direction: "asc",
 	}
+	if current != nil {
+		*options = *current
+	}
 
 	// Column dropdown
 	columnOptions := make([]string, len(columns))
-	for i, col := range columns {
-		columnOptions[i] = col
-	}
-	form.AddDropDown("Column", columnOptions, 0, func(option string, optionIndex int) {
+	copy(columnOptions, columns)
+	form.AddDropDown("Column", columnOptions, max(0, indexOf(columnOptions, options.Column)), func(option string, optionIndex int) {
 		options.Column = option
 	})
 
 	// Sort type dropdown
-	sortTypeOptions := []string{"alphabet", "date", "number"}
-	form.AddDropDown("Sort Type", sortTypeOptions, 0, func(option string, optionIndex int) {
+	form.AddDropDown("Sort Type", SortTypes, max(0, indexOf(SortTypes, options.SortType)), func(option string, optionIndex int) {
 		options.SortType = option
 	})
 
 	// Direction dropdown
 	directionOptions := []string{"ascending", "descending"}
-	form.AddDropDown("Direction", directionOptions, 0, func(option string, optionIndex int) {
+	direction := 0
+	if options.Direction == "desc" {
+		direction = 1
+	}
+	form.AddDropDown("Direction", directionOptions, direction, func(option string, optionIndex int) {
 		if option == "ascending" {
 			options.Direction = "asc"
 		} else {
@@ -111,3 +117,13 @@ func ShowSortModal(
 
 	app.SetFocus(form)
 }
+
+// indexOf returns the position of s in list, or -1.
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
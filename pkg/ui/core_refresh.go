@@ -127,7 +127,7 @@ func (c *CoreView) runLazyRefreshInitial(app *tview.Application) {
 		return
 	}
 	c.Log("Refreshing data...")
-	data, err := loader(0, pageSize)
+	data, more, err := loader(0, pageSize)
 	app.QueueUpdateDraw(func() {
 		c.dataMutex.Lock()
 		c.isLoading = false
@@ -137,7 +137,7 @@ func (c *CoreView) runLazyRefreshInitial(app *tview.Application) {
 			return
 		}
 		c.lazyOffset = len(data)
-		c.lazyHasMore = len(data) >= pageSize
+		c.lazyHasMore = more
 		c.rawTableData = data
 		c.tableData = c.applyFilter(data)
 		c.refreshTable()
@@ -178,8 +178,28 @@ func (c *CoreView) clearLoadingOnUI(app *tview.Application) {
 	})
 }
 
+// PagedLoader returns the rows after offset and whether more follow. A page
+// may hold fewer or more than limit rows.
+type PagedLoader func(offset, limit int) (rows [][]string, more bool, err error)
+
 // SetLazyLoader enables lazy loading with a page size and loader function.
+// A page shorter than pageSize ends the data.
 func (c *CoreView) SetLazyLoader(pageSize int, loader func(offset, limit int) ([][]string, error)) *CoreView {
+	return c.SetPagedLoader(pageSize, func(offset, limit int) ([][]string, bool, error) {
+		rows, err := loader(offset, limit)
+		return rows, len(rows) >= limit, err
+	})
+}
+
+// SetPagedLoader enables lazy loading where the loader reports whether more
+// rows exist (cursor-based sources). A nil loader turns lazy loading off.
+func (c *CoreView) SetPagedLoader(pageSize int, loader PagedLoader) *CoreView {
+	if loader == nil {
+		c.lazyLoader = nil
+		c.lazyHasMore = false
+		delete(c.keyBindings, "PgDn")
+		return c
+	}
 	if pageSize <= 0 {
 		pageSize = 500
 	}
@@ -190,6 +210,16 @@ func (c *CoreView) SetLazyLoader(pageSize int, loader func(offset, limit int) ([
 	return c
 }
 
+// SetLazyState records rows already shown (e.g. a first page set with
+// SetTableData) so LoadMore continues after them.
+func (c *CoreView) SetLazyState(offset int, more bool) *CoreView {
+	c.dataMutex.Lock()
+	c.lazyOffset = offset
+	c.lazyHasMore = more
+	c.dataMutex.Unlock()
+	return c
+}
+
 // LoadMore fetches the next page when lazy loading is enabled.
 // The loader runs in a background goroutine; UI updates run on the tview thread.
 func (c *CoreView) LoadMore() *CoreView {
@@ -220,16 +250,17 @@ func (c *CoreView) LoadMore() *CoreView {
 		return c
 	}
 
+	c.Log("Loading more…")
 	go func() {
-		data, err := loader(offset, pageSize)
+		data, more, err := loader(offset, pageSize)
 		app.QueueUpdateDraw(func() {
-			c.finalizeLoadMore(data, err)
+			c.finalizeLoadMore(data, more, err)
 		})
 	}()
 	return c
 }
 
-func (c *CoreView) finalizeLoadMore(data [][]string, err error) {
+func (c *CoreView) finalizeLoadMore(data [][]string, more bool, err error) {
 	c.dataMutex.Lock()
 	c.isLoading = false
 	if err != nil {
@@ -237,18 +268,19 @@ func (c *CoreView) finalizeLoadMore(data [][]string, err error) {
 		c.Log(fmt.Sprintf("[red]Error loading more: %v", err))
 		return
 	}
+	c.lazyHasMore = more
 	if len(data) == 0 {
-		c.lazyHasMore = false
 		c.dataMutex.Unlock()
-		c.Log("[yellow]No more rows to load")
+		if more {
+			c.Log("[yellow]No new rows in this page - PgDn to keep going")
+		} else {
+			c.Log("[yellow]No more rows to load")
+		}
 		return
 	}
 	c.rawTableData = append(c.rawTableData, data...)
 	c.tableData = c.applyFilter(c.rawTableData)
 	c.lazyOffset += len(data)
-	if len(data) < c.lazyPageSize {
-		c.lazyHasMore = false
-	}
 	c.refreshTable()
 	c.dataMutex.Unlock()
 	c.Log(fmt.Sprintf("[green]Loaded %d more rows", len(data)))
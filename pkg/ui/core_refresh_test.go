@@ -0,0 +1,32 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+)
+
+func TestPagedLoaderState(t *testing.T) {
+	c := NewCoreView(tview.NewApplication(), "test")
+	c.SetTableHeaders([]string{"key"})
+	c.SetTableData([][]string{{"a"}, {"b"}})
+	c.SetPagedLoader(2, func(offset, limit int) ([][]string, bool, error) { return nil, false, nil })
+	c.SetLazyState(2, true)
+
+	c.finalizeLoadMore(nil, true, nil)
+	if !c.lazyHasMore || c.lazyOffset != 2 {
+		t.Fatalf("after empty page: more=%v offset=%d, want true 2", c.lazyHasMore, c.lazyOffset)
+	}
+	c.finalizeLoadMore([][]string{{"c"}}, false, nil)
+	if c.lazyHasMore || c.lazyOffset != 3 || len(c.rawTableData) != 3 {
+		t.Fatalf("after last page: more=%v offset=%d rows=%d, want false 3 3", c.lazyHasMore, c.lazyOffset, len(c.rawTableData))
+	}
+
+	c.SetPagedLoader(0, nil)
+	if c.lazyLoader != nil || c.lazyHasMore {
+		t.Fatalf("SetPagedLoader(nil) left lazy loading on")
+	}
+	if _, ok := c.keyBindings["PgDn"]; ok {
+		t.Fatalf("SetPagedLoader(nil) kept the PgDn binding")
+	}
+}
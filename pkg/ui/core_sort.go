@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sort types understood by the table. SortAuto picks one from the column
+// contents (see DetectSortType).
+const (
+	SortAuto     = "auto"
+	SortAlphabet = "alphabet"
+	SortNumber   = "number"
+	SortSize     = "size"
+	SortDuration = "duration"
+	SortDate     = "date"
+)
+
+// SortTypes lists the sort types in the order the sort modal offers them.
+var SortTypes = []string{SortAuto, SortAlphabet, SortNumber, SortSize, SortDuration, SortDate}
+
+var tagPattern = regexp.MustCompile(`\[[^\[\]]*\]`)
+
+// plainCell drops tview color tags and surrounding space.
+func plainCell(s string) string {
+	return strings.TrimSpace(tagPattern.ReplaceAllString(s, ""))
+}
+
+// parseNumber reads "42", "-3.5", "1,024" or "87%".
+func parseNumber(s string) (float64, bool) {
+	s = strings.TrimSuffix(strings.ReplaceAll(s, ",", ""), "%")
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return v, err == nil && !math.IsNaN(v)
+}
+
+var sizePattern = regexp.MustCompile(`^(-?[0-9]*\.?[0-9]+)\s*([kmgtpe]?)(i?)(b?)$`)
+
+// parseSize reads byte sizes such as "512B", "12.3 MB", "1.5GiB" or "256Mi"
+// and returns bytes. xB units are decimal, xiB / Xi and bare K/M/G binary.
+func parseSize(s string) (float64, bool) {
+	m := sizePattern.FindStringSubmatch(strings.ToLower(s))
+	if m == nil {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	exp := strings.Index("kmgtpe", m[2]) + 1
+	if m[2] == "" {
+		exp = 0
+	}
+	base := 1024.0
+	if m[3] == "" && m[4] == "b" {
+		base = 1000
+	}
+	return v * math.Pow(base, float64(exp)), true
+}
+
+var durationPattern = regexp.MustCompile(`(\d+(?:\.\d+)?|\ban?\b)\s*(ns|us|µs|ms|seconds?|secs?|s|minutes?|mins?|m|hours?|hrs?|h|days?|d|weeks?|w|months?|mo|years?|y)\b`)
+
+var unitDigitPattern = regexp.MustCompile(`([a-zµ])(\d)`)
+
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond, "us": time.Microsecond, "µs": time.Microsecond, "ms": time.Millisecond,
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+	"mo": 30 * 24 * time.Hour, "month": 30 * 24 * time.Hour, "months": 30 * 24 * time.Hour,
+	"y": 365 * 24 * time.Hour, "year": 365 * 24 * time.Hour, "years": 365 * 24 * time.Hour,
+}
+
+// parseDuration reads Go durations ("1h30m"), compact ones ("3d4h") and the
+// prose forms tools print ("Up 2 hours", "about a minute ago", "5 days").
+func parseDuration(s string) (time.Duration, bool) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, true
+	}
+	s = unitDigitPattern.ReplaceAllString(strings.ToLower(s), "$1 $2") // "3d4h" → "3d 4h"
+	matches := durationPattern.FindAllStringSubmatch(s, -1)
+	if len(matches) == 0 {
+		return 0, false
+	}
+	var total time.Duration
+	for _, m := range matches {
+		n := 1.0
+		if m[1] != "a" && m[1] != "an" {
+			n, _ = strconv.ParseFloat(m[1], 64)
+		}
+		total += time.Duration(n * float64(durationUnits[m[2]]))
+	}
+	return total, true
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700 MST",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC822,
+	time.ANSIC,
+	time.UnixDate,
+	time.Stamp,
+}
+
+// parseTime reads the common timestamp layouts.
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// sortKey turns a cell into a float for typed sorts.
+func sortKey(kind, s string) (float64, bool) {
+	switch kind {
+	case SortNumber:
+		return parseNumber(s)
+	case SortSize:
+		return parseSize(s)
+	case SortDuration:
+		d, ok := parseDuration(s)
+		return float64(d), ok
+	case SortDate:
+		t, ok := parseTime(s)
+		return float64(t.UnixNano()), ok
+	}
+	return 0, false
+}
+
+// DetectSortType picks the most specific type every non-empty value parses
+// as, falling back to alphabetical.
+func DetectSortType(values []string) string {
+	for _, kind := range []string{SortNumber, SortSize, SortDuration, SortDate} {
+		seen := false
+		all := true
+		for _, v := range values {
+			v = plainCell(v)
+			if v == "" || v == "-" || v == "<none>" {
+				continue
+			}
+			seen = true
+			if _, ok := sortKey(kind, v); !ok {
+				all = false
+				break
+			}
+		}
+		if seen && all {
+			return kind
+		}
+	}
+	return SortAlphabet
+}
+
+// sortRows stably orders rows (and the raw indices that travel with them) by
+// column. Empty cells stay last in both directions. Cells are parsed once.
+func sortRows(rows [][]string, indices []int, column int, kind string, desc bool) {
+	entries := make([]sortEntry, len(rows))
+	values := make([]string, len(rows))
+	for i, row := range rows {
+		if column < len(row) {
+			values[i] = plainCell(row[column])
+		}
+	}
+	if kind == "" || kind == SortAuto {
+		kind = DetectSortType(values)
+	}
+	for i, v := range values {
+		e := sortEntry{row: rows[i], index: indices[i], text: strings.ToLower(v)}
+		if kind != SortAlphabet {
+			e.key, e.typed = sortKey(kind, v)
+		}
+		entries[i] = e
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if (a.text == "") != (b.text == "") {
+			return b.text == ""
+		}
+		c := a.compare(b)
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+	for i, e := range entries {
+		rows[i], indices[i] = e.row, e.index
+	}
+}
+
+type sortEntry struct {
+	row   []string
+	index int
+	text  string
+	key   float64
+	typed bool
+}
+
+// compare orders two parsed cells. Values that do not parse as the sort
+// type go after the ones that do, alphabetically.
+func (a sortEntry) compare(b sortEntry) int {
+	switch {
+	case a.typed && b.typed:
+		if a.key < b.key {
+			return -1
+		}
+		if a.key > b.key {
+			return 1
+		}
+		return 0
+	case a.typed:
+		return -1
+	case b.typed:
+		return 1
+	}
+	return strings.Compare(a.text, b.text)
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"512B", 512},
+		{"12.5 MB", 12.5e6},
+		{"1.5GiB", 1.5 * 1024 * 1024 * 1024},
+		{"256Mi", 256 * 1024 * 1024},
+		{"4K", 4096},
+		{"0", 0},
+	}
+	for _, tt := range tests {
+		got, ok := parseSize(tt.in)
+		if !ok || got != tt.want {
+			t.Fatalf("parseSize(%q) = %v, %v, want %v", tt.in, got, ok, tt.want)
+		}
+	}
+	if _, ok := parseSize("web-1"); ok {
+		t.Fatalf("parseSize(web-1) parsed")
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"1h30m", 90 * time.Minute},
+		{"3d4h", 76 * time.Hour},
+		{"Up 2 hours (healthy)", 2 * time.Hour},
+		{"About a minute ago", time.Minute},
+		{"5 days", 120 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, ok := parseDuration(tt.in)
+		if !ok || got != tt.want {
+			t.Fatalf("parseDuration(%q) = %v, %v, want %v", tt.in, got, ok, tt.want)
+		}
+	}
+}
+
+func TestDetectSortType(t *testing.T) {
+	tests := []struct {
+		values []string
+		want   string
+	}{
+		{[]string{"10", "9", "", "[green]100[white]"}, SortNumber},
+		{[]string{"1.2 MB", "900kB", "3GB"}, SortSize},
+		{[]string{"5m", "3h", "2d"}, SortDuration},
+		{[]string{"2024-05-01 10:00:00", "2023-12-31T08:00:00Z"}, SortDate},
+		{[]string{"redis", "10"}, SortAlphabet},
+	}
+	for _, tt := range tests {
+		if got := DetectSortType(tt.values); got != tt.want {
+			t.Fatalf("DetectSortType(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestSortRows(t *testing.T) {
+	rows := [][]string{{"a", "1.5 GB"}, {"b", ""}, {"c", "900 MB"}, {"d", "12 kB"}}
+	indices := []int{0, 1, 2, 3}
+	sortRows(rows, indices, 1, SortAuto, true)
+	if want := []int{0, 2, 3, 1}; !reflect.DeepEqual(indices, want) {
+		t.Fatalf("desc indices = %v, want %v", indices, want)
+	}
+	sortRows(rows, indices, 1, SortSize, false)
+	if want := []int{3, 2, 0, 1}; !reflect.DeepEqual(indices, want) {
+		t.Fatalf("asc indices = %v, want %v (empty last)", indices, want)
+	}
+}
@@ -94,7 +94,13 @@ func (c *CoreView) refreshTable() {
 
 	// Update virtual table content
 	c.tableContent.SetHeaders(c.tableHeaders)
+	c.tableContent.SetLayout(c.layout, c.layout.columnOrder(c.tableHeaders))
 	c.tableContent.SetData(c.tableData)
+	if c.layout.PinFirst {
+		c.table.SetFixed(1, 1)
+	} else {
+		c.table.SetFixed(1, 0)
+	}
 
 	// Try to restore selection by matching signature
 	restored := false
@@ -130,6 +136,7 @@ func (c *CoreView) refreshTable() {
 //   - The CoreView instance for method chaining
 func (c *CoreView) SetTableHeaders(headers []string) *CoreView {
 	c.tableHeaders = headers
+	c.layout = c.layout.matchHeaders(headers)
 	c.refreshTable()
 	return c
 }
@@ -147,6 +154,7 @@ func (c *CoreView) SetTableData(data [][]string) *CoreView {
 	defer c.dataMutex.Unlock()
 	c.rawTableData = data
 	c.tableData = c.applyFilter(data)
+	c.pruneMarks()
 	c.refreshTable()
 	return c
 }
@@ -178,12 +186,11 @@ func (c *CoreView) SetFilterQuery(query string) *CoreView {
 	c.tableData = c.applyFilter(c.rawTableData)
 	c.refreshTable()
 	afterCount := len(c.tableData)
+	c.updateTableTitle()
 	if c.filterQuery == "" {
 		c.Log("[yellow]Filter cleared")
-		c.table.SetTitle(fmt.Sprintf(" [%s]%s[%s] ", HexBorder, c.title, HexValue))
 	} else {
 		c.Log(fmt.Sprintf("[green]Filter '%s': %d/%d rows", c.filterQuery, afterCount, beforeCount))
-		c.table.SetTitle(fmt.Sprintf(" [%s]%s[%s] [%s](filter: %s)[%s] ", HexBorder, c.title, HexValue, HexLabel, c.filterQuery, HexValue))
 		if afterCount == 0 && c.lazyHasMore {
 			c.Log("[gray]More data available - use PgDn to load more")
 		}
@@ -193,8 +200,9 @@ func (c *CoreView) SetFilterQuery(query string) *CoreView {
 
 func (c *CoreView) applyFilter(data [][]string) [][]string {
 	if c.filterQuery == "" {
-		c.filteredIndices = nil
-		return data
+		rows, indices := c.sortView(data, nil)
+		c.filteredIndices = indices
+		return rows
 	}
 	query := strings.ToLower(c.filterQuery)
 	filtered := make([][]string, 0, len(data))
@@ -208,6 +216,7 @@ func (c *CoreView) applyFilter(data [][]string) [][]string {
 			}
 		}
 	}
+	filtered, c.filteredIndices = c.sortView(filtered, c.filteredIndices)
 	return filtered
 }
 
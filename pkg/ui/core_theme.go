@@ -35,6 +35,10 @@ func (c *CoreView) ApplyTheme() {
 	if c.contentPages != nil {
 		c.contentPages.SetBackgroundColor(ColorAppBg)
 	}
+	if c.detailPane != nil {
+		c.tableRow.SetBackgroundColor(ColorAppBg)
+		c.styleDetailPane()
+	}
 	if c.mainLayout != nil {
 		c.mainLayout.SetBackgroundColor(ColorAppBg)
 	}
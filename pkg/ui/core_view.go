@@ -61,11 +61,20 @@ type CoreView struct {
 	activeViewID   string
 	keyBindings    map[string]string // right: action / global keys
 	keyHandlers    map[string]func()
+	chord          KeyChord
+
+	// Rows marked for batch actions, by row signature.
+	marked map[string]bool
 
 	// Table view
 	table        *Table
 	tableContent *VirtualTableContent
 
+	// Detail pane right of the table (table + pane share tableRow).
+	tableRow      *tview.Flex
+	detailPane    *tview.TextView
+	detailVisible bool
+
 	// Table data
 	tableHeaders    []string
 	tableData       [][]string
@@ -73,7 +82,9 @@ type CoreView struct {
 	selectionKey    string
 	selectedRow     int
 	filterQuery     string
-	filteredIndices []int
+	filteredIndices []int // raw index of each shown row when filtered or sorted
+	layout          TableLayout
+	onLayout        func(TableLayout)
 
 	// Data refresh management
 	refreshMutex  sync.Mutex
@@ -94,7 +105,7 @@ type CoreView struct {
 	navStack []string
 
 	// Lazy loading
-	lazyLoader   func(offset, limit int) ([][]string, error)
+	lazyLoader   PagedLoader
 	lazyPageSize int
 	lazyOffset   int
 	lazyHasMore  bool
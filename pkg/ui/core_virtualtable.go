@@ -15,6 +15,15 @@ type VirtualTableContent struct {
 	headers  []string
 	data     [][]string
 	selected int
+	marked   func(dataRow int) bool
+
+	// Column layout: shown columns as header indices, width caps by header,
+	// sort indicator and header clicks.
+	columns    []int
+	maxWidth   map[string]int
+	sortColumn string
+	sortDesc   bool
+	onHeader   func(header string)
 }
 
 // NewVirtualTableContent creates a new virtual table content.
@@ -26,9 +35,39 @@ func NewVirtualTableContent() *VirtualTableContent {
 	}
 }
 
-// SetHeaders sets the table headers.
+// SetHeaders sets the table headers and shows all of them in order.
 func (v *VirtualTableContent) SetHeaders(headers []string) {
 	v.headers = headers
+	v.columns = nil
+}
+
+// SetLayout shows the given header indices in order, with the layout's
+// width caps and sort indicator.
+func (v *VirtualTableContent) SetLayout(l TableLayout, columns []int) {
+	v.columns = columns
+	v.maxWidth = l.MaxWidth
+	v.sortColumn = l.SortBy
+	v.sortDesc = l.SortDesc
+}
+
+// SetHeaderClickedFunc is called with the header name when a header cell is
+// clicked.
+func (v *VirtualTableContent) SetHeaderClickedFunc(fn func(header string)) {
+	v.onHeader = fn
+}
+
+// dataColumn maps a shown column to its header index, or -1.
+func (v *VirtualTableContent) dataColumn(column int) int {
+	if v.columns == nil {
+		if column < 0 || column >= len(v.headers) {
+			return -1
+		}
+		return column
+	}
+	if column < 0 || column >= len(v.columns) || v.columns[column] >= len(v.headers) {
+		return -1
+	}
+	return v.columns[column]
 }
 
 // SetData sets the table data.
@@ -41,20 +80,45 @@ func (v *VirtualTableContent) SetSelected(row int) {
 	v.selected = row
 }
 
+// SetMarkedFunc reports which data rows are marked for batch actions.
+func (v *VirtualTableContent) SetMarkedFunc(marked func(dataRow int) bool) {
+	v.marked = marked
+}
+
 // GetCell returns the cell at the given position.
 func (v *VirtualTableContent) GetCell(row, column int) *tview.TableCell {
-	if column < 0 || column >= len(v.headers) {
+	shown := column
+	column = v.dataColumn(shown)
+	if column < 0 {
 		return nil
 	}
+	header := v.headers[column]
+	maxWidth := v.maxWidth[header]
 
-	// Header row — warm gold column names.
+	// Header row — warm gold column names, ▲/▼ on the sort column.
 	if row == 0 {
-		return tview.NewTableCell(strings.ToUpper(v.headers[column])).
+		text := strings.ToUpper(header)
+		if header == v.sortColumn {
+			if v.sortDesc {
+				text += " ▼"
+			} else {
+				text += " ▲"
+			}
+		}
+		cell := tview.NewTableCell(text).
 			SetTextColor(ColorBorder).
 			SetBackgroundColor(ColorAppBg).
 			SetAttributes(tcell.AttrBold).
 			SetSelectable(false).
-			SetExpansion(v.getExpansion(column))
+			SetMaxWidth(maxWidth).
+			SetExpansion(v.getExpansion(shown))
+		if v.onHeader != nil {
+			cell.SetClickedFunc(func() bool {
+				v.onHeader(header)
+				return true
+			})
+		}
+		return cell
 	}
 
 	// Data rows
@@ -68,17 +132,24 @@ func (v *VirtualTableContent) GetCell(row, column int) *tview.TableCell {
 		return tview.NewTableCell("").
 			SetBackgroundColor(ColorAppBg).
 			SetSelectable(true).
-			SetExpansion(v.getExpansion(column))
+			SetExpansion(v.getExpansion(shown))
 	}
 
-	text := rowData[column]
+	text := SlotColors(rowData[column])
+	marked := v.marked != nil && v.marked(dataRow)
+	if marked && shown == 0 {
+		text = markGlyph + text
+	}
 	cell := tview.NewTableCell(text).
 		SetSelectable(true).
 		SetAlign(tview.AlignLeft).
 		SetBackgroundColor(ColorAppBg).
-		SetExpansion(v.getExpansion(column))
+		SetMaxWidth(maxWidth).
+		SetExpansion(v.getExpansion(shown))
 
-	if !strings.HasPrefix(text, "[") || !strings.Contains(text, "]") {
+	if marked {
+		cell.SetTextColor(tcell.GetColor(HexActionKey))
+	} else if !strings.HasPrefix(text, "[") || !strings.Contains(text, "]") {
 		cell.SetTextColor(ColorTableRow)
 	}
 
@@ -97,8 +168,11 @@ func (v *VirtualTableContent) GetRowCount() int {
 	return len(v.data) + 1 // +1 for header
 }
 
-// GetColumnCount returns the number of columns.
+// GetColumnCount returns the number of shown columns.
 func (v *VirtualTableContent) GetColumnCount() int {
+	if v.columns != nil {
+		return len(v.columns)
+	}
 	return len(v.headers)
 }
 
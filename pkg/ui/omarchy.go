@@ -57,54 +57,54 @@ func builtinOmarchyThemes() []Theme {
 }
 
 func parseOmarchyColors(src string) OmarchyColors {
-	var c OmarchyColors
+	return omarchyFromKeys(parseThemeKeys(src))
+}
+
+// parseThemeKeys reads the flat `key = "value"` lines of a colors.toml.
+// Comments and [section] headers are skipped.
+func parseThemeKeys(src string) map[string]string {
+	out := map[string]string{}
 	for _, line := range strings.Split(src, "\n") {
 		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "#") {
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") {
 			continue
 		}
 		key, val, ok := strings.Cut(line, "=")
 		if !ok {
 			continue
 		}
-		key = strings.TrimSpace(key)
-		val = strings.Trim(strings.TrimSpace(val), `"'`)
-		switch key {
-		case "accent":
-			c.Accent = val
-		case "cursor":
-			c.Cursor = val
-		case "foreground":
-			c.Foreground = val
-		case "background":
-			c.Background = val
-		case "selection_foreground":
-			c.SelectionForeground = val
-		case "selection_background":
-			c.SelectionBackground = val
-		case "color0":
-			c.Color0 = val
-		case "color1":
-			c.Color1 = val
-		case "color2":
-			c.Color2 = val
-		case "color3":
-			c.Color3 = val
-		case "color4":
-			c.Color4 = val
-		case "color5":
-			c.Color5 = val
-		case "color6":
-			c.Color6 = val
-		case "color7":
-			c.Color7 = val
-		case "color8":
-			c.Color8 = val
-		case "color15":
-			c.Color15 = val
+		val = strings.TrimSpace(val)
+		if q := val[:min(1, len(val))]; q == `"` || q == "'" {
+			if end := strings.Index(val[1:], q); end >= 0 {
+				val = val[1 : end+1]
+			}
+		} else {
+			val, _, _ = strings.Cut(val, " #") // trailing comment; bare #hex stays
 		}
+		out[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(val), `"'`)
+	}
+	return out
+}
+
+func omarchyFromKeys(k map[string]string) OmarchyColors {
+	return OmarchyColors{
+		Accent:              k["accent"],
+		Cursor:              k["cursor"],
+		Foreground:          k["foreground"],
+		Background:          k["background"],
+		SelectionForeground: k["selection_foreground"],
+		SelectionBackground: k["selection_background"],
+		Color0:              k["color0"],
+		Color1:              k["color1"],
+		Color2:              k["color2"],
+		Color3:              k["color3"],
+		Color4:              k["color4"],
+		Color5:              k["color5"],
+		Color6:              k["color6"],
+		Color7:              k["color7"],
+		Color8:              k["color8"],
+		Color15:             k["color15"],
 	}
-	return c
 }
 
 // PaletteFromOmarchy maps an Omarchy terminal scheme onto OMO chrome roles.
@@ -129,6 +129,10 @@ func PaletteFromOmarchy(c OmarchyColors) Palette {
 		Label:         label,
 		InfoKey:       firstHex(c.Color3, accent),
 		Value:         firstHex(c.Color15, fg),
+		OK:            firstHex(c.Color2, defaultPalette.OK),
+		Warn:          firstHex(c.Color3, defaultPalette.Warn),
+		Error:         firstHex(c.Color1, defaultPalette.Error),
+		Muted:         firstHex(c.Color8, defaultPalette.Muted),
 	}
 }
 
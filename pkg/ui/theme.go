@@ -25,6 +25,12 @@ type Palette struct {
 	Label         string
 	InfoKey       string
 	Value         string
+
+	// Semantic slots plugins use as tags ("[ok]up[-]") instead of raw colors.
+	OK    string
+	Warn  string
+	Error string
+	Muted string
 }
 
 // Theme is a named palette the user can apply from the Themes picker.
@@ -48,6 +54,10 @@ var defaultPalette = Palette{
 	Label:         "#c4b8a8",
 	InfoKey:       "#e09201",
 	Value:         "#f5efe6",
+	OK:            "#9ccf6a",
+	Warn:          "#e8b86d",
+	Error:         "#e0665a",
+	Muted:         "#8a7f72",
 }
 
 // ActiveThemeID is the persisted theme id (omo, omarchy, tokyo-night, …).
@@ -74,6 +84,11 @@ func ApplyPalette(p Palette) {
 	HexLabel = p.Label
 	HexInfoKey = p.InfoKey
 	HexValue = p.Value
+	HexOK = p.OK
+	HexWarn = p.Warn
+	HexError = p.Error
+	HexMuted = p.Muted
+	slotTags = newSlotTags(p)
 	ColorAppBg = tcell.GetColor(HexAppBg)
 	ColorTableRow = tcell.GetColor(HexRow)
 	ColorHighlight = tcell.GetColor(HexHighlight)
@@ -99,6 +114,10 @@ func (p Palette) normalized() Palette {
 	p.Label = fill(p.Label, defaultPalette.Label)
 	p.InfoKey = fill(p.InfoKey, defaultPalette.InfoKey)
 	p.Value = fill(p.Value, defaultPalette.Value)
+	p.OK = fill(p.OK, defaultPalette.OK)
+	p.Warn = fill(p.Warn, defaultPalette.Warn)
+	p.Error = fill(p.Error, defaultPalette.Error)
+	p.Muted = fill(p.Muted, defaultPalette.Muted)
 	return p
 }
 
@@ -107,6 +126,9 @@ func ensureHash(v string) string {
 	if v == "" {
 		return v
 	}
+	if _, named := tcell.ColorNames[strings.ToLower(v)]; named {
+		return strings.ToLower(v)
+	}
 	if !strings.HasPrefix(v, "#") {
 		return "#" + v
 	}
@@ -154,7 +176,8 @@ func LoadSavedTheme() string {
 	return activeThemeID
 }
 
-// ApplyNamedTheme looks up id (omo or a bundled Omarchy theme) and applies it.
+// ApplyNamedTheme looks up id (omo, a user theme or a bundled Omarchy theme)
+// and applies it.
 func ApplyNamedTheme(id string) {
 	id = strings.TrimSpace(strings.ToLower(id))
 	if id == "" || id == "omarchy" {
@@ -183,10 +206,71 @@ func lookupTheme(id string) (Theme, bool) {
 	return Theme{}, false
 }
 
-// ListThemes returns Omo plus every bundled Omarchy palette.
+// ListThemes returns Omo, the user themes in ~/.omo/themes and every bundled
+// Omarchy palette. A user theme named like a bundled one replaces it.
 func ListThemes() []Theme {
 	out := []Theme{
 		{ID: ThemeOmo, Name: "Omo", Source: "built-in", Palette: defaultPalette},
 	}
-	return append(out, builtinOmarchyThemes()...)
+	user, _ := LoadUserThemes()
+	out = append(out, user...)
+	for _, t := range builtinOmarchyThemes() {
+		if !hasTheme(user, t.ID) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func hasTheme(list []Theme, id string) bool {
+	for _, t := range list {
+		if t.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Semantic color slot names. Plugins write them in the foreground position
+// of a tview color tag ("[ok]running[-]", "[error::b]down"); the host swaps
+// in the active palette's color when it paints.
+const (
+	SlotOK    = "ok"
+	SlotWarn  = "warn"
+	SlotError = "error"
+	SlotMuted = "muted"
+)
+
+var slotTags = newSlotTags(defaultPalette)
+
+func newSlotTags(p Palette) *strings.Replacer {
+	var pairs []string
+	for _, s := range [][2]string{{SlotOK, p.OK}, {SlotWarn, p.Warn}, {SlotError, p.Error}, {SlotMuted, p.Muted}} {
+		pairs = append(pairs, "["+s[0]+"]", "["+s[1]+"]", "["+s[0]+":", "["+s[1]+":")
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// SlotColors replaces semantic slot tags in s with the active palette's colors.
+func SlotColors(s string) string {
+	if !strings.Contains(s, "[") {
+		return s
+	}
+	return slotTags.Replace(s)
+}
+
+// SlotHex returns the active color of a slot name, or name itself when it
+// is not a slot (a hex value or a color name).
+func SlotHex(name string) string {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case SlotOK:
+		return HexOK
+	case SlotWarn:
+		return HexWarn
+	case SlotError:
+		return HexError
+	case SlotMuted:
+		return HexMuted
+	}
+	return ensureHash(name)
 }
@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -95,3 +97,77 @@ func ids(list []Theme) []string {
 	}
 	return out
 }
+
+func TestParseUserTheme(t *testing.T) {
+	src := `
+name = "Night Shift"
+background = "#101010"
+accent = "7aa2f7"
+color2 = "#00ff00"
+
+[omo]
+error = "#ff0000"   # slot override
+muted = "gray"
+`
+	th, err := parseUserTheme("night", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := th.Palette
+	if th.Name != "Night Shift" || th.Source != "user" {
+		t.Fatalf("theme = %+v", th)
+	}
+	if p.AppBg != "#101010" || p.Border != "#7aa2f7" || p.OK != "#00ff00" || p.Error != "#ff0000" || p.Muted != "gray" {
+		t.Fatalf("palette = %+v", p)
+	}
+	if p.Warn != defaultPalette.Warn {
+		t.Fatalf("warn = %s, want default", p.Warn)
+	}
+
+	if _, err := parseUserTheme("bad", `accent = "#12345"`); err == nil {
+		t.Fatal("short hex accepted")
+	}
+	if _, err := parseUserTheme(ThemeOmo, `accent = "#123456"`); err == nil {
+		t.Fatal("reserved id accepted")
+	}
+}
+
+func TestUserThemesReplaceBuiltins(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".omo", "themes")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, "nord.toml"), []byte(`background = "#000000"`), 0o644)
+	os.WriteFile(filepath.Join(dir, "broken.toml"), []byte(`accent = "nope"`), 0o644)
+
+	themes, errs := LoadUserThemes()
+	if len(themes) != 1 || len(errs) != 1 {
+		t.Fatalf("themes = %v, errs = %v", ids(themes), errs)
+	}
+	count := 0
+	for _, th := range ListThemes() {
+		if th.ID == "nord" {
+			count++
+			if th.Source != "user" || th.Palette.AppBg != "#000000" {
+				t.Fatalf("nord = %+v, want the user theme", th)
+			}
+		}
+	}
+	if count != 1 {
+		t.Fatalf("nord listed %d times", count)
+	}
+}
+
+func TestSlotColors(t *testing.T) {
+	ApplyNamedTheme(ThemeOmo)
+	got := SlotColors("[ok]up[-] [error::b]down[-] [okay] [white]x")
+	want := "[" + HexOK + "]up[-] [" + HexError + "::b]down[-] [okay] [white]x"
+	if got != want {
+		t.Fatalf("SlotColors = %q, want %q", got, want)
+	}
+	if SlotHex("warn") != HexWarn || SlotHex("ff0000") != "#ff0000" || SlotHex("red") != "red" {
+		t.Fatalf("SlotHex: %s %s %s", SlotHex("warn"), SlotHex("ff0000"), SlotHex("red"))
+	}
+}
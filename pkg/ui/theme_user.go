@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// User themes live in ~/.omo/themes/<id>.toml. They use the Omarchy
+// colors.toml keys and may set any OMO role or semantic slot directly:
+//
+//	name = "Night Shift"
+//	background = "#1a1b26"
+//	foreground = "#c0caf5"
+//	accent = "#7aa2f7"
+//	color1 = "#f7768e"
+//	error = "#ff5555"      # overrides the slot derived from color1
+//	highlight = "#283457"
+var userThemeKeys = map[string]func(*Palette, string){
+	"app_bg":         func(p *Palette, v string) { p.AppBg = v },
+	"row":            func(p *Palette, v string) { p.Row = v },
+	"highlight":      func(p *Palette, v string) { p.Highlight = v },
+	"highlight_text": func(p *Palette, v string) { p.HighlightText = v },
+	"border":         func(p *Palette, v string) { p.Border = v },
+	"view_key":       func(p *Palette, v string) { p.ViewKey = v },
+	"action_key":     func(p *Palette, v string) { p.ActionKey = v },
+	"label":          func(p *Palette, v string) { p.Label = v },
+	"info_key":       func(p *Palette, v string) { p.InfoKey = v },
+	"value":          func(p *Palette, v string) { p.Value = v },
+	SlotOK:           func(p *Palette, v string) { p.OK = v },
+	SlotWarn:         func(p *Palette, v string) { p.Warn = v },
+	SlotError:        func(p *Palette, v string) { p.Error = v },
+	SlotMuted:        func(p *Palette, v string) { p.Muted = v },
+}
+
+var hexColor = regexp.MustCompile(`^#?[0-9a-fA-F]{6}$`)
+
+// UserThemesDir returns ~/.omo/themes.
+func UserThemesDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".omo", "themes")
+}
+
+// LoadUserThemes reads every ~/.omo/themes/*.toml. Files that fail to parse
+// are left out and reported in the returned errors.
+func LoadUserThemes() ([]Theme, []error) {
+	dir := UserThemesDir()
+	if dir == "" {
+		return nil, nil
+	}
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.toml"))
+	var out []Theme
+	var errs []error
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		id := strings.ToLower(strings.TrimSuffix(filepath.Base(path), ".toml"))
+		theme, err := parseUserTheme(id, string(b))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		out = append(out, theme)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, errs
+}
+
+// parseUserTheme builds a theme from a user colors file. Every color must be
+// #rrggbb or a color name tcell knows.
+func parseUserTheme(id, src string) (Theme, error) {
+	keys := parseThemeKeys(src)
+	if id == ThemeOmo || id == "omarchy" {
+		return Theme{}, fmt.Errorf("theme id %q is reserved", id)
+	}
+	for k, v := range keys {
+		if k == "name" {
+			continue
+		}
+		if !validColor(v) {
+			return Theme{}, fmt.Errorf("%s: invalid color %q", k, v)
+		}
+	}
+	palette := PaletteFromOmarchy(omarchyFromKeys(keys))
+	for k, v := range keys {
+		if set, ok := userThemeKeys[k]; ok {
+			set(&palette, ensureHash(v))
+		}
+	}
+	name := keys["name"]
+	if name == "" {
+		name = prettyThemeName(id)
+	}
+	return Theme{ID: id, Name: name, Source: "user", Palette: palette.normalized()}, nil
+}
+
+func validColor(v string) bool {
+	if hexColor.MatchString(v) {
+		return true
+	}
+	_, ok := tcell.ColorNames[strings.ToLower(v)]
+	return ok
+}
@@ -217,11 +217,20 @@ func (s *Service) DoAction(req pluginrpc.ActionRequest) (pluginrpc.ActionResult,
 		if err != nil {
 			return pluginrpc.ActionResult{OK: false, Message: err.Error()}, nil
 		}
-		return pluginrpc.ActionResult{
+		res := pluginrpc.ActionResult{
 			OK:         true,
 			ModalTitle: "Token for " + name,
 			ModalBody:  fmt.Sprintf("Token: %s\nIssued: %s\nExpires: %s", tok.Token, tok.FormatIssuedAt(), tok.FormatExpiresAt()),
-		}, nil
+			Credential: &pluginrpc.IssuedCredential{
+				Name:       name + "-token",
+				UserName:   name,
+				Attributes: map[string]string{"auth_token": tok.Token},
+			},
+		}
+		if tok.ExpiresAt > 0 {
+			res.Credential.ExpiresAt = time.Unix(tok.ExpiresAt, 0)
+		}
+		return res, nil
 
 	case "create_project":
 		name := req.Payload["name"]
@@ -0,0 +1,143 @@
+package docker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"omo/pkg/pluginrpc"
+)
+
+// Inspect results as structured documents for the host detail pane / modal.
+
+func containerDetail(info *ContainerInspectInfo) pluginrpc.Detail {
+	d := pluginrpc.Detail{
+		Title: info.Name,
+		Sections: []pluginrpc.DetailSection{{
+			Fields: pluginrpc.Fields(
+				"ID", shortID(info.ID),
+				"Image", info.Image,
+				"State", info.State,
+				"Status", info.Status,
+				"Created", info.Created,
+				"Platform", info.Platform,
+				"Restarts", fmt.Sprint(info.RestartCount),
+			),
+		}},
+	}
+	d.Sections = append(d.Sections,
+		listSection("Ports", info.Ports),
+		listSection("Networks", info.Networks),
+		listSection("Mounts", info.Mounts),
+	)
+	if len(info.Env) > 0 {
+		d.Sections = append(d.Sections, pluginrpc.DetailSection{
+			Title: "Environment",
+			Code:  &pluginrpc.DetailCode{Lang: "sh", Body: strings.Join(info.Env, "\n")},
+		})
+	}
+	return d
+}
+
+func imageDetail(info *ImageInspectInfo) pluginrpc.Detail {
+	title := shortID(strings.TrimPrefix(info.ID, "sha256:"))
+	if len(info.RepoTags) > 0 {
+		title = info.RepoTags[0]
+	}
+	d := pluginrpc.Detail{
+		Title: title,
+		Sections: []pluginrpc.DetailSection{{
+			Fields: pluginrpc.Fields(
+				"ID", info.ID,
+				"Size", info.Size,
+				"Created", info.Created,
+				"Platform", strings.Trim(info.OS+"/"+info.Architecture, "/"),
+				"Docker", info.DockerVersion,
+			),
+		}},
+	}
+	d.Sections = append(d.Sections,
+		listSection("Tags", info.RepoTags),
+		listSection("Digests", info.RepoDigests),
+		listSection("Exposed ports", info.ExposedPorts),
+	)
+	if len(info.Env) > 0 {
+		d.Sections = append(d.Sections, pluginrpc.DetailSection{
+			Title: "Environment",
+			Code:  &pluginrpc.DetailCode{Lang: "sh", Body: strings.Join(info.Env, "\n")},
+		})
+	}
+	return d
+}
+
+func networkDetail(info *NetworkInspectInfo) pluginrpc.Detail {
+	return pluginrpc.Detail{
+		Title: info.Name,
+		Sections: []pluginrpc.DetailSection{
+			{Fields: pluginrpc.Fields(
+				"ID", shortID(info.ID),
+				"Driver", info.Driver,
+				"Scope", info.Scope,
+				"Subnet", info.Subnet,
+				"Gateway", info.Gateway,
+				"Internal", fmt.Sprint(info.Internal),
+				"Attachable", fmt.Sprint(info.Attachable),
+				"IPv6", fmt.Sprint(info.EnableIPv6),
+			)},
+			listSection("Containers", info.Containers),
+			mapSection("Labels", info.Labels),
+		},
+	}
+}
+
+func volumeDetail(info *VolumeInspectInfo) pluginrpc.Detail {
+	fields := pluginrpc.Fields(
+		"Driver", info.Driver,
+		"Mountpoint", info.Mountpoint,
+		"Scope", info.Scope,
+		"Created", info.CreatedAt,
+	)
+	if info.UsageData != nil {
+		fields = append(fields, pluginrpc.Fields(
+			"Size", info.UsageData.Size,
+			"References", fmt.Sprint(info.UsageData.RefCount),
+		)...)
+	}
+	return pluginrpc.Detail{
+		Title: info.Name,
+		Sections: []pluginrpc.DetailSection{
+			{Fields: fields},
+			mapSection("Labels", info.Labels),
+			mapSection("Options", info.Options),
+		},
+	}
+}
+
+// listSection shows one value per line, or "(none)".
+func listSection(title string, items []string) pluginrpc.DetailSection {
+	if len(items) == 0 {
+		return pluginrpc.DetailSection{Title: title, Text: "(none)"}
+	}
+	return pluginrpc.DetailSection{Title: title, Text: strings.Join(items, "\n")}
+}
+
+// mapSection shows a map as a sorted key/value table.
+func mapSection(title string, m map[string]string) pluginrpc.DetailSection {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	rows := make([][]string, len(keys))
+	for i, k := range keys {
+		rows[i] = []string{k, m[k]}
+	}
+	return pluginrpc.DetailSection{Title: title, Table: &pluginrpc.DetailTable{Headers: []string{"Key", "Value"}, Rows: rows}}
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
@@ -222,14 +222,14 @@ func (s *Service) DoAction(req pluginrpc.ActionRequest) (pluginrpc.ActionResult,
 		return pluginrpc.ActionResult{OK: true, Message: "logs " + id, Next: &view}, nil
 
 	case "inspect":
-		if id == "" {
+		if id == "" || id == "-" {
 			return pluginrpc.ActionResult{OK: false, Message: "no selection"}, nil
 		}
-		body, title, err := s.inspectLocked(id)
+		detail, title, err := s.inspectLocked(id)
 		if err != nil {
 			return pluginrpc.ActionResult{OK: false, Message: err.Error()}, nil
 		}
-		return pluginrpc.ActionResult{OK: true, ModalTitle: title, ModalBody: body}, nil
+		return pluginrpc.ActionResult{OK: true, ModalTitle: title, Detail: &detail}, nil
 
 	case "history":
 		if id == "" {
@@ -362,31 +362,31 @@ func (s *Service) Stop() error {
 	return nil
 }
 
-func (s *Service) inspectLocked(id string) (body, title string, err error) {
+func (s *Service) inspectLocked(id string) (detail pluginrpc.Detail, title string, err error) {
 	switch s.currentView {
 	case viewImages:
 		info, e := s.client.InspectImage(id)
 		if e != nil {
-			return "", "", e
+			return detail, "", e
 		}
-		return fmt.Sprintf("%+v", info), "Image: " + id, nil
+		return imageDetail(info), "Image: " + id, nil
 	case viewNetworks:
 		info, e := s.client.InspectNetwork(id)
 		if e != nil {
-			return "", "", e
+			return detail, "", e
 		}
-		return fmt.Sprintf("%+v", info), "Network: " + id, nil
+		return networkDetail(info), "Network: " + id, nil
 	case viewVolumes:
 		info, e := s.client.InspectVolume(id)
 		if e != nil {
-			return "", "", e
+			return detail, "", e
 		}
-		return fmt.Sprintf("%+v", info), "Volume: " + id, nil
+		return volumeDetail(info), "Volume: " + id, nil
 	default:
 		info, e := s.client.InspectContainer(id)
 		if e != nil {
-			return "", "", e
+			return detail, "", e
 		}
-		return fmt.Sprintf("%+v", info), "Container: " + id, nil
+		return containerDetail(info), "Container: " + id, nil
 	}
 }
@@ -21,21 +21,21 @@ func viewNavBindings() []pluginrpc.KeyBinding {
 
 func containersActions() []pluginrpc.KeyBinding {
 	return []pluginrpc.KeyBinding{
-		{Key: "S", Label: "Start", Action: "start"},
-		{Key: "X", Label: "Stop", Action: "stop"},
-		{Key: "D", Label: "Delete", Action: "delete"},
+		{Key: "S", Label: "Start", Action: "start", Batch: true},
+		{Key: "X", Label: "Stop", Action: "stop", Batch: true},
+		{Key: "D", Label: "Delete", Action: "delete", Batch: true},
 		{Key: "L", Label: "Logs", Action: "logs"},
 		{Key: "E", Label: "Inspect", Action: "inspect"},
-		{Key: "P", Label: "Pause", Action: "pause"},
-		{Key: "U", Label: "Unpause", Action: "unpause"},
-		{Key: "K", Label: "Kill", Action: "kill"},
-		{Key: "Z", Label: "Restart", Action: "restart"},
+		{Key: "P", Label: "Pause", Action: "pause", Batch: true},
+		{Key: "U", Label: "Unpause", Action: "unpause", Batch: true},
+		{Key: "K", Label: "Kill", Action: "kill", Batch: true},
+		{Key: "Z", Label: "Restart", Action: "restart", Batch: true},
 	}
 }
 
 func imagesActions() []pluginrpc.KeyBinding {
 	return []pluginrpc.KeyBinding{
-		{Key: "D", Label: "Delete", Action: "delete"},
+		{Key: "D", Label: "Delete", Action: "delete", Batch: true},
 		{Key: "P", Label: "Pull", Action: "pull"},
 		{Key: "H", Label: "History", Action: "history"},
 		{Key: "U", Label: "Run", Action: "run"},
@@ -45,14 +45,14 @@ func imagesActions() []pluginrpc.KeyBinding {
 
 func networksActions() []pluginrpc.KeyBinding {
 	return []pluginrpc.KeyBinding{
-		{Key: "D", Label: "Delete", Action: "delete"},
+		{Key: "D", Label: "Delete", Action: "delete", Batch: true},
 		{Key: "E", Label: "Inspect", Action: "inspect"},
 	}
 }
 
 func volumesActions() []pluginrpc.KeyBinding {
 	return []pluginrpc.KeyBinding{
-		{Key: "D", Label: "Delete", Action: "delete"},
+		{Key: "D", Label: "Delete", Action: "delete", Batch: true},
 		{Key: "P", Label: "Prune", Action: "prune"},
 		{Key: "E", Label: "Inspect", Action: "inspect"},
 	}
@@ -181,7 +181,7 @@ func (s *Service) viewContainersLocked() (pluginrpc.ViewData, error) {
 		return pluginrpc.ViewData{}, err
 	}
 	rows := pluginrpc.EnsureRows(pluginrpc.MapRows(list, func(c DockerContainer) []string { return c.GetTableRow() }), []string{"-", "-", "-", "-", "No containers", "-"})
-	return ui.Connected(viewContainers, "Docker Containers", s.baseInfo(fmt.Sprintf("Containers: %d", len(list))), []string{"ID", "Name", "Image", "State", "Status", "Ports"}, rows, "ID", containersActions()...), nil
+	return pluginrpc.WithDetail(ui.Connected(viewContainers, "Docker Containers", s.baseInfo(fmt.Sprintf("Containers: %d", len(list))), []string{"ID", "Name", "Image", "State", "Status", "Ports"}, rows, "ID", containersActions()...), "inspect"), nil
 }
 
 func (s *Service) viewDashboardLocked() (pluginrpc.ViewData, error) {
@@ -196,12 +196,16 @@ func (s *Service) viewDashboardLocked() (pluginrpc.ViewData, error) {
 	if host == "" {
 		host = "local"
 	}
-	return pluginrpc.Widget("Docker", "connected", host, [][2]string{
+	widget := pluginrpc.Widget("Docker", "connected", host, [][2]string{
 		{"Running", fmt.Sprintf("%d", info.ContainersRunning)},
 		{"Containers", fmt.Sprintf("%d", info.Containers)},
 		{"Images", fmt.Sprintf("%d", info.Images)},
 		{"Version", pluginrpc.Truncate(info.ServerVersion, 24)},
-	}), nil
+	})
+	return pluginrpc.WithMetrics(widget,
+		pluginrpc.Metric{Name: "Running", Value: float64(info.ContainersRunning)},
+		pluginrpc.Metric{Name: "Containers", Value: float64(info.Containers)},
+	), nil
 }
 
 func (s *Service) viewImagesLocked() (pluginrpc.ViewData, error) {
@@ -210,7 +214,7 @@ func (s *Service) viewImagesLocked() (pluginrpc.ViewData, error) {
 		return pluginrpc.ViewData{}, err
 	}
 	rows := pluginrpc.EnsureRows(pluginrpc.MapRows(list, func(img DockerImage) []string { return img.GetTableRow() }), []string{"-", "-", "-", "-", "No images"})
-	return ui.Connected(viewImages, "Docker Images", s.baseInfo(fmt.Sprintf("Images: %d", len(list))), []string{"ID", "Repository", "Tag", "Size", "Created"}, rows, "ID", imagesActions()...), nil
+	return pluginrpc.WithDetail(ui.Connected(viewImages, "Docker Images", s.baseInfo(fmt.Sprintf("Images: %d", len(list))), []string{"ID", "Repository", "Tag", "Size", "Created"}, rows, "ID", imagesActions()...), "inspect"), nil
 }
 
 func (s *Service) viewNetworksLocked() (pluginrpc.ViewData, error) {
@@ -227,7 +231,7 @@ func (s *Service) viewNetworksLocked() (pluginrpc.ViewData, error) {
 		rows = append(rows, []string{id, n.Name, n.Driver, n.Scope, n.Subnet, n.Gateway})
 	}
 	rows = pluginrpc.EnsureRows(rows, []string{"-", "-", "-", "-", "-", "No networks"})
-	return ui.Connected(viewNetworks, "Docker Networks", s.baseInfo(fmt.Sprintf("Networks: %d", len(list))), []string{"ID", "Name", "Driver", "Scope", "Subnet", "Gateway"}, rows, "ID", networksActions()...), nil
+	return pluginrpc.WithDetail(ui.Connected(viewNetworks, "Docker Networks", s.baseInfo(fmt.Sprintf("Networks: %d", len(list))), []string{"ID", "Name", "Driver", "Scope", "Subnet", "Gateway"}, rows, "ID", networksActions()...), "inspect"), nil
 }
 
 func (s *Service) viewVolumesLocked() (pluginrpc.ViewData, error) {
@@ -244,7 +248,7 @@ func (s *Service) viewVolumesLocked() (pluginrpc.ViewData, error) {
 		rows = append(rows, []string{v.Name, v.Driver, v.Mountpoint, v.Scope, created})
 	}
 	rows = pluginrpc.EnsureRows(rows, []string{"-", "-", "-", "-", "No volumes"})
-	return ui.Connected(viewVolumes, "Docker Volumes", s.baseInfo(fmt.Sprintf("Volumes: %d", len(list))), []string{"Name", "Driver", "Mountpoint", "Scope", "Created"}, rows, "Name", volumesActions()...), nil
+	return pluginrpc.WithDetail(ui.Connected(viewVolumes, "Docker Volumes", s.baseInfo(fmt.Sprintf("Volumes: %d", len(list))), []string{"Name", "Driver", "Mountpoint", "Scope", "Created"}, rows, "Name", volumesActions()...), "inspect"), nil
 }
 
 func (s *Service) viewStatsLocked() (pluginrpc.ViewData, error) {
@@ -142,15 +142,15 @@ func (s *Service) viewReposLocked() (pluginrpc.ViewData, error) {
 		statusDisplay := repo.Status
 		switch statusDisplay {
 		case "clean":
-			statusDisplay = "[green]Clean[white]"
+			statusDisplay = pluginrpc.Tint(pluginrpc.ColorOK, "Clean")
 		case "dirty":
-			statusDisplay = "[yellow]Modified[white]"
+			statusDisplay = pluginrpc.Tint(pluginrpc.ColorWarn, "Modified")
 		case "ahead":
 			statusDisplay = "[#e8b86d]Ahead[white]"
 		case "behind":
-			statusDisplay = "[red]Behind[white]"
+			statusDisplay = pluginrpc.Tint(pluginrpc.ColorError, "Behind")
 		case "":
-			statusDisplay = "[gray]...[white]"
+			statusDisplay = pluginrpc.Tint(pluginrpc.ColorMuted, "...")
 		}
 		rows = append(rows, []string{
 			repo.Name,
@@ -28,9 +28,9 @@ func repositoriesActions() []pluginrpc.KeyBinding {
 func prsActions() []pluginrpc.KeyBinding {
 	return []pluginrpc.KeyBinding{
 		{Key: "M", Label: "Merge", Action: "merge"},
-		{Key: "C", Label: "Close", Action: "close"},
-		{Key: "O", Label: "Reopen", Action: "reopen"},
-		{Key: "V", Label: "Approve", Action: "approve"},
+		{Key: "C", Label: "Close", Action: "close", Batch: true},
+		{Key: "O", Label: "Reopen", Action: "reopen", Batch: true},
+		{Key: "V", Label: "Approve", Action: "approve", Batch: true},
 		{Key: "K", Label: "Checks", Action: "view_checks"},
 		{Key: "I", Label: "Reviews", Action: "view_reviews"},
 		{Key: "T", Label: "Toggle State", Action: "toggle_pr_state"},
@@ -182,15 +182,15 @@ func (s *Service) viewReposLocked() (pluginrpc.ViewData, error) {
 	s.cachedRepos = repos
 	rows := make([][]string, len(repos))
 	for i, r := range repos {
-		visibility := "[green]public"
+		visibility := pluginrpc.Tint(pluginrpc.ColorOK, "public")
 		if r.Private {
-			visibility = "[yellow]private"
+			visibility = pluginrpc.Tint(pluginrpc.ColorWarn, "private")
 		}
 		if r.Archived {
-			visibility = "[gray]archived"
+			visibility = pluginrpc.Tint(pluginrpc.ColorMuted, "archived")
 		}
 		if r.Fork {
-			visibility += "[gray]/fork"
+			visibility += pluginrpc.Tint(pluginrpc.ColorMuted, "/fork")
 		}
 		desc := r.Description
 		if len(desc) > 60 {
@@ -198,22 +198,22 @@ func (s *Service) viewReposLocked() (pluginrpc.ViewData, error) {
 		}
 		lang := r.Language
 		if lang == "" {
-			lang = "[gray]-"
+			lang = pluginrpc.Tint(pluginrpc.ColorMuted, "-")
 		} else {
-			lang = "[yellow]" + lang
+			lang = pluginrpc.Tint(pluginrpc.ColorWarn, lang)
 		}
 		updated := r.UpdatedAt
 		if len(updated) > 10 {
 			updated = updated[:10]
 		}
 		rows[i] = []string{
-			"[white]" + r.FullName,
-			"[gray]" + desc,
+			r.FullName,
+			pluginrpc.Tint(pluginrpc.ColorMuted, desc),
 			lang,
-			"[white]" + fmt.Sprintf("%d", r.Stars),
+			fmt.Sprintf("%d", r.Stars),
 			visibility,
-			"[green]" + r.DefaultBranch,
-			"[gray]" + updated,
+			pluginrpc.Tint(pluginrpc.ColorOK, r.DefaultBranch),
+			pluginrpc.Tint(pluginrpc.ColorMuted, updated),
 		}
 	}
 	rows = pluginrpc.EnsureRows(rows, []string{"-", "No repositories", "-", "-", "-", "-", "-"})
@@ -20,5 +20,6 @@ const (
 	jqlDoneToday = "assignee = currentUser() AND statusCategory = Done AND resolved >= startOfDay() ORDER BY updated DESC"
 	jqlDeployed  = "assignee = currentUser() AND development[deployments].environmentType is not EMPTY ORDER BY updated DESC"
 
-	maxList = 50
+	maxList        = 50
+	detailComments = 5 // latest comments shown in issue details
 )
@@ -60,13 +60,37 @@ func (s *Service) actionIssueDetailLocked(payload map[string]string) (pluginrpc.
 	if len(desc) > 4000 {
 		desc = desc[:4000] + "\n… truncated …"
 	}
-	body := fmt.Sprintf(
-		"Key:       %s\nType:      %s\nStatus:    %s\nPriority:  %s\nAssignee:  %s\nProject:   %s\nUpdated:   %s\nSummary:   %s\n\n%s\n",
-		issue.Key, issue.TypeName(), issue.StatusName(), issue.PriorityName(),
-		issue.AssigneeName(), dash(issue.ProjectKey()), formatWhen(issue.Fields.Updated),
-		issue.Fields.Summary, desc,
-	)
-	return pluginrpc.ActionResult{OK: true, ModalTitle: issue.Key, ModalBody: body}, nil
+	detail := pluginrpc.Detail{
+		Title: issue.Key + " · " + issue.Fields.Summary,
+		Sections: []pluginrpc.DetailSection{
+			{Fields: []pluginrpc.DetailField{
+				{Key: "Type", Value: issue.TypeName()},
+				{Key: "Status", Value: issue.StatusName()},
+				{Key: "Priority", Value: issue.PriorityName()},
+				{Key: "Assignee", Value: issue.AssigneeName()},
+				{Key: "Project", Value: dash(issue.ProjectKey())},
+				{Key: "Updated", Value: formatWhen(issue.Fields.Updated)},
+			}},
+			{Title: "Description", Text: desc},
+		},
+	}
+	if comments, err := s.client.Comments(issue.Key); err == nil && len(comments) > 0 {
+		if len(comments) > detailComments {
+			comments = comments[len(comments)-detailComments:]
+		}
+		rows := pluginrpc.MapRows(comments, func(c Comment) []string {
+			return []string{
+				dash(c.Author.DisplayName),
+				formatWhen(c.Created),
+				pluginrpc.Truncate(strings.ReplaceAll(adfToText(c.Body), "\n", " "), 60),
+			}
+		})
+		detail.Sections = append(detail.Sections, pluginrpc.DetailSection{
+			Title: "Latest comments",
+			Table: &pluginrpc.DetailTable{Headers: []string{"Author", "Created", "Body"}, Rows: rows},
+		})
+	}
+	return pluginrpc.ActionResult{OK: true, ModalTitle: issue.Key, Detail: &detail}, nil
 }
 
 func (s *Service) actionCloseReopenLocked(payload map[string]string, closeIt bool) (pluginrpc.ActionResult, error) {
@@ -189,9 +189,9 @@ func (s *Service) viewMineLocked() (pluginrpc.ViewData, error) {
 	}
 	rows := issueRows(issues, true)
 	rows = pluginrpc.EnsureRows(rows, []string{"-", "-", "-", "-", "No issues assigned to you", "-"})
-	return ui.Connected(viewMine, "Mine", s.baseInfo(fmt.Sprintf("Open: %d", len(issues))),
+	return pluginrpc.WithDetail(ui.Connected(viewMine, "Mine", s.baseInfo(fmt.Sprintf("Open: %d", len(issues))),
 		[]string{"Key", "Type", "Status", "Priority", "Summary", "Updated"},
-		rows, "Key", mineActions()...), nil
+		rows, "Key", mineActions()...), "issue_detail"), nil
 }
 
 func (s *Service) viewBoardsLocked() (pluginrpc.ViewData, error) {
@@ -244,9 +244,9 @@ func (s *Service) viewIssuesLocked() (pluginrpc.ViewData, error) {
 	}
 	rows := issueRows(issues, false)
 	rows = pluginrpc.EnsureRows(rows, []string{"-", "-", "-", "-", "No issues"})
-	return ui.Connected(viewIssues, title, s.baseInfo(fmt.Sprintf("%s · %d issues", extra, len(issues))),
+	return pluginrpc.WithDetail(ui.Connected(viewIssues, title, s.baseInfo(fmt.Sprintf("%s · %d issues", extra, len(issues))),
 		[]string{"Key", "Status", "Assignee", "Priority", "Summary"},
-		rows, "Key", issuesActions()...), nil
+		rows, "Key", issuesActions()...), "issue_detail"), nil
 }
 
 func (s *Service) viewSprintsLocked() (pluginrpc.ViewData, error) {
@@ -301,9 +301,9 @@ func (s *Service) viewBacklogLocked() (pluginrpc.ViewData, error) {
 	}
 	rows := issueRows(issues, false)
 	rows = pluginrpc.EnsureRows(rows, []string{"-", "-", "-", "-", "Backlog is empty"})
-	return ui.Connected(viewBacklog, "Backlog — "+s.selectedBoard.Name, s.baseInfo(fmt.Sprintf("Backlog: %d", len(issues))),
+	return pluginrpc.WithDetail(ui.Connected(viewBacklog, "Backlog — "+s.selectedBoard.Name, s.baseInfo(fmt.Sprintf("Backlog: %d", len(issues))),
 		[]string{"Key", "Status", "Assignee", "Priority", "Summary"},
-		rows, "Key", backlogActions()...), nil
+		rows, "Key", backlogActions()...), "issue_detail"), nil
 }
 
 func (s *Service) viewTransitionsLocked() (pluginrpc.ViewData, error) {
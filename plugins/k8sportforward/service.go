@@ -338,29 +338,50 @@ func (s *Service) actionViewDetailsLocked(payload map[string]string) (pluginrpc.
 	if namespace == "" || name == "" {
 		id := firstNonEmpty(payload["id"], payload["key"], payload["col0"])
 		if f := s.forwards.Get(id); f != nil {
-			body := fmt.Sprintf("ID:      %s\nStatus:  %s\nTarget:  %s %s/%s\nPod:     %s\nLocal:   127.0.0.1:%d\nRemote:  %d\nAge:     %s\nError:   %s\n",
-				f.ID, f.Status, f.Kind, f.Namespace, f.Name, f.Pod, f.LocalPort, f.RemotePort, f.Age(), dash(f.Error))
-			return pluginrpc.ActionResult{OK: true, ModalTitle: "Forward Details", ModalBody: body}, nil
+			detail := pluginrpc.Detail{
+				Title: f.ID,
+				Sections: []pluginrpc.DetailSection{{Fields: []pluginrpc.DetailField{
+					{Key: "Status", Value: string(f.Status)},
+					{Key: "Target", Value: fmt.Sprintf("%s %s/%s", f.Kind, f.Namespace, f.Name)},
+					{Key: "Pod", Value: f.Pod},
+					{Key: "Local", Value: fmt.Sprintf("127.0.0.1:%d", f.LocalPort)},
+					{Key: "Remote", Value: strconv.Itoa(f.RemotePort)},
+					{Key: "Age", Value: f.Age()},
+					{Key: "Error", Value: dash(f.Error)},
+				}}},
+			}
+			return pluginrpc.ActionResult{OK: true, ModalTitle: "Forward Details", Detail: &detail}, nil
 		}
 		return pluginrpc.ActionResult{OK: false, Message: "nothing selected"}, nil
 	}
 
-	fs := s.forwards.ForTarget(kind, namespace, name)
-	var b strings.Builder
-	fmt.Fprintf(&b, "Kind:      %s\nNamespace: %s\nName:      %s\n", kind, namespace, name)
-	if ports := firstNonEmpty(payload["ports"], payload["col6"], payload["col5"]); ports != "" {
-		fmt.Fprintf(&b, "Ports:     %s\n", ports)
+	detail := pluginrpc.Detail{
+		Title: name,
+		Sections: []pluginrpc.DetailSection{{Fields: pluginrpc.Fields(
+			"Kind", kind,
+			"Namespace", namespace,
+			"Name", name,
+			"Ports", firstNonEmpty(payload["ports"], payload["col6"], payload["col5"]),
+		)}},
 	}
+	fs := s.forwards.ForTarget(kind, namespace, name)
 	if len(fs) == 0 {
-		b.WriteString("\nNo active forwards.\nPress F to start one.\n")
+		detail.Sections = append(detail.Sections, pluginrpc.DetailSection{
+			Title: "Active forwards",
+			Text:  "No active forwards.\nPress F to start one.",
+		})
 	} else {
-		b.WriteString("\nActive forwards:\n")
+		rows := make([][]string, 0, len(fs))
 		for _, f := range fs {
-			fmt.Fprintf(&b, "  %s  127.0.0.1:%d → %d  (%s)  pod=%s\n", fwdMarkActive, f.LocalPort, f.RemotePort, f.Status, f.Pod)
+			rows = append(rows, []string{fmt.Sprintf("127.0.0.1:%d", f.LocalPort), strconv.Itoa(f.RemotePort), string(f.Status), f.Pod})
 		}
-		b.WriteString("\nPoint redis/postgres KeePass entries at 127.0.0.1 and the local port above.\n")
+		detail.Sections = append(detail.Sections, pluginrpc.DetailSection{
+			Title: "Active forwards",
+			Table: &pluginrpc.DetailTable{Headers: []string{"Local", "Remote", "Status", "Pod"}, Rows: rows},
+			Text:  "Point redis/postgres KeePass entries at 127.0.0.1 and the local port above.",
+		})
 	}
-	return pluginrpc.ActionResult{OK: true, ModalTitle: name, ModalBody: b.String()}, nil
+	return pluginrpc.ActionResult{OK: true, ModalTitle: name, Detail: &detail}, nil
 }
 
 func (s *Service) actionConnectionInfoLocked(payload map[string]string) (pluginrpc.ActionResult, error) {
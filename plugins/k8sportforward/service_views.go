@@ -155,7 +155,7 @@ func (s *Service) viewWorkloadsLocked() (pluginrpc.ViewData, error) {
 	}
 	rows = pluginrpc.EnsureRows(rows, []string{"○", "-", "-", "No workloads", "-", "-", "-", "-"})
 	extra := fmt.Sprintf("%d workloads (Deployments + StatefulSets)", len(list))
-	return ui.Connected(viewWorkloads, "Workloads", s.baseInfo(extra), headers, rows, "Name", workloadActions()...), nil
+	return pluginrpc.WithDetail(ui.Connected(viewWorkloads, "Workloads", s.baseInfo(extra), headers, rows, "Name", workloadActions()...), "view_details"), nil
 }
 
 func (s *Service) viewServicesLocked() (pluginrpc.ViewData, error) {
@@ -185,7 +185,7 @@ func (s *Service) viewServicesLocked() (pluginrpc.ViewData, error) {
 		})
 	}
 	rows = pluginrpc.EnsureRows(rows, []string{"○", "-", "-", "No services", "-", "-", "-", "-"})
-	return ui.Connected(viewServices, "Services", s.baseInfo(fmt.Sprintf("%d services", len(list))), headers, rows, "Name", serviceActions()...), nil
+	return pluginrpc.WithDetail(ui.Connected(viewServices, "Services", s.baseInfo(fmt.Sprintf("%d services", len(list))), headers, rows, "Name", serviceActions()...), "view_details"), nil
 }
 
 func (s *Service) viewPodsLocked() (pluginrpc.ViewData, error) {
@@ -215,7 +215,7 @@ func (s *Service) viewPodsLocked() (pluginrpc.ViewData, error) {
 		})
 	}
 	rows = pluginrpc.EnsureRows(rows, []string{"○", "-", "-", "No pods", "-", "-", "-", "-"})
-	return ui.Connected(viewPods, "Pods", s.baseInfo(fmt.Sprintf("%d pods", len(list))), headers, rows, "Name", podActions()...), nil
+	return pluginrpc.WithDetail(ui.Connected(viewPods, "Pods", s.baseInfo(fmt.Sprintf("%d pods", len(list))), headers, rows, "Name", podActions()...), "view_details"), nil
 }
 
 func (s *Service) viewForwardsLocked() (pluginrpc.ViewData, error) {
@@ -238,7 +238,7 @@ func (s *Service) viewForwardsLocked() (pluginrpc.ViewData, error) {
 	}
 	rows = pluginrpc.EnsureRows(rows, []string{"-", "idle", "No active forwards", "-", "-", "-"})
 	extra := fmt.Sprintf("%d active forward(s)", len(list))
-	return ui.Connected(viewForwards, "Active Forwards", s.baseInfo(extra), headers, rows, "ID", forwardActions()...), nil
+	return pluginrpc.WithDetail(ui.Connected(viewForwards, "Active Forwards", s.baseInfo(extra), headers, rows, "ID", forwardActions()...), "view_details"), nil
 }
 
 func (s *Service) viewNamespacesLocked() (pluginrpc.ViewData, error) {
@@ -277,7 +277,7 @@ func (s *Service) viewPortsLocked() (pluginrpc.ViewData, error) {
 	}
 	rows = pluginrpc.EnsureRows(rows, []string{"-", "-", "127.0.0.1", "No ports forwarded", "-", "-"})
 	extra := "Bind redis/postgres KeePass host=127.0.0.1 to these local ports"
-	return ui.Connected(viewPorts, "Port Registry", s.baseInfo(extra), headers, rows, "Local", portActions()...), nil
+	return pluginrpc.WithDetail(ui.Connected(viewPorts, "Port Registry", s.baseInfo(extra), headers, rows, "Local", portActions()...), "view_details"), nil
 }
 
 func (s *Service) fwdCells(kind, namespace, name string) (fwd, local string) {
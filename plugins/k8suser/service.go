@@ -140,7 +140,21 @@ func (s *Service) DoAction(req pluginrpc.ActionRequest) (pluginrpc.ActionResult,
 			return pluginrpc.ActionResult{OK: false, Message: err.Error()}, nil
 		}
 		view, _ := s.buildViewLocked(k8sViewUsers)
-		return pluginrpc.ActionResult{OK: true, Message: fmt.Sprintf("created user %s (expires %s)", user.Username, user.CertExpiry), Next: &view}, nil
+		res := pluginrpc.ActionResult{OK: true, Message: fmt.Sprintf("created user %s (expires %s)", user.Username, user.CertExpiry), Next: &view}
+		if cert := user.Certificate; cert != nil {
+			res.Credential = &pluginrpc.IssuedCredential{
+				Name:     user.Username,
+				UserName: user.Username,
+				Attributes: map[string]string{
+					"client_cert": cert.Cert,
+					"client_key":  cert.PrivateKey,
+					"context":     s.context,
+					"kubeconfig":  s.kubeconfig,
+				},
+				ExpiresAt: cert.ExpiryDate,
+			}
+		}
+		return res, nil
 
 	case "delete":
 		if s.currentView == k8sViewRoles || req.View == k8sViewRoles {
@@ -274,7 +288,14 @@ func (s *Service) viewDetailsLocked(req pluginrpc.ActionRequest) (pluginrpc.Acti
 		if err != nil {
 			return pluginrpc.ActionResult{OK: false, Message: fmt.Sprintf("%v: %s", err, out)}, nil
 		}
-		return pluginrpc.ActionResult{OK: true, ModalTitle: "Role: " + name, ModalBody: string(out)}, nil
+		detail := pluginrpc.Detail{
+			Title: name,
+			Sections: []pluginrpc.DetailSection{
+				{Fields: pluginrpc.Fields("Kind", roleKind(ns), "Namespace", ns)},
+				{Title: "Manifest", Code: &pluginrpc.DetailCode{Lang: "yaml", Body: string(out)}},
+			},
+		}
+		return pluginrpc.ActionResult{OK: true, ModalTitle: "Role: " + name, Detail: &detail}, nil
 	}
 
 	username := firstNonEmpty(req.Payload["username"], req.Payload["name"], req.Payload["key"])
@@ -287,14 +308,28 @@ func (s *Service) viewDetailsLocked(req pluginrpc.ActionRequest) (pluginrpc.Acti
 	}
 	for _, u := range users {
 		if u.Username == username {
-			body := fmt.Sprintf("Username: %s\nNamespaces: %s\nRoles: %s\nCert Expiry: %s",
-				u.Username, u.Namespace, u.Roles, u.CertExpiry)
-			return pluginrpc.ActionResult{OK: true, ModalTitle: "User: " + username, ModalBody: body}, nil
+			detail := pluginrpc.Detail{
+				Title: u.Username,
+				Sections: []pluginrpc.DetailSection{{Fields: []pluginrpc.DetailField{
+					{Key: "Username", Value: u.Username},
+					{Key: "Namespaces", Value: u.Namespace},
+					{Key: "Roles", Value: u.Roles},
+					{Key: "Cert Expiry", Value: u.CertExpiry},
+				}}},
+			}
+			return pluginrpc.ActionResult{OK: true, ModalTitle: "User: " + username, Detail: &detail}, nil
 		}
 	}
 	return pluginrpc.ActionResult{OK: false, Message: "user not found: " + username}, nil
 }
 
+func roleKind(namespace string) string {
+	if namespace == "cluster-wide" {
+		return "ClusterRole"
+	}
+	return "Role"
+}
+
 func (s *Service) Stop() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -91,8 +91,9 @@ func (s *Service) k8sViewUsersLocked() (pluginrpc.ViewData, error) {
 	}
 	rows = pluginrpc.EnsureRows(rows, []string{"No certificate-based users found", "Use create_user", "", ""})
 
-	return ui.OK(k8sViewUsers, titleK8sUsers, s.baseInfo(fmt.Sprintf("Users: %d", len(users))),
-		[]string{"Username", "Certificate Expiry", "Namespaces", "Roles"}, rows, "Username", usersActions()...), nil
+	view := ui.OK(k8sViewUsers, titleK8sUsers, s.baseInfo(fmt.Sprintf("Users: %d", len(users))),
+		[]string{"Username", "Certificate Expiry", "Namespaces", "Roles"}, rows, "Username", usersActions()...)
+	return pluginrpc.WithDetail(view, "view_details"), nil
 }
 
 func (s *Service) k8sViewRolesLocked() (pluginrpc.ViewData, error) {
@@ -100,8 +101,9 @@ func (s *Service) k8sViewRolesLocked() (pluginrpc.ViewData, error) {
 	if err != nil {
 		return pluginrpc.ViewData{}, err
 	}
-	return ui.OK(k8sViewRoles, "K8s Roles", s.baseInfo(fmt.Sprintf("Roles: %d", len(rows))),
-		[]string{"Name", "Namespace", "Resources"}, rows, "Name", rolesActions()...), nil
+	view := ui.OK(k8sViewRoles, "K8s Roles", s.baseInfo(fmt.Sprintf("Roles: %d", len(rows))),
+		[]string{"Name", "Namespace", "Resources"}, rows, "Name", rolesActions()...)
+	return pluginrpc.WithDetail(view, "view_details"), nil
 }
 
 func (s *Service) fetchRolesLocked() ([][]string, error) {
@@ -79,6 +79,54 @@ func (s *Service) baseInfo(extra string) string {
 	return pluginrpc.FormatInfo(msg, extra)
 }
 
+// viewDashboardLocked summarizes the cluster for a dashboard tile. Lag is
+// the committed-offset lag summed over every consumer group.
+func (s *Service) viewDashboardLocked() (pluginrpc.ViewData, error) {
+	if err := s.ensureConnectedLocked(); err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	brokers, err := s.client.GetBrokers()
+	if err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	topics, err := s.client.GetTopics()
+	if err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	groups, err := s.client.GetConsumerGroups()
+	if err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	var total, worst int64
+	worstGroup := "-"
+	for _, g := range groups {
+		offsets, err := s.client.GetConsumerGroupOffsets(g.GroupID)
+		if err != nil {
+			continue
+		}
+		var lag int64
+		for _, o := range offsets {
+			lag += o.Lag
+		}
+		total += lag
+		if lag > worst {
+			worst, worstGroup = lag, fmt.Sprintf("%s (%d)", g.GroupID, lag)
+		}
+	}
+	name := s.name
+	if name == "" {
+		name = s.bootstrap
+	}
+	widget := pluginrpc.Widget("Kafka", "connected", name, [][2]string{
+		{"Brokers", strconv.Itoa(len(brokers))},
+		{"Topics", strconv.Itoa(len(topics))},
+		{"Groups", strconv.Itoa(len(groups))},
+		{"Lag", strconv.FormatInt(total, 10)},
+		{"Top lag", pluginrpc.Truncate(worstGroup, 28)},
+	})
+	return pluginrpc.WithMetrics(widget, pluginrpc.Metric{Name: "Lag", Value: float64(total)}), nil
+}
+
 func (s *Service) buildViewLocked(viewID string) (pluginrpc.ViewData, error) {
 	if viewID == "" {
 		viewID = kafkaViewBrokers
@@ -0,0 +1,492 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"omo/pkg/pluginrpc"
+)
+
+// consoleMaxEntries caps the console transcript kept per target.
+const consoleMaxEntries = 200
+
+// Safe-mode levels of the protected attribute.
+const (
+	protectOff     = ""
+	protectConfirm = "confirm"
+	protectBlock   = "block"
+)
+
+type consoleEntry struct {
+	Prompt  string
+	Command string
+	Reply   string
+	Took    time.Duration
+}
+
+// writeCommands change data. Keys are command names, or "COMMAND SUBCOMMAND"
+// for containers such as CONFIG or FUNCTION. classifyCommand adds the
+// server's COMMAND INFO flags when it answers.
+var writeCommands = setOf(
+	"APPEND", "BITFIELD", "BITOP", "BLMOVE", "BLMPOP", "BLPOP", "BRPOP",
+	"BRPOPLPUSH", "BZMPOP", "BZPOPMAX", "BZPOPMIN", "COPY", "DECR",
+	"DECRBY", "DEL", "EVAL", "EVALSHA", "EXPIRE", "EXPIREAT", "FCALL",
+	"GEOADD", "GEORADIUS", "GEORADIUSBYMEMBER", "GEOSEARCHSTORE", "GETDEL",
+	"GETEX", "GETSET", "HDEL", "HEXPIRE", "HEXPIREAT", "HGETDEL", "HGETEX",
+	"HINCRBY", "HINCRBYFLOAT", "HMSET", "HPERSIST", "HPEXPIRE",
+	"HPEXPIREAT", "HSET", "HSETEX", "HSETNX", "INCR", "INCRBY",
+	"INCRBYFLOAT", "LINSERT", "LMOVE", "LMPOP", "LPOP", "LPUSH", "LPUSHX",
+	"LREM", "LSET", "LTRIM", "MIGRATE", "MOVE", "MSET", "MSETNX", "PERSIST",
+	"PEXPIRE", "PEXPIREAT", "PFADD", "PFMERGE", "PSETEX", "RENAME",
+	"RENAMENX", "RESTORE", "RPOP", "RPOPLPUSH", "RPUSH", "RPUSHX", "SADD",
+	"SDIFFSTORE", "SET", "SETBIT", "SETEX", "SETNX", "SETRANGE",
+	"SINTERSTORE", "SMOVE", "SORT", "SPOP", "SREM", "SUNIONSTORE", "UNLINK",
+	"XACK", "XADD", "XAUTOCLAIM", "XCLAIM", "XDEL", "XGROUP", "XREADGROUP",
+	"XSETID", "XTRIM", "ZADD", "ZDIFFSTORE", "ZINCRBY", "ZINTERSTORE",
+	"ZMPOP", "ZPOPMAX", "ZPOPMIN", "ZRANGESTORE", "ZREM", "ZREMRANGEBYLEX",
+	"ZREMRANGEBYRANK", "ZREMRANGEBYSCORE", "ZUNIONSTORE",
+)
+
+// dangerousCommands wipe data or change the server; they always ask first.
+var dangerousCommands = setOf(
+	"ACL DELUSER", "ACL LOAD", "ACL SETUSER", "BGREWRITEAOF", "BGSAVE",
+	"CLIENT KILL", "CLIENT PAUSE", "CLUSTER ADDSLOTS", "CLUSTER DELSLOTS",
+	"CLUSTER FAILOVER", "CLUSTER FLUSHSLOTS", "CLUSTER FORGET",
+	"CLUSTER MEET", "CLUSTER REPLICATE", "CLUSTER RESET", "CLUSTER SETSLOT",
+	"CONFIG RESETSTAT", "CONFIG REWRITE", "CONFIG SET", "DEBUG", "FAILOVER",
+	"FLUSHALL", "FLUSHDB", "FUNCTION DELETE", "FUNCTION FLUSH",
+	"FUNCTION KILL", "FUNCTION LOAD", "FUNCTION RESTORE", "LATENCY RESET",
+	"MEMORY PURGE", "MODULE LOAD", "MODULE UNLOAD", "REPLICAOF", "SAVE",
+	"SCRIPT FLUSH", "SCRIPT KILL", "SHUTDOWN", "SLAVEOF", "SLOWLOG RESET",
+	"SWAPDB",
+)
+
+// inspectCommands carry the admin flag but only read server state, so the
+// flag alone does not make them dangerous.
+var inspectCommands = setOf(
+	"ACL CAT", "ACL GETUSER", "ACL LIST", "ACL USERS", "ACL WHOAMI",
+	"CLIENT GETNAME", "CLIENT ID", "CLIENT INFO", "CLIENT LIST",
+	"CLUSTER INFO", "CLUSTER NODES", "CLUSTER SHARDS", "CLUSTER SLOTS",
+	"CONFIG GET", "LATENCY DOCTOR", "LATENCY GRAPH", "LATENCY HISTOGRAM",
+	"LATENCY HISTORY", "LATENCY LATEST", "MEMORY DOCTOR", "MEMORY MALLOC-STATS",
+	"MEMORY STATS", "MODULE LIST", "SLOWLOG GET", "SLOWLOG LEN",
+)
+
+// unsupportedCommands need a dedicated connection, which the pooled client
+// behind the console does not have.
+var unsupportedCommands = setOf(
+	"DISCARD", "EXEC", "HELLO", "MONITOR", "MULTI", "PSUBSCRIBE", "PSYNC",
+	"PUNSUBSCRIBE", "QUIT", "RESET", "SSUBSCRIBE", "SUBSCRIBE", "SUNSUBSCRIBE",
+	"SYNC", "UNSUBSCRIBE", "UNWATCH", "WATCH",
+)
+
+func setOf(names ...string) map[string]bool {
+	m := make(map[string]bool, len(names))
+	for _, n := range names {
+		m[n] = true
+	}
+	return m
+}
+
+// commandIn reports whether args name a command of set, matching
+// "COMMAND SUBCOMMAND" before the bare command.
+func commandIn(set map[string]bool, args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	name := strings.ToUpper(args[0])
+	if len(args) > 1 && set[name+" "+strings.ToUpper(args[1])] {
+		return true
+	}
+	return set[name]
+}
+
+// classifyCommand reports whether args write data and whether they are
+// dangerous. flags looks the command up in the server's COMMAND INFO; when
+// the server does not answer, the static lists decide. Both static lists
+// always apply on top of the server's flags, since some commands that change
+// data or the server (SCRIPT KILL) carry neither flag.
+func classifyCommand(args []string, flags func(name, sub string) (commandFlags, bool)) (write, dangerous bool) {
+	if len(args) == 0 {
+		return false, false
+	}
+	dangerous = commandIn(dangerousCommands, args)
+	f, ok := commandFlags{}, false
+	if len(args) > 1 {
+		f, ok = flags(args[0], args[1])
+	}
+	if !ok {
+		f, ok = flags(args[0], "")
+	}
+	if !ok {
+		return commandIn(writeCommands, args), dangerous
+	}
+	help := len(args) > 1 && strings.EqualFold(args[1], "HELP")
+	if f.admin && !help && !commandIn(inspectCommands, args) {
+		dangerous = true
+	}
+	write = f.write || commandIn(writeCommands, args)
+	if write && f.dangerous {
+		dangerous = true
+	}
+	return write, dangerous
+}
+
+// parseProtected reads the protected attribute: true or confirm asks before
+// every write command, block refuses them.
+func parseProtected(v string) string {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "1", "true", "yes", "on", protectConfirm:
+		return protectConfirm
+	case protectBlock:
+		return protectBlock
+	}
+	return protectOff
+}
+
+// splitArgs splits a command line with redis-cli quoting rules: double quotes
+// take \n, \r, \t, \b, \a, \xHH and \<char> escapes, single quotes only \',
+// and a closing quote must be followed by a space or the end of the line.
+func splitArgs(line string) ([]string, error) {
+	var args []string
+	i := 0
+	for {
+		for i < len(line) && isSpace(line[i]) {
+			i++
+		}
+		if i >= len(line) {
+			return args, nil
+		}
+		var (
+			cur      strings.Builder
+			inDouble bool
+			inSingle bool
+		)
+		for done := false; !done; {
+			if i >= len(line) {
+				if inDouble || inSingle {
+					return nil, errors.New("unbalanced quotes")
+				}
+				break
+			}
+			ch := line[i]
+			switch {
+			case inDouble:
+				switch {
+				case ch == '\\' && i+3 < len(line) && line[i+1] == 'x' && isHex(line[i+2]) && isHex(line[i+3]):
+					b, _ := strconv.ParseUint(line[i+2:i+4], 16, 8)
+					cur.WriteByte(byte(b))
+					i += 3
+				case ch == '\\' && i+1 < len(line):
+					i++
+					cur.WriteByte(unescape(line[i]))
+				case ch == '"':
+					if i+1 < len(line) && !isSpace(line[i+1]) {
+						return nil, errors.New("closing quote must be followed by a space")
+					}
+					done = true
+				default:
+					cur.WriteByte(ch)
+				}
+			case inSingle:
+				switch {
+				case ch == '\\' && i+1 < len(line) && line[i+1] == '\'':
+					i++
+					cur.WriteByte('\'')
+				case ch == '\'':
+					if i+1 < len(line) && !isSpace(line[i+1]) {
+						return nil, errors.New("closing quote must be followed by a space")
+					}
+					done = true
+				default:
+					cur.WriteByte(ch)
+				}
+			default:
+				switch {
+				case isSpace(ch):
+					done = true
+				case ch == '"':
+					inDouble = true
+				case ch == '\'':
+					inSingle = true
+				default:
+					cur.WriteByte(ch)
+				}
+			}
+			i++
+		}
+		args = append(args, cur.String())
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func unescape(c byte) byte {
+	switch c {
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case 'b':
+		return '\b'
+	case 'a':
+		return '\a'
+	}
+	return c
+}
+
+// formatReply renders a reply the way redis-cli does on a terminal: numbered
+// nested arrays, "1#" map entries and typed RESP3 scalars.
+func formatReply(v interface{}) string {
+	var b strings.Builder
+	writeReply(&b, v, "")
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func writeReply(b *strings.Builder, v interface{}, prefix string) {
+	switch x := v.(type) {
+	case nil:
+		b.WriteString("(nil)\n")
+	case error:
+		b.WriteString("(error) " + x.Error() + "\n")
+	case string:
+		b.WriteString(quoteReply(x) + "\n")
+	case int64:
+		fmt.Fprintf(b, "(integer) %d\n", x)
+	case float64:
+		b.WriteString("(double) " + strconv.FormatFloat(x, 'f', -1, 64) + "\n")
+	case bool:
+		fmt.Fprintf(b, "(%t)\n", x)
+	case *big.Int:
+		b.WriteString("(big number) " + x.String() + "\n")
+	case []interface{}:
+		if len(x) == 0 {
+			b.WriteString("(empty array)\n")
+			return
+		}
+		width := len(strconv.Itoa(len(x)))
+		for i, item := range x {
+			idx := fmt.Sprintf("%*d) ", width, i+1)
+			if i > 0 {
+				b.WriteString(prefix)
+			}
+			b.WriteString(idx)
+			writeReply(b, item, prefix+strings.Repeat(" ", len(idx)))
+		}
+	case map[interface{}]interface{}:
+		if len(x) == 0 {
+			b.WriteString("(empty hash)\n")
+			return
+		}
+		type pair struct {
+			key string
+			val interface{}
+		}
+		pairs := make([]pair, 0, len(x))
+		for k, val := range x {
+			pairs = append(pairs, pair{formatReply(k), val})
+		}
+		sort.Slice(pairs, func(i, j int) bool { return pairs[i].key < pairs[j].key })
+		width := len(strconv.Itoa(len(pairs)))
+		for i, p := range pairs {
+			head := fmt.Sprintf("%*d# %s => ", width, i+1, p.key)
+			if i > 0 {
+				b.WriteString(prefix)
+			}
+			b.WriteString(head)
+			writeReply(b, p.val, prefix+strings.Repeat(" ", len(head)))
+		}
+	default:
+		fmt.Fprintf(b, "%v\n", x)
+	}
+}
+
+// quoteReply quotes a bulk string like redis-cli: printable bytes as-is,
+// common escapes, \xHH for the rest.
+func quoteReply(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '\\', '"':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\a':
+			b.WriteString(`\a`)
+		case '\b':
+			b.WriteString(`\b`)
+		default:
+			if c < 0x20 || c > 0x7e {
+				fmt.Fprintf(&b, `\x%02x`, c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// consolePrompt mirrors the redis-cli prompt, e.g. "10.0.0.1:6379[2]>".
+func (s *Service) consolePrompt() string {
+	prompt := s.conn.Label()
+	if s.conn.Mode == ModeCluster {
+		prompt = "cluster " + prompt
+	}
+	if s.conn.Database != 0 {
+		prompt += fmt.Sprintf("[%d]", s.conn.Database)
+	}
+	return prompt + ">"
+}
+
+func (s *Service) consoleBody() string {
+	if len(s.console) == 0 {
+		hint := "Press : to run a command (redis-cli quoting, ↑/↓ recall history)."
+		switch s.protected {
+		case protectBlock:
+			hint += "\nThis target is protected: write commands are blocked."
+		case protectConfirm:
+			hint += "\nThis target is protected: write commands ask for confirmation."
+		}
+		return hint
+	}
+	var b strings.Builder
+	for _, e := range s.console {
+		fmt.Fprintf(&b, "%s %s\n%s\n", e.Prompt, e.Command, e.Reply)
+		if e.Took > 0 {
+			fmt.Fprintf(&b, "(%s)\n", e.Took.Round(time.Microsecond))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func (s *Service) viewConsoleLocked() (pluginrpc.ViewData, error) {
+	extra := fmt.Sprintf("Commands: %d", len(s.console))
+	if s.protected != protectOff {
+		extra += "\nSafe mode: " + s.protected
+	}
+	return ui.Logs(viewConsole, "Redis Console", s.baseInfo(extra), s.consoleBody(), consoleActions()...), nil
+}
+
+func (s *Service) logConsole(prompt, line, reply string, took time.Duration) {
+	s.console = append(s.console, consoleEntry{Prompt: prompt, Command: line, Reply: reply, Took: took})
+	if len(s.console) > consoleMaxEntries {
+		s.console = s.console[len(s.console)-consoleMaxEntries:]
+	}
+}
+
+// guardWriteLocked applies the protected attribute to a write action the
+// way the console applies it to write commands: refused on block, and on
+// confirm a confirmation the host re-sends with confirmed=true. It returns
+// nil when the write may go ahead.
+func (s *Service) guardWriteLocked(action, what string, payload map[string]string) *pluginrpc.ActionResult {
+	switch {
+	case s.protected == protectBlock:
+		return &pluginrpc.ActionResult{OK: false, Message: "blocked: writes are disabled on this protected target"}
+	case s.protected == protectConfirm && payload["confirmed"] != "true":
+		next := make(map[string]string, len(payload)+1)
+		for k, v := range payload {
+			next[k] = v
+		}
+		next["confirmed"] = "true"
+		return &pluginrpc.ActionResult{OK: true, Confirm: &pluginrpc.Confirm{
+			Title:   "Confirm Write",
+			Body:    fmt.Sprintf("%s on %s?\n\nThis target is protected.", what, s.conn.Label()),
+			Action:  action,
+			Payload: next,
+		}}
+	}
+	return nil
+}
+
+// runCommandLocked runs one console line. Write commands on protected
+// targets, and dangerous commands anywhere, come back as a confirmation the
+// host re-sends with confirmed=true.
+func (s *Service) runCommandLocked(payload map[string]string) pluginrpc.ActionResult {
+	line := strings.TrimSpace(payload["command"])
+	if line == "" {
+		return pluginrpc.ActionResult{OK: false, Message: "command required"}
+	}
+	prompt := s.consolePrompt()
+	done := func(reply string, took time.Duration) pluginrpc.ActionResult {
+		s.logConsole(prompt, line, reply, took)
+		view, _ := s.viewConsoleLocked()
+		return pluginrpc.ActionResult{OK: true, Next: &view}
+	}
+
+	args, err := splitArgs(line)
+	if err != nil {
+		return done("(error) Invalid argument(s): "+err.Error(), 0)
+	}
+	if len(args) == 0 {
+		return pluginrpc.ActionResult{OK: false, Message: "command required"}
+	}
+	name := strings.ToUpper(args[0])
+
+	if commandIn(unsupportedCommands, args) {
+		return done(fmt.Sprintf("(error) %s is not supported in the console (no dedicated connection); use the PubSub view or redis-cli", name), 0)
+	}
+	write, dangerous := classifyCommand(args, s.client.CommandFlags)
+	if (write || dangerous) && s.protected == protectBlock {
+		return done("(error) blocked: write commands are disabled on this protected target", 0)
+	}
+	if (dangerous || (write && s.protected == protectConfirm)) && payload["confirmed"] != "true" {
+		body := fmt.Sprintf("Run on %s?\n\n%s", s.conn.Label(), line)
+		if s.protected != protectOff {
+			body += "\n\nThis target is protected."
+		}
+		return pluginrpc.ActionResult{
+			OK: true,
+			Confirm: &pluginrpc.Confirm{
+				Title:   "Confirm " + name,
+				Body:    body,
+				Action:  "run_command",
+				Payload: map[string]string{"command": line, "confirmed": "true"},
+			},
+		}
+	}
+
+	if err := s.ensureConnectedLocked(); err != nil {
+		return done("(error) "+err.Error(), 0)
+	}
+	// SELECT would only switch one pooled connection; reconnect instead.
+	if name == "SELECT" && len(args) == 2 {
+		db, err := strconv.Atoi(args[1])
+		if err != nil || db < 0 {
+			return done("(error) ERR invalid DB index", 0)
+		}
+		if err := s.reconnectDBLocked(db); err != nil {
+			return done("(error) "+err.Error(), 0)
+		}
+		return done(`"OK"`, 0)
+	}
+
+	start := time.Now()
+	reply, err := s.client.Run(args)
+	took := time.Since(start)
+	if err != nil {
+		return done("(error) "+err.Error(), 0)
+	}
+	return done(formatReply(reply), took)
+}
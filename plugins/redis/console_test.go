@@ -0,0 +1,156 @@
+package redis
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	cases := []struct {
+		line string
+		want []string
+	}{
+		{`SET  key value`, []string{"SET", "key", "value"}},
+		{`SET k "a b\n\x41"`, []string{"SET", "k", "a b\nA"}},
+		{`SET k 'it\'s "raw"\n'`, []string{"SET", "k", `it's "raw"\n`}},
+		{`SET k ""`, []string{"SET", "k", ""}},
+		{`HSET h f"x"`, []string{"HSET", "h", "fx"}},
+	}
+	for _, c := range cases {
+		got, err := splitArgs(c.line)
+		if err != nil || !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("splitArgs(%q) = %q, %v, want %q", c.line, got, err, c.want)
+		}
+	}
+	for _, bad := range []string{`GET "open`, `GET 'open`, `GET "a"b`} {
+		if _, err := splitArgs(bad); err == nil {
+			t.Fatalf("splitArgs(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestFormatReply(t *testing.T) {
+	cases := []struct {
+		reply interface{}
+		want  string
+	}{
+		{nil, "(nil)"},
+		{int64(3), "(integer) 3"},
+		{1.5, "(double) 1.5"},
+		{true, "(true)"},
+		{big.NewInt(42), "(big number) 42"},
+		{errors.New("ERR unknown command"), "(error) ERR unknown command"},
+		{"a\"b\x01", `"a\"b\x01"`},
+		{[]interface{}{}, "(empty array)"},
+		{
+			[]interface{}{"a", []interface{}{"x", int64(2)}},
+			"1) \"a\"\n2) 1) \"x\"\n   2) (integer) 2",
+		},
+		{
+			map[interface{}]interface{}{"b": []interface{}{"x", "y"}, "a": int64(1)},
+			"1# \"a\" => (integer) 1\n2# \"b\" => 1) \"x\"\n          2) \"y\"",
+		},
+	}
+	for _, c := range cases {
+		if got := formatReply(c.reply); got != c.want {
+			t.Fatalf("formatReply(%v) =\n%s\nwant\n%s", c.reply, got, c.want)
+		}
+	}
+}
+
+func TestCommandSafety(t *testing.T) {
+	if !commandIn(writeCommands, []string{"del", "k"}) || commandIn(writeCommands, []string{"GET", "k"}) {
+		t.Fatalf("DEL should be a write, GET should not")
+	}
+	if !commandIn(dangerousCommands, []string{"config", "set", "maxmemory", "1"}) || commandIn(dangerousCommands, []string{"CONFIG", "GET", "*"}) {
+		t.Fatalf("CONFIG SET should be dangerous, CONFIG GET should not")
+	}
+	if parseProtected("true") != protectConfirm || parseProtected("Block") != protectBlock || parseProtected("") != protectOff {
+		t.Fatalf("parseProtected mismatch")
+	}
+}
+
+func TestClassifyCommandFromCommandInfo(t *testing.T) {
+	cmds := map[string]*commandFlags{}
+	entry := func(name string, flags, cats []interface{}, subs ...interface{}) []interface{} {
+		return []interface{}{name, int64(-1), flags, int64(0), int64(0), int64(0), cats, []interface{}{}, []interface{}{}, subs}
+	}
+	storeCommandInfo(cmds, entry("xreadgroup", []interface{}{"write", "blocking"}, []interface{}{"@write", "@stream"}), "")
+	storeCommandInfo(cmds, entry("slowlog", []interface{}{}, []interface{}{"@slow"},
+		entry("slowlog|get", []interface{}{"admin", "loading"}, []interface{}{"@admin", "@dangerous"}),
+		entry("slowlog|reset", []interface{}{"admin", "loading"}, []interface{}{"@admin", "@dangerous"}),
+	), "")
+	storeCommandInfo(cmds, entry("hexpire", []interface{}{"write", "fast"}, []interface{}{"@write", "@hash"}), "")
+	storeCommandInfo(cmds, entry("get", []interface{}{"readonly", "fast"}, []interface{}{"@read"}), "")
+	script := []interface{}{"noscript", "skip_monitor", "may_replicate", "no_mandatory_keys", "stale", "movablekeys"}
+	storeCommandInfo(cmds, entry("eval", script, []interface{}{"@slow", "@scripting"}), "")
+	storeCommandInfo(cmds, entry("fcall", script, []interface{}{"@slow", "@scripting"}), "")
+	storeCommandInfo(cmds, entry("eval_ro", []interface{}{"readonly", "noscript", "skip_monitor", "no_mandatory_keys", "stale", "movablekeys"}, []interface{}{"@slow", "@scripting"}), "")
+	storeCommandInfo(cmds, entry("pfcount", []interface{}{"readonly", "may_replicate"}, []interface{}{"@read", "@hyperloglog"}), "")
+	storeCommandInfo(cmds, nil, "")
+	if !cmds["EVAL"].write || !cmds["FCALL"].write || cmds["EVAL_RO"].write || cmds["PFCOUNT"].write {
+		t.Fatalf("may_replicate without readonly should mark EVAL and FCALL as writes only")
+	}
+	lookup := func(name, sub string) (commandFlags, bool) {
+		key := strings.ToUpper(name)
+		if sub != "" {
+			key += "|" + strings.ToUpper(sub)
+		}
+		if f := cmds[key]; f != nil {
+			return *f, true
+		}
+		return commandFlags{}, false
+	}
+
+	cases := []struct {
+		args             []string
+		write, dangerous bool
+	}{
+		{[]string{"XREADGROUP", "GROUP", "g", "c", "STREAMS", "s", ">"}, true, false},
+		{[]string{"hexpire", "h", "10", "FIELDS", "1", "f"}, true, false},
+		{[]string{"SLOWLOG", "RESET"}, false, true},
+		{[]string{"SLOWLOG", "GET", "10"}, false, false},
+		{[]string{"GET", "k"}, false, false},
+		{[]string{"EVAL", "return redis.call('flushall')", "0"}, true, false},
+		{[]string{"FCALL", "mylib_delete", "1", "k"}, true, false},
+		{[]string{"EVAL_RO", "return 1", "0"}, false, false},
+		{[]string{"PFCOUNT", "hll"}, false, false},
+		{[]string{"SCRIPT", "KILL"}, false, true},
+		{[]string{"DEL", "k"}, true, false},
+	}
+	for _, c := range cases {
+		write, dangerous := classifyCommand(c.args, lookup)
+		if write != c.write || dangerous != c.dangerous {
+			t.Fatalf("classifyCommand(%q) = %v, %v, want %v, %v", c.args, write, dangerous, c.write, c.dangerous)
+		}
+	}
+}
+
+func TestRunCommandSafeMode(t *testing.T) {
+	s := NewService()
+	s.conn = RedisConnection{Host: "h", Port: "6379", Mode: ModeStandalone, Nodes: []string{"h:6379"}}
+
+	s.protected = protectBlock
+	res := s.runCommandLocked(map[string]string{"command": "DEL k"})
+	if res.Confirm != nil || len(s.console) != 1 || s.console[0].Reply != "(error) blocked: write commands are disabled on this protected target" {
+		t.Fatalf("blocked write = %+v, console %+v", res, s.console)
+	}
+
+	s.protected = protectConfirm
+	res = s.runCommandLocked(map[string]string{"command": "DEL k"})
+	if res.Confirm == nil || res.Confirm.Action != "run_command" || res.Confirm.Payload["confirmed"] != "true" {
+		t.Fatalf("protected write = %+v, want confirmation", res)
+	}
+
+	s.protected = protectOff
+	if res := s.runCommandLocked(map[string]string{"command": "FLUSHALL"}); res.Confirm == nil {
+		t.Fatalf("FLUSHALL on unprotected target ran without confirmation")
+	}
+	res = s.runCommandLocked(map[string]string{"command": "SUBSCRIBE ch"})
+	if res.Confirm != nil || len(s.console) != 2 {
+		t.Fatalf("SUBSCRIBE = %+v, want a console error entry", res)
+	}
+}
@@ -15,4 +15,23 @@ const (
 	viewDatabases   = "databases"
 	viewCmdStats    = "commandstats"
 	viewLatency     = "latency"
+	viewNodes       = "nodes"
+	viewConsole     = "console"
+	viewKeyTree     = "keytree"
+	viewValue       = "value"
+
+	viewStreams       = "streams"
+	viewStreamEntries = "stream_entries"
+	viewStreamGroups  = "stream_groups"
+	viewStreamPending = "stream_pending"
+
+	viewBigKeys  = "bigkeys"
+	viewHotspots = "hotspots"
+
+	viewACL    = "acl"
+	viewACLLog = "acl_log"
+
+	viewWatch = "watch"
+
+	viewFunctions = "functions"
 )
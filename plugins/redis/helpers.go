@@ -16,3 +16,21 @@ func parseKeyspace(infoMap map[string]string) string {
 	sort.Strings(dbFields)
 	return strings.Join(dbFields, ", ")
 }
+
+// humanBytes formats a byte count the way INFO's *_human fields do.
+func humanBytes(n int64) string {
+	units := []string{"K", "M", "G", "T"}
+	if n < 1024 {
+		return fmt.Sprintf("%dB", n)
+	}
+	v := float64(n)
+	unit := ""
+	for _, u := range units {
+		if v < 1024 {
+			break
+		}
+		v /= 1024
+		unit = u
+	}
+	return fmt.Sprintf("%.2f%s", v, unit)
+}
@@ -0,0 +1,451 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"omo/pkg/pluginrpc"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// keyPageSize is how many matches a keys page aims for.
+	keyPageSize = 500
+	// keyScanCount is the SCAN COUNT hint; large so sparse patterns move fast.
+	keyScanCount = 1000
+	// keyPageBudget bounds one page so sparse patterns still return.
+	keyPageBudget = 5 * time.Second
+	// keyTreeLimit caps how many keys the prefix tree samples.
+	keyTreeLimit = 10000
+	// keyTreeDepth is how many ":" levels the prefix tree expands.
+	keyTreeDepth = 3
+	// keyTreeChildren caps siblings shown under one prefix.
+	keyTreeChildren = 25
+	// keySeparator splits key namespaces.
+	keySeparator = ":"
+)
+
+// KeyScan is a resumable SCAN MATCH/TYPE across every master.
+type KeyScan struct {
+	Pattern string
+	Type    string
+	Matched int
+
+	node   int
+	cursor uint64
+	done   bool
+}
+
+// Done reports whether every master has been scanned to the end.
+func (s *KeyScan) Done() bool { return s.done }
+
+// ScanNext continues scan until limit keys match, budget elapses, ctx is
+// cancelled or the keyspace ends. A cancelled or expired page is not an
+// error; the keys found so far are returned and the scan can resume.
+func (c *RedisClient) ScanNext(ctx context.Context, scan *KeyScan, limit int, budget time.Duration) ([]string, error) {
+	masters, err := c.masters()
+	if err != nil {
+		return nil, err
+	}
+	pattern := scan.Pattern
+	if pattern == "" {
+		pattern = "*"
+	}
+	deadline := time.Now().Add(budget)
+	var keys []string
+	for !scan.done && len(keys) < limit && time.Now().Before(deadline) {
+		if scan.node >= len(masters) {
+			scan.done = true
+			break
+		}
+		m := masters[scan.node]
+		var (
+			batch []string
+			next  uint64
+		)
+		if scan.Type != "" {
+			batch, next, err = m.ScanType(ctx, scan.cursor, pattern, keyScanCount, scan.Type).Result()
+		} else {
+			batch, next, err = m.Scan(ctx, scan.cursor, pattern, keyScanCount).Result()
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return keys, fmt.Errorf("error scanning keys on %s: %v", m.Options().Addr, err)
+		}
+		keys = append(keys, batch...)
+		scan.Matched += len(batch)
+		scan.cursor = next
+		if next == 0 {
+			scan.node++
+			scan.done = scan.node >= len(masters)
+		}
+	}
+	return keys, nil
+}
+
+// KeyCount sums DBSIZE over every master.
+func (c *RedisClient) KeyCount() (int64, error) {
+	masters, err := c.masters()
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, m := range masters {
+		n, err := m.DBSize(c.ctx).Result()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get dbsize from %s: %v", m.Options().Addr, err)
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// KeyInfos returns type, TTL and size for keys. TYPE and TTL are pipelined
+// for the whole page; size still costs one call per key.
+func (c *RedisClient) KeyInfos(keys []string) ([]map[string]string, error) {
+	if !c.connected || c.client == nil {
+		return nil, errors.New("not connected to any Redis server")
+	}
+	types := make([]*redis.StatusCmd, len(keys))
+	ttls := make([]*redis.DurationCmd, len(keys))
+	_, err := c.client.Pipelined(c.ctx, func(p redis.Pipeliner) error {
+		for i, key := range keys {
+			types[i] = p.Type(c.ctx, key)
+			ttls[i] = p.TTL(c.ctx, key)
+		}
+		return nil
+	})
+	// Per-key failures show as "?"; only a pipeline that failed outright
+	// (no reply at all) is an error.
+	if err != nil && len(keys) > 0 && types[0].Err() != nil && types[len(keys)-1].Err() != nil {
+		return nil, fmt.Errorf("failed to get key info: %v", err)
+	}
+	out := make([]map[string]string, len(keys))
+	for i, key := range keys {
+		keyType, err := types[i].Result()
+		if err != nil {
+			out[i] = map[string]string{"type": "?", "ttl": "?", "size": "?"}
+			continue
+		}
+		ttl := "?"
+		if d, err := ttls[i].Result(); err == nil {
+			ttl = formatTTL(d)
+		}
+		out[i] = map[string]string{
+			"type": keyType,
+			"ttl":  ttl,
+			"size": c.getKeySize(key, keyType),
+		}
+	}
+	return out, nil
+}
+
+// keyTreeNode is one row of the prefix tree: a namespace and how many
+// sampled keys live under it.
+type keyTreeNode struct {
+	Prefix string
+	Depth  int
+	Count  int
+	Hidden int // siblings folded into this "more" row
+}
+
+// buildKeyTree groups keys by ":"-separated namespaces up to depth levels.
+// Siblings are ordered by count, largest first, and only the top
+// keyTreeChildren are kept; the rest fold into one row per parent. Keys
+// without a separator are counted under an empty top-level prefix.
+func buildKeyTree(keys []string, depth int) []keyTreeNode {
+	var (
+		out  []keyTreeNode
+		walk func(prefix string, keys []string, level int)
+	)
+	walk = func(prefix string, keys []string, level int) {
+		groups := map[string][]string{}
+		loose := 0
+		for _, key := range keys {
+			rest := strings.TrimPrefix(key, prefix)
+			i := strings.Index(rest, keySeparator)
+			if i < 0 {
+				loose++
+				continue
+			}
+			child := prefix + rest[:i+len(keySeparator)]
+			groups[child] = append(groups[child], key)
+		}
+		names := make([]string, 0, len(groups))
+		for name := range groups {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			a, b := len(groups[names[i]]), len(groups[names[j]])
+			if a != b {
+				return a > b
+			}
+			return names[i] < names[j]
+		})
+		for i, name := range names {
+			if i == keyTreeChildren {
+				rest := 0
+				for _, n := range names[i:] {
+					rest += len(groups[n])
+				}
+				out = append(out, keyTreeNode{Prefix: prefix, Depth: level, Count: rest, Hidden: len(names) - i})
+				break
+			}
+			out = append(out, keyTreeNode{Prefix: name, Depth: level, Count: len(groups[name])})
+			if level+1 < depth {
+				walk(name, groups[name], level+1)
+			}
+		}
+		if level == 0 && loose > 0 {
+			out = append(out, keyTreeNode{Depth: 0, Count: loose})
+		}
+	}
+	walk("", keys, 0)
+	return out
+}
+
+// keyTreeRows renders nodes, indenting each prefix by its depth. Column 0
+// holds the prefix itself so Enter can open it.
+func keyTreeRows(nodes []keyTreeNode, total int) [][]string {
+	rows := make([][]string, 0, len(nodes))
+	for _, n := range nodes {
+		label := n.Prefix
+		switch {
+		case n.Hidden > 0:
+			label = fmt.Sprintf("… %d more", n.Hidden)
+		case n.Prefix == "":
+			label = "(no namespace)"
+		}
+		share := "-"
+		if total > 0 {
+			share = fmt.Sprintf("%.1f%%", float64(n.Count)*100/float64(total))
+		}
+		rows = append(rows, []string{strings.Repeat("  ", n.Depth) + label, fmt.Sprintf("%d", n.Count), share})
+	}
+	return rows
+}
+
+// globEscape quotes SCAN glob metacharacters so prefix matches literally.
+func globEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`*?[]\`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// beginScanPage returns the context for one scan page; cancel_scan cancels
+// it without waiting for s.mu.
+func (s *Service) beginScanPage() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.scanMu.Lock()
+	s.scanCancel = cancel
+	s.scanMu.Unlock()
+	return ctx
+}
+
+func (s *Service) endScanPage() {
+	s.scanMu.Lock()
+	if s.scanCancel != nil {
+		s.scanCancel()
+		s.scanCancel = nil
+	}
+	s.scanMu.Unlock()
+}
+
+// cancelScan stops the page in flight, if any. It must not take s.mu: the
+// page being cancelled holds it.
+func (s *Service) cancelScan() bool {
+	s.scanMu.Lock()
+	defer s.scanMu.Unlock()
+	if s.scanCancel == nil {
+		return false
+	}
+	s.scanCancel()
+	s.scanCancel = nil
+	return true
+}
+
+// startKeyScanLocked restarts the keys view scan with the current search.
+func (s *Service) startKeyScanLocked() {
+	s.scan = &KeyScan{Pattern: s.keyPattern, Type: s.keyType}
+	s.keyRows = nil
+	s.keyTotal = -1
+	if n, err := s.client.KeyCount(); err == nil {
+		s.keyTotal = n
+	}
+}
+
+// nextKeyPageLocked scans one page, appends its rows to keyRows and returns
+// them. cancelled reports that cancel_scan cut the page short.
+func (s *Service) nextKeyPageLocked() (rows [][]string, cancelled bool, err error) {
+	ctx := s.beginScanPage()
+	defer s.endScanPage()
+	keys, err := s.client.ScanNext(ctx, s.scan, keyPageSize, keyPageBudget)
+	if err != nil {
+		return nil, false, err
+	}
+	infos, err := s.client.KeyInfos(keys)
+	if err != nil {
+		return nil, false, err
+	}
+	rows = make([][]string, len(keys))
+	for i, key := range keys {
+		rows[i] = []string{key, infos[i]["type"], infos[i]["ttl"], infos[i]["size"]}
+	}
+	s.keyRows = append(s.keyRows, rows...)
+	return rows, ctx.Err() != nil, nil
+}
+
+// keysViewLocked renders the rows scanned so far without scanning more.
+func (s *Service) keysViewLocked(note string) pluginrpc.ViewData {
+	rows := s.keyRows
+	state := "partial - PgDn for more"
+	if s.scan.Done() {
+		state = "complete"
+		rows = pluginrpc.EnsureRows(rows, []string{"-", "-", "-", "No keys found"})
+	}
+	total := "?"
+	if s.keyTotal >= 0 {
+		total = fmt.Sprintf("%d", s.keyTotal)
+	}
+	typ := s.scan.Type
+	if typ == "" {
+		typ = "any"
+	}
+	extra := fmt.Sprintf("Pattern: %s\nType: %s\nMatched: %d of %s keys\nScan: %s", s.scan.Pattern, typ, s.scan.Matched, total, state)
+	if note != "" {
+		extra += "\n" + note
+	}
+	view := ui.Connected(viewKeys, "Redis Keys", s.baseInfo(extra), []string{"Key", "Type", "TTL", "Size"}, rows, "Key", keysActions()...)
+	view.LoadMoreAction = "load_more_keys"
+	view.More = !s.scan.Done()
+	return view
+}
+
+func (s *Service) viewKeysLocked() (pluginrpc.ViewData, error) {
+	s.startKeyScanLocked()
+	if _, _, err := s.nextKeyPageLocked(); err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	return s.keysViewLocked(""), nil
+}
+
+// loadMoreKeysLocked serves PgDn on the keys view; offset 0 restarts the scan.
+func (s *Service) loadMoreKeysLocked(payload map[string]string) pluginrpc.ActionResult {
+	if err := s.ensureConnectedLocked(); err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	if s.scan == nil || payload["offset"] == "0" {
+		s.startKeyScanLocked()
+	}
+	rows, cancelled, err := s.nextKeyPageLocked()
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	if cancelled {
+		// cancel_scan repaints keyRows, which already hold this page.
+		rows = nil
+	}
+	view := s.keysViewLocked("")
+	view.Rows = rows
+	return pluginrpc.ActionResult{OK: true, Message: fmt.Sprintf("matched %d", s.scan.Matched), Next: &view}
+}
+
+// searchKeysLocked sets the pattern and type filter and rescans viewID.
+func (s *Service) searchKeysLocked(viewID, pattern, typ string) pluginrpc.ActionResult {
+	s.keyPattern = strings.TrimSpace(pattern)
+	if s.keyPattern == "" {
+		s.keyPattern = "*"
+	}
+	s.keyType = strings.ToLower(strings.TrimSpace(typ))
+	view, err := s.buildViewLocked(viewID)
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	return pluginrpc.ActionResult{OK: true, Message: "searching " + s.keyPattern, Next: &view}
+}
+
+// cancelScanAction handles cancel_scan. It runs outside s.mu so it can
+// interrupt the page holding it, then repaints what was found.
+func (s *Service) cancelScanAction() pluginrpc.ActionResult {
+	note := "No scan running"
+	if s.cancelScan() {
+		note = "Scan cancelled"
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var view pluginrpc.ViewData
+	switch {
+	case s.currentView == viewKeyTree && s.tree != nil:
+		view = s.keyTreeViewLocked(note)
+	case s.currentView == viewKeys && s.scan != nil:
+		view = s.keysViewLocked(note)
+	default:
+		return pluginrpc.ActionResult{OK: true, Message: strings.ToLower(note)}
+	}
+	return pluginrpc.ActionResult{OK: true, Message: strings.ToLower(note), Next: &view}
+}
+
+// openPrefixLocked lists the keys under a prefix tree namespace.
+func (s *Service) openPrefixLocked(prefix string) pluginrpc.ActionResult {
+	prefix = strings.TrimSpace(prefix)
+	if !strings.HasSuffix(prefix, keySeparator) {
+		return pluginrpc.ActionResult{OK: false, Message: "select a namespace"}
+	}
+	return s.searchKeysLocked(viewKeys, globEscape(prefix)+"*", s.keyType)
+}
+
+// keyTree is the last prefix tree sample, kept so cancel_scan can repaint
+// it without scanning again.
+type keyTree struct {
+	rows    [][]string
+	sampled int
+	done    bool
+}
+
+func (s *Service) viewKeyTreeLocked() (pluginrpc.ViewData, error) {
+	scan := &KeyScan{Pattern: s.keyPattern, Type: s.keyType}
+	if scan.Pattern == "" {
+		scan.Pattern = "*"
+	}
+	ctx := s.beginScanPage()
+	keys, err := s.client.ScanNext(ctx, scan, keyTreeLimit, keyPageBudget)
+	s.endScanPage()
+	if err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	s.tree = &keyTree{
+		rows:    keyTreeRows(buildKeyTree(keys, keyTreeDepth), len(keys)),
+		sampled: len(keys),
+		done:    scan.Done(),
+	}
+	return s.keyTreeViewLocked(""), nil
+}
+
+func (s *Service) keyTreeViewLocked(note string) pluginrpc.ViewData {
+	pattern := s.keyPattern
+	if pattern == "" {
+		pattern = "*"
+	}
+	sample := fmt.Sprintf("%d keys (all matches)", s.tree.sampled)
+	if !s.tree.done {
+		sample = fmt.Sprintf("first %d matches", s.tree.sampled)
+	}
+	extra := fmt.Sprintf("Pattern: %s\nSampled: %s", pattern, sample)
+	if note != "" {
+		extra += "\n" + note
+	}
+	rows := pluginrpc.EnsureRows(s.tree.rows, []string{"No keys found", "-", "-"})
+	return ui.Connected(viewKeyTree, "Redis Key Tree", s.baseInfo(extra), []string{"Prefix", "Keys", "Share"}, rows, "Prefix", keyTreeActions()...)
+}
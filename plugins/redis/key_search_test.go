@@ -0,0 +1,47 @@
+package redis
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestBuildKeyTree(t *testing.T) {
+	keys := []string{
+		"user:1:name", "user:1:email", "user:2:name",
+		"session:abc", "session:def", "session:ghi", "session:jkl",
+		"counter",
+	}
+	got := keyTreeRows(buildKeyTree(keys, 2), len(keys))
+	want := [][]string{
+		{"session:", "4", "50.0%"},
+		{"user:", "3", "37.5%"},
+		{"  user:1:", "2", "25.0%"},
+		{"  user:2:", "1", "12.5%"},
+		{"(no namespace)", "1", "12.5%"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("rows = %v, want %v", got, want)
+	}
+}
+
+func TestBuildKeyTreeFoldsSiblings(t *testing.T) {
+	var keys []string
+	for i := 0; i < keyTreeChildren+3; i++ {
+		keys = append(keys, fmt.Sprintf("k%02d:x", i))
+	}
+	nodes := buildKeyTree(keys, 1)
+	if len(nodes) != keyTreeChildren+1 {
+		t.Fatalf("nodes = %d, want %d", len(nodes), keyTreeChildren+1)
+	}
+	last := nodes[len(nodes)-1]
+	if last.Hidden != 3 || last.Count != 3 {
+		t.Fatalf("fold row = %+v, want 3 hidden prefixes holding 3 keys", last)
+	}
+}
+
+func TestGlobEscape(t *testing.T) {
+	if got, want := globEscape(`a*b?[c]\:`), `a\*b\?\[c\]\\:`; got != want {
+		t.Fatalf("globEscape = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,507 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"omo/pkg/pluginrpc"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// watchLogLines is how many events the watch log keeps.
+	watchLogLines = 1000
+	// watchRateWindow is the window, in seconds, rates are averaged over.
+	watchRateWindow = 10
+	// watchPollInterval is how often the watch view refreshes while live.
+	watchPollInterval = time.Second
+	// notifyConfig is the CONFIG parameter that enables notifications.
+	notifyConfig = "notify-keyspace-events"
+)
+
+// notifyFlags returns the notify-keyspace-events value that adds keyevent
+// notifications for every event class to current, and whether it differs.
+func notifyFlags(current string) (string, bool) {
+	has := func(c rune) bool { return strings.ContainsRune(current, c) }
+	classesOK := has('A') || (has('g') && has('$') && has('x') && has('e'))
+	if has('E') && classesOK {
+		return current, false
+	}
+	next := current
+	if !has('E') {
+		next += "E"
+	}
+	if !classesOK {
+		next += "A"
+	}
+	return next, true
+}
+
+// globMatch reports whether s matches a Redis glob pattern: *, ?, [abc],
+// [^a], [a-z] and \ escapes.
+func globMatch(pattern, s string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for len(pattern) > 0 && pattern[0] == '*' {
+				pattern = pattern[1:]
+			}
+			if pattern == "" {
+				return true
+			}
+			for i := 0; i <= len(s); i++ {
+				if globMatch(pattern, s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if s == "" {
+				return false
+			}
+		case '[':
+			if s == "" {
+				return false
+			}
+			end := strings.IndexByte(pattern[1:], ']')
+			if end < 0 {
+				return pattern == s
+			}
+			class := pattern[1 : end+1]
+			negate := strings.HasPrefix(class, "^")
+			if negate {
+				class = class[1:]
+			}
+			matched := false
+			for i := 0; i < len(class); i++ {
+				if i+2 < len(class) && class[i+1] == '-' {
+					if class[i] <= s[0] && s[0] <= class[i+2] {
+						matched = true
+					}
+					i += 2
+				} else if class[i] == s[0] {
+					matched = true
+				}
+			}
+			if matched == negate {
+				return false
+			}
+			pattern = pattern[end+1:]
+		case '\\':
+			if len(pattern) > 1 {
+				pattern = pattern[1:]
+			}
+			fallthrough
+		default:
+			if s == "" || pattern[0] != s[0] {
+				return false
+			}
+		}
+		pattern, s = pattern[1:], s[1:]
+	}
+	return s == ""
+}
+
+// rateCounter counts events in one-second buckets over watchRateWindow.
+type rateCounter struct {
+	buckets [watchRateWindow]int
+	last    int64 // unix second of the newest bucket
+}
+
+func (r *rateCounter) advance(now int64) {
+	if now-r.last >= watchRateWindow {
+		r.buckets = [watchRateWindow]int{}
+	} else {
+		for s := r.last + 1; s <= now; s++ {
+			r.buckets[s%watchRateWindow] = 0
+		}
+	}
+	if now > r.last {
+		r.last = now
+	}
+}
+
+func (r *rateCounter) add(now time.Time) {
+	r.advance(now.Unix())
+	r.buckets[now.Unix()%watchRateWindow]++
+}
+
+// rate is the average events per second over the window ending at now.
+func (r *rateCounter) rate(now time.Time) float64 {
+	r.advance(now.Unix())
+	total := 0
+	for _, n := range r.buckets {
+		total += n
+	}
+	return float64(total) / watchRateWindow
+}
+
+// keyEvent is one keyspace notification.
+type keyEvent struct {
+	At    time.Time
+	Event string
+	Key   string
+}
+
+// keyWatch follows __keyevent@N__:* on every master. Its state is guarded
+// by mu; the goroutines never take Service.mu.
+type keyWatch struct {
+	mu       sync.Mutex
+	db       int
+	pattern  string          // key glob; "" = every key
+	events   map[string]bool // event names to log; nil = all
+	paused   bool
+	started  time.Time
+	log      []keyEvent
+	counts   map[string]int
+	rates    map[string]*rateCounter
+	restore  string // notify-keyspace-events value to put back on stop
+	changed  bool
+	subs     []*redis.PubSub
+	cancel   context.CancelFunc
+	finished sync.WaitGroup
+}
+
+// record counts an event and, unless it is filtered out or the log is
+// paused, appends it to the log.
+func (w *keyWatch) record(ev keyEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.pattern != "" && !globMatch(w.pattern, ev.Key) {
+		return
+	}
+	if w.events != nil && !w.events[ev.Event] {
+		return
+	}
+	w.counts[ev.Event]++
+	rc := w.rates[ev.Event]
+	if rc == nil {
+		rc = &rateCounter{}
+		w.rates[ev.Event] = rc
+	}
+	rc.add(ev.At)
+	if w.paused {
+		return
+	}
+	w.log = append(w.log, ev)
+	if len(w.log) > watchLogLines {
+		w.log = w.log[len(w.log)-watchLogLines:]
+	}
+}
+
+// eventColor tints the common events in the log.
+func eventColor(event string) string {
+	switch event {
+	case "set", "hset", "lpush", "rpush", "sadd", "zadd", "xadd", "incrby", "incrbyfloat", "append", "setrange":
+		return "green"
+	case "del", "unlink":
+		return "red"
+	case "expired":
+		return "yellow"
+	case "evicted":
+		return "orange"
+	}
+	return "white"
+}
+
+// body renders the log, oldest first.
+func (w *keyWatch) body() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.log) == 0 {
+		return "(waiting for key events…)"
+	}
+	var b strings.Builder
+	for _, ev := range w.log {
+		fmt.Fprintf(&b, "%s [%s]%-10s[white] %s\n", ev.At.Format("15:04:05.000"), eventColor(ev.Event), ev.Event, ev.Key)
+	}
+	return b.String()
+}
+
+// summary is the info panel text: state, filters and per-event counters.
+func (w *keyWatch) summary(now time.Time) string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	state := "live"
+	if w.cancel == nil {
+		state = "stopped"
+	} else if w.paused {
+		state = "paused"
+	}
+	pattern := w.pattern
+	if pattern == "" {
+		pattern = "*"
+	}
+	events := "all"
+	if w.events != nil {
+		names := make([]string, 0, len(w.events))
+		for e := range w.events {
+			names = append(names, e)
+		}
+		sort.Strings(names)
+		events = strings.Join(names, ",")
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Watch: %s (%s)\nChannel: __keyevent@%d__:*\nKeys: %s\nEvents: %s",
+		state, now.Sub(w.started).Round(time.Second), w.db, pattern, events)
+	if w.changed {
+		fmt.Fprintf(&b, "\n%s: %q, restored on stop", notifyConfig, w.restore)
+	}
+	names := make([]string, 0, len(w.counts))
+	for e := range w.counts {
+		names = append(names, e)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if w.counts[names[i]] != w.counts[names[j]] {
+			return w.counts[names[i]] > w.counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	for _, e := range names {
+		fmt.Fprintf(&b, "\n%-10s %6d  %.1f/s", e, w.counts[e], w.rates[e].rate(now))
+	}
+	return b.String()
+}
+
+// NotifyConfig reads notify-keyspace-events.
+func (c *RedisClient) NotifyConfig() (string, error) {
+	cfg, err := c.GetConfig(notifyConfig)
+	if err != nil {
+		return "", err
+	}
+	return cfg[notifyConfig], nil
+}
+
+// SetNotifyConfig sets notify-keyspace-events on every node.
+func (c *RedisClient) SetNotifyConfig(value string) error {
+	return c.eachNode(func(ctx context.Context, n redis.UniversalClient) error {
+		if err := n.ConfigSet(ctx, notifyConfig, value).Err(); err != nil {
+			return fmt.Errorf("CONFIG SET %s failed: %v", notifyConfig, err)
+		}
+		return nil
+	})
+}
+
+// startWatchLocked subscribes to key events of the current database on
+// every master. restore is the config value to put back on stop when the
+// watch changed it.
+func (s *Service) startWatchLocked(pattern string, events map[string]bool, restore string, changed bool) error {
+	s.stopWatchLocked()
+	masters, err := s.client.masters()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &keyWatch{
+		db:      s.conn.Database,
+		pattern: pattern,
+		events:  events,
+		started: time.Now(),
+		counts:  map[string]int{},
+		rates:   map[string]*rateCounter{},
+		restore: restore,
+		changed: changed,
+		cancel:  cancel,
+	}
+	channel := fmt.Sprintf("__keyevent@%d__:*", w.db)
+	for _, m := range masters {
+		sub := m.PSubscribe(ctx, channel)
+		if _, err := sub.Receive(ctx); err != nil {
+			sub.Close()
+			for _, other := range w.subs {
+				other.Close()
+			}
+			cancel()
+			return fmt.Errorf("failed to subscribe to %s on %s: %v", channel, m.Options().Addr, err)
+		}
+		w.subs = append(w.subs, sub)
+		w.finished.Add(1)
+		go func() {
+			defer w.finished.Done()
+			ch := sub.Channel()
+			for {
+				select {
+				case msg, ok := <-ch:
+					if !ok {
+						return
+					}
+					_, event, _ := strings.Cut(msg.Channel, "__:")
+					w.record(keyEvent{At: time.Now(), Event: event, Key: msg.Payload})
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	s.watch = w
+	return nil
+}
+
+// stopWatchLocked unsubscribes and puts notify-keyspace-events back if the
+// watch changed it. The log stays readable.
+func (s *Service) stopWatchLocked() error {
+	w := s.watch
+	if w == nil || w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+	for _, sub := range w.subs {
+		sub.Close()
+	}
+	w.finished.Wait()
+	w.mu.Lock()
+	w.cancel, w.subs, w.paused = nil, nil, false
+	changed := w.changed
+	w.changed = false
+	w.mu.Unlock()
+	if changed && s.client.IsConnected() {
+		return s.client.SetNotifyConfig(w.restore)
+	}
+	return nil
+}
+
+func watchActions() []pluginrpc.KeyBinding {
+	return []pluginrpc.KeyBinding{
+		{Key: "N", Label: "Start", Action: "watch_start"},
+		{Key: "C", Label: "Stop", Action: "watch_stop"},
+		{Key: "S", Label: "Pause/Resume", Action: "watch_pause"},
+		{Key: "M", Label: "Filter", Action: "watch_filter"},
+		{Key: "D", Label: "Clear", Action: "watch_clear"},
+	}
+}
+
+func (s *Service) viewWatchLocked() (pluginrpc.ViewData, error) {
+	if s.watch == nil {
+		return ui.Logs(viewWatch, "Redis Keyspace Watch",
+			s.baseInfo("Watch: stopped\nPress N to watch key events"), "(not watching)", watchActions()...), nil
+	}
+	view := ui.Logs(viewWatch, "Redis Keyspace Watch", s.baseInfo(s.watch.summary(time.Now())), s.watch.body(), watchActions()...)
+	if s.watch.cancel != nil {
+		view.RefreshAfter = watchPollInterval
+	}
+	return view, nil
+}
+
+// parseWatchEvents reads a comma or space separated event list; blank or
+// "*" means every event.
+func parseWatchEvents(text string) map[string]bool {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return r == ',' || r == ' ' })
+	if len(fields) == 0 || (len(fields) == 1 && fields[0] == "*") {
+		return nil
+	}
+	out := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		out[f] = true
+	}
+	return out
+}
+
+// watchActionLocked runs the watch_* actions.
+func (s *Service) watchActionLocked(action string, payload map[string]string) pluginrpc.ActionResult {
+	view := func(msg string) pluginrpc.ActionResult {
+		next, err := s.buildViewLocked(viewWatch)
+		if err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		return pluginrpc.ActionResult{OK: true, Message: msg, Next: &next}
+	}
+	switch action {
+	case "watch_start":
+		if err := s.ensureConnectedLocked(); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		// Stop a running watch first so its config change is undone and
+		// current is the value to restore, not the one the watch set.
+		if err := s.stopWatchLocked(); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: "could not restore " + notifyConfig + ": " + err.Error()}
+		}
+		current, err := s.client.NotifyConfig()
+		if err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		pattern := strings.TrimSpace(payload["pattern"])
+		if pattern == "*" {
+			pattern = ""
+		}
+		events := parseWatchEvents(payload["events"])
+		next, change := notifyFlags(current)
+		if change {
+			if s.protected == protectBlock {
+				return pluginrpc.ActionResult{OK: false, Message: fmt.Sprintf("blocked: %s is %q and this target is protected", notifyConfig, current)}
+			}
+			if payload["confirmed"] != "true" {
+				body := fmt.Sprintf("Keyspace notifications are off or partial on %s (%s = %q).\n\nSet it to %q while watching? The old value is restored when the watch stops. Notifications cost some CPU on a busy server.",
+					s.conn.Label(), notifyConfig, current, next)
+				if s.protected != protectOff {
+					body += "\n\nThis target is protected."
+				}
+				confirmed := map[string]string{"pattern": payload["pattern"], "events": payload["events"], "confirmed": "true"}
+				return pluginrpc.ActionResult{OK: true, Confirm: &pluginrpc.Confirm{
+					Title: "Enable Notifications", Body: body, Action: action, Payload: confirmed,
+				}}
+			}
+			if err := s.client.SetNotifyConfig(next); err != nil {
+				return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+			}
+		}
+		if err := s.startWatchLocked(pattern, events, current, change); err != nil {
+			if change {
+				_ = s.client.SetNotifyConfig(current)
+			}
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		return view(fmt.Sprintf("watching key events in db%d", s.conn.Database))
+
+	case "watch_stop":
+		if s.watch == nil || s.watch.cancel == nil {
+			return pluginrpc.ActionResult{OK: false, Message: "not watching"}
+		}
+		if err := s.stopWatchLocked(); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: "stopped, but could not restore " + notifyConfig + ": " + err.Error()}
+		}
+		return view("watch stopped")
+
+	case "watch_pause":
+		if s.watch == nil || s.watch.cancel == nil {
+			return pluginrpc.ActionResult{OK: false, Message: "not watching"}
+		}
+		s.watch.mu.Lock()
+		s.watch.paused = !s.watch.paused
+		msg := "log resumed"
+		if s.watch.paused {
+			msg = "log paused; counters keep running"
+		}
+		s.watch.mu.Unlock()
+		return view(msg)
+
+	case "watch_filter":
+		if s.watch == nil {
+			return pluginrpc.ActionResult{OK: false, Message: "not watching"}
+		}
+		pattern := strings.TrimSpace(payload["pattern"])
+		if pattern == "*" {
+			pattern = ""
+		}
+		s.watch.mu.Lock()
+		s.watch.pattern, s.watch.events = pattern, parseWatchEvents(payload["events"])
+		s.watch.mu.Unlock()
+		return view("filter applied to new events")
+
+	case "watch_clear":
+		if s.watch == nil {
+			return pluginrpc.ActionResult{OK: false, Message: "not watching"}
+		}
+		s.watch.mu.Lock()
+		s.watch.log = nil
+		s.watch.counts = map[string]int{}
+		s.watch.rates = map[string]*rateCounter{}
+		s.watch.mu.Unlock()
+		return view("log cleared")
+	}
+	return pluginrpc.ActionResult{OK: false, Message: "unknown action: " + action}
+}
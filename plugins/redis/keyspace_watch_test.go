@@ -0,0 +1,85 @@
+package redis
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotifyFlags(t *testing.T) {
+	cases := []struct {
+		current, want string
+		change        bool
+	}{
+		{"", "EA", true},
+		{"KEA", "KEA", false},
+		{"Ex", "ExA", true},
+		{"AK", "AKE", true},
+		{"Eg$xe", "Eg$xe", false},
+	}
+	for _, c := range cases {
+		got, change := notifyFlags(c.current)
+		if got != c.want || change != c.change {
+			t.Fatalf("notifyFlags(%q) = %q, %v, want %q, %v", c.current, got, change, c.want, c.change)
+		}
+	}
+}
+
+func TestGlobMatch(t *testing.T) {
+	cases := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"*", "", true},
+		{"user:*", "user:1", true},
+		{"user:*", "order:1", false},
+		{"user:?", "user:12", false},
+		{"*:[0-9]", "a:b:7", true},
+		{"[^a]x", "ax", false},
+		{"[^a]x", "bx", true},
+		{"a\\*b", "a*b", true},
+		{"a\\*b", "axb", false},
+		{"*mid*", "xmidy", true},
+	}
+	for _, c := range cases {
+		if got := globMatch(c.pattern, c.s); got != c.want {
+			t.Fatalf("globMatch(%q, %q) = %v, want %v", c.pattern, c.s, got, c.want)
+		}
+	}
+}
+
+func TestRateCounter(t *testing.T) {
+	base := time.Unix(1000, 0)
+	var r rateCounter
+	for i := 0; i < 20; i++ {
+		r.add(base)
+	}
+	r.add(base.Add(3 * time.Second))
+	if got := r.rate(base.Add(3 * time.Second)); got != 2.1 {
+		t.Fatalf("rate = %v, want 2.1", got)
+	}
+	if got := r.rate(base.Add(watchRateWindow * time.Second)); got != 0.1 {
+		t.Fatalf("rate after window = %v, want 0.1", got)
+	}
+	if got := r.rate(base.Add(time.Minute)); got != 0 {
+		t.Fatalf("rate after a minute = %v, want 0", got)
+	}
+}
+
+func TestKeyWatchRecord(t *testing.T) {
+	w := &keyWatch{pattern: "user:*", events: parseWatchEvents("set, del"), counts: map[string]int{}, rates: map[string]*rateCounter{}}
+	now := time.Now()
+	w.record(keyEvent{At: now, Event: "set", Key: "user:1"})
+	w.record(keyEvent{At: now, Event: "expired", Key: "user:2"})
+	w.record(keyEvent{At: now, Event: "del", Key: "order:1"})
+	w.paused = true
+	w.record(keyEvent{At: now, Event: "del", Key: "user:3"})
+	if len(w.log) != 1 || w.log[0].Key != "user:1" {
+		t.Fatalf("log = %+v, want only user:1", w.log)
+	}
+	if w.counts["set"] != 1 || w.counts["del"] != 1 || w.counts["expired"] != 0 {
+		t.Fatalf("counts = %v, want set=1 del=1", w.counts)
+	}
+	if parseWatchEvents(" * ") != nil {
+		t.Fatalf("parseWatchEvents(*) should mean every event")
+	}
+}
@@ -0,0 +1,507 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/pluginrpc"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// bigKeysTop is how many of the largest keys a report keeps.
+	bigKeysTop = 100
+	// memBatch is how many keys one MEMORY USAGE pipeline measures.
+	memBatch = 200
+	// memSamples is the MEMORY USAGE SAMPLES count for aggregate types.
+	memSamples = 5
+	// memDefaultLimit is the default number of keys an analysis samples.
+	memDefaultLimit = 100000
+	// memPollInterval is how often a running analysis view refreshes.
+	memPollInterval = 2 * time.Second
+	// noNamespace labels keys without a ":" separator.
+	noNamespace = "(no namespace)"
+)
+
+// Analysis states.
+const (
+	memRunning   = "running"
+	memDone      = "done"
+	memCancelled = "cancelled"
+	memFailed    = "failed"
+)
+
+// bigKey is one measured key.
+type bigKey struct {
+	Key   string        `json:"key"`
+	Type  string        `json:"type"`
+	Bytes int64         `json:"bytes"`
+	TTL   time.Duration `json:"ttl"` // -1 = no expiry
+}
+
+// prefixStat totals the keys of one namespace.
+type prefixStat struct {
+	Prefix       string `json:"prefix"`
+	Keys         int    `json:"keys"`
+	Bytes        int64  `json:"bytes"`
+	NoTTL        int    `json:"no_ttl"`
+	Biggest      string `json:"biggest"`
+	BiggestBytes int64  `json:"biggest_bytes"`
+}
+
+// memReport is what an analysis has found so far.
+type memReport struct {
+	Target     string                 `json:"target"`
+	DB         int                    `json:"db"`
+	Started    time.Time              `json:"started"`
+	Finished   time.Time              `json:"finished,omitempty"`
+	State      string                 `json:"state"`
+	Err        string                 `json:"error,omitempty"`
+	Limit      int                    `json:"limit"`
+	DBSize     int64                  `json:"dbsize"`
+	Sampled    int                    `json:"sampled"`
+	Bytes      int64                  `json:"bytes"`
+	NoTTL      int                    `json:"no_ttl"`
+	NoTTLBytes int64                  `json:"no_ttl_bytes"`
+	Top        []bigKey               `json:"top"`
+	Prefixes   map[string]*prefixStat `json:"-"`
+}
+
+// add folds one measured key into the report.
+func (r *memReport) add(k bigKey) {
+	r.Sampled++
+	r.Bytes += k.Bytes
+	if k.TTL < 0 {
+		r.NoTTL++
+		r.NoTTLBytes += k.Bytes
+	}
+
+	ns := keyNamespace(k.Key)
+	if r.Prefixes == nil {
+		r.Prefixes = map[string]*prefixStat{}
+	}
+	p := r.Prefixes[ns]
+	if p == nil {
+		p = &prefixStat{Prefix: ns}
+		r.Prefixes[ns] = p
+	}
+	p.Keys++
+	p.Bytes += k.Bytes
+	if k.TTL < 0 {
+		p.NoTTL++
+	}
+	if k.Bytes > p.BiggestBytes {
+		p.Biggest, p.BiggestBytes = k.Key, k.Bytes
+	}
+
+	if len(r.Top) == bigKeysTop && k.Bytes <= r.Top[len(r.Top)-1].Bytes {
+		return
+	}
+	i := sort.Search(len(r.Top), func(i int) bool { return r.Top[i].Bytes < k.Bytes })
+	r.Top = append(r.Top, bigKey{})
+	copy(r.Top[i+1:], r.Top[i:])
+	r.Top[i] = k
+	if len(r.Top) > bigKeysTop {
+		r.Top = r.Top[:bigKeysTop]
+	}
+}
+
+// prefixes returns the namespaces by total bytes, largest first.
+func (r *memReport) prefixes() []prefixStat {
+	out := make([]prefixStat, 0, len(r.Prefixes))
+	for _, p := range r.Prefixes {
+		out = append(out, *p)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Bytes != out[j].Bytes {
+			return out[i].Bytes > out[j].Bytes
+		}
+		return out[i].Prefix < out[j].Prefix
+	})
+	return out
+}
+
+// keyNamespace is the key up to and including its first ":".
+func keyNamespace(key string) string {
+	if i := strings.Index(key, keySeparator); i >= 0 {
+		return key[:i+len(keySeparator)]
+	}
+	return noNamespace
+}
+
+// memJob is a background analysis. The report is guarded by mu; the
+// goroutine never takes Service.mu.
+type memJob struct {
+	mu     sync.Mutex
+	report memReport
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// snapshot copies the report for rendering.
+func (j *memJob) snapshot() memReport {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	r := j.report
+	r.Top = append([]bigKey(nil), j.report.Top...)
+	r.Prefixes = make(map[string]*prefixStat, len(j.report.Prefixes))
+	for k, p := range j.report.Prefixes {
+		cp := *p
+		r.Prefixes[k] = &cp
+	}
+	return r
+}
+
+// MeasureKeys pipelines TYPE, TTL and MEMORY USAGE for keys. Keys deleted
+// since they were scanned are skipped.
+func (c *RedisClient) MeasureKeys(ctx context.Context, keys []string) ([]bigKey, error) {
+	if !c.connected || c.client == nil {
+		return nil, errors.New("not connected to any Redis server")
+	}
+	types := make([]*redis.StatusCmd, len(keys))
+	ttls := make([]*redis.DurationCmd, len(keys))
+	mems := make([]*redis.IntCmd, len(keys))
+	_, err := c.client.Pipelined(ctx, func(p redis.Pipeliner) error {
+		for i, key := range keys {
+			types[i] = p.Type(ctx, key)
+			ttls[i] = p.TTL(ctx, key)
+			mems[i] = p.MemoryUsage(ctx, key, memSamples)
+		}
+		return nil
+	})
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("MEMORY USAGE failed: %v", err)
+	}
+	out := make([]bigKey, 0, len(keys))
+	for i, key := range keys {
+		n, err := mems[i].Result()
+		if err != nil {
+			continue // deleted since the scan
+		}
+		ttl, err := ttls[i].Result()
+		if err != nil || ttl == -1 {
+			ttl = -1
+		}
+		out = append(out, bigKey{Key: key, Type: types[i].Val(), Bytes: n, TTL: ttl})
+	}
+	return out, nil
+}
+
+// run scans the keyspace and measures keys until the limit, the end of the
+// keyspace or cancellation.
+func (j *memJob) run(ctx context.Context, c *RedisClient) {
+	defer close(j.done)
+	finish := func(state string, err error) {
+		j.mu.Lock()
+		j.report.State, j.report.Finished = state, time.Now()
+		if err != nil {
+			j.report.Err = err.Error()
+		}
+		j.mu.Unlock()
+	}
+	scan := &KeyScan{Pattern: "*"}
+	limit := j.report.Limit
+	for sampled := 0; !scan.Done() && (limit == 0 || sampled < limit); {
+		batch := memBatch
+		if limit > 0 && limit-sampled < batch {
+			batch = limit - sampled
+		}
+		keys, err := c.ScanNext(ctx, scan, batch, keyPageBudget)
+		if ctx.Err() != nil {
+			finish(memCancelled, nil)
+			return
+		}
+		if err != nil {
+			finish(memFailed, err)
+			return
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		measured, err := c.MeasureKeys(ctx, keys)
+		if ctx.Err() != nil {
+			finish(memCancelled, nil)
+			return
+		}
+		if err != nil {
+			finish(memFailed, err)
+			return
+		}
+		j.mu.Lock()
+		for _, k := range measured {
+			j.report.add(k)
+		}
+		j.mu.Unlock()
+		sampled += len(keys)
+	}
+	finish(memDone, nil)
+}
+
+// startMemJobLocked starts a new analysis, stopping one already running.
+func (s *Service) startMemJobLocked(limit int) {
+	s.stopMemJobLocked()
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &memJob{
+		cancel: cancel,
+		done:   make(chan struct{}),
+		report: memReport{
+			Target:  s.conn.Label(),
+			DB:      s.conn.Database,
+			Started: time.Now(),
+			State:   memRunning,
+			Limit:   limit,
+		},
+	}
+	if n, err := s.client.KeyCount(); err == nil {
+		job.report.DBSize = n
+	}
+	s.memJob = job
+	go job.run(ctx, s.client)
+}
+
+// stopMemJobLocked cancels a running analysis and waits for it, so the
+// client can be reconnected under it. The report is kept.
+func (s *Service) stopMemJobLocked() {
+	if s.memJob == nil {
+		return
+	}
+	s.memJob.cancel()
+	<-s.memJob.done
+}
+
+// memProgress is the info panel summary of a report.
+func memProgress(r memReport) string {
+	var b strings.Builder
+	elapsed := time.Since(r.Started)
+	if !r.Finished.IsZero() {
+		elapsed = r.Finished.Sub(r.Started)
+	}
+	fmt.Fprintf(&b, "Analysis: %s (%s)\n", r.State, elapsed.Round(time.Second))
+	of := "all keys"
+	if r.Limit > 0 {
+		of = fmt.Sprintf("limit %d", r.Limit)
+	}
+	if r.DBSize > 0 {
+		fmt.Fprintf(&b, "Sampled: %d of %d keys, %s (%.1f%%)\n", r.Sampled, r.DBSize, of, float64(r.Sampled)*100/float64(r.DBSize))
+	} else {
+		fmt.Fprintf(&b, "Sampled: %d keys, %s\n", r.Sampled, of)
+	}
+	fmt.Fprintf(&b, "Bytes: %s\n", humanBytes(r.Bytes))
+	fmt.Fprintf(&b, "No TTL: %d keys, %s", r.NoTTL, humanBytes(r.NoTTLBytes))
+	if r.Err != "" {
+		fmt.Fprintf(&b, "\nError: %s", r.Err)
+	}
+	return b.String()
+}
+
+func ttlCell(ttl time.Duration) string {
+	if ttl < 0 {
+		return "[orange]none[white]"
+	}
+	return formatTTL(ttl)
+}
+
+func share(part, total int64) string {
+	if total <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total))
+}
+
+func bigKeysActions() []pluginrpc.KeyBinding {
+	return []pluginrpc.KeyBinding{
+		{Key: "N", Label: "Analyze", Action: "memory_analyze"},
+		{Key: "C", Label: "Cancel", Action: "memory_cancel"},
+		{Key: "H", Label: "Namespaces", Action: "goto_hotspots"},
+		{Key: "E", Label: "Export", Action: "memory_export"},
+	}
+}
+
+func hotspotsActions() []pluginrpc.KeyBinding {
+	return []pluginrpc.KeyBinding{
+		{Key: "N", Label: "Analyze", Action: "memory_analyze"},
+		{Key: "C", Label: "Cancel", Action: "memory_cancel"},
+		{Key: "H", Label: "Big Keys", Action: "goto_bigkeys"},
+		{Key: "E", Label: "Export", Action: "memory_export"},
+	}
+}
+
+// memViewLocked wraps a report table, polling while the analysis runs.
+func (s *Service) memViewLocked(viewID, title string, headers []string, rows [][]string, actions []pluginrpc.KeyBinding) pluginrpc.ViewData {
+	extra := "Analysis: none yet - press N to start"
+	running := false
+	if s.memJob != nil {
+		r := s.memJob.snapshot()
+		extra, running = memProgress(r), r.State == memRunning
+	}
+	view := ui.Connected(viewID, title, s.baseInfo(extra), headers, rows, headers[0], actions...)
+	if running {
+		view.RefreshAfter = memPollInterval
+	}
+	return view
+}
+
+func (s *Service) viewBigKeysLocked() (pluginrpc.ViewData, error) {
+	headers := []string{"Key", "Type", "Bytes", "Size", "Share", "TTL", "Namespace"}
+	var rows [][]string
+	if s.memJob != nil {
+		r := s.memJob.snapshot()
+		for _, k := range r.Top {
+			rows = append(rows, []string{
+				k.Key, k.Type, fmt.Sprintf("%d", k.Bytes), humanBytes(k.Bytes), share(k.Bytes, r.Bytes), ttlCell(k.TTL), keyNamespace(k.Key),
+			})
+		}
+	}
+	rows = pluginrpc.EnsureRows(rows, pluginrpc.DashRow(len(headers), "No keys measured yet"))
+	return s.memViewLocked(viewBigKeys, "Redis Big Keys", headers, rows, bigKeysActions()), nil
+}
+
+func (s *Service) viewHotspotsLocked() (pluginrpc.ViewData, error) {
+	headers := []string{"Namespace", "Keys", "Bytes", "Size", "Share", "Avg", "No TTL", "Biggest Key"}
+	var rows [][]string
+	if s.memJob != nil {
+		r := s.memJob.snapshot()
+		for _, p := range r.prefixes() {
+			noTTL := fmt.Sprintf("%d", p.NoTTL)
+			if p.NoTTL > 0 {
+				noTTL = "[orange]" + noTTL + "[white]"
+			}
+			rows = append(rows, []string{
+				p.Prefix, fmt.Sprintf("%d", p.Keys), fmt.Sprintf("%d", p.Bytes), humanBytes(p.Bytes), share(p.Bytes, r.Bytes),
+				humanBytes(p.Bytes / int64(p.Keys)), noTTL, p.Biggest,
+			})
+		}
+	}
+	rows = pluginrpc.EnsureRows(rows, pluginrpc.DashRow(len(headers), "No keys measured yet"))
+	return s.memViewLocked(viewHotspots, "Redis Memory by Namespace", headers, rows, hotspotsActions()), nil
+}
+
+// memoryActionLocked runs the memory_* actions.
+func (s *Service) memoryActionLocked(action string, payload map[string]string) pluginrpc.ActionResult {
+	view := s.currentView
+	if view != viewBigKeys && view != viewHotspots {
+		view = viewBigKeys
+	}
+	switch action {
+	case "memory_analyze":
+		if err := s.ensureConnectedLocked(); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		limit := memDefaultLimit
+		if raw := strings.TrimSpace(payload["limit"]); raw != "" {
+			if _, err := fmt.Sscan(raw, &limit); err != nil || limit < 0 {
+				return pluginrpc.ActionResult{OK: false, Message: "limit must be a number of keys (0 = all)"}
+			}
+		}
+		s.startMemJobLocked(limit)
+		next, err := s.buildViewLocked(view)
+		if err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		return pluginrpc.ActionResult{OK: true, Message: "memory analysis started", Next: &next}
+
+	case "memory_cancel":
+		if s.memJob == nil || s.memJob.snapshot().State != memRunning {
+			return pluginrpc.ActionResult{OK: false, Message: "no analysis running"}
+		}
+		s.stopMemJobLocked()
+		next, err := s.buildViewLocked(view)
+		if err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		return pluginrpc.ActionResult{OK: true, Message: "memory analysis cancelled", Next: &next}
+
+	case "memory_export":
+		if s.memJob == nil {
+			return pluginrpc.ActionResult{OK: false, Message: "run an analysis first"}
+		}
+		path, err := exportMemReport(s.memJob.snapshot(), s.name, time.Now())
+		if err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		return pluginrpc.ActionResult{OK: true, Message: "report saved to " + path}
+	}
+	return pluginrpc.ActionResult{OK: false, Message: "unknown action: " + action}
+}
+
+// exportMemReport writes the report as Markdown and JSON next to the host
+// table exports and returns the Markdown path.
+func exportMemReport(r memReport, name string, now time.Time) (string, error) {
+	dir := pluginapi.PluginExportsDir("redis")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	if name == "" {
+		name = "redis"
+	}
+	base := filepath.Join(dir, fmt.Sprintf("memory-%s-%s", sanitizeFileName(name), now.Format("20060102-150405")))
+
+	data, err := json.MarshalIndent(struct {
+		memReport
+		Namespaces []prefixStat `json:"namespaces"`
+	}{r, r.prefixes()}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(base+".json", data, 0o644); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(base+".md", []byte(memReportMarkdown(r)), 0o644); err != nil {
+		return "", err
+	}
+	return base + ".md", nil
+}
+
+// memReportMarkdown renders the report like redis-cli --bigkeys --memkeys,
+// as tables.
+func memReportMarkdown(r memReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Redis memory report: %s db%d\n\n", r.Target, r.DB)
+	fmt.Fprintf(&b, "- Started: %s\n", r.Started.Format(time.RFC3339))
+	fmt.Fprintf(&b, "- State: %s\n", r.State)
+	fmt.Fprintf(&b, "- Sampled: %d of %d keys\n", r.Sampled, r.DBSize)
+	fmt.Fprintf(&b, "- Bytes: %s (%d)\n", humanBytes(r.Bytes), r.Bytes)
+	fmt.Fprintf(&b, "- Keys without TTL: %d (%s)\n", r.NoTTL, humanBytes(r.NoTTLBytes))
+
+	b.WriteString("\n## Biggest keys\n\n| Key | Type | Bytes | TTL |\n| --- | --- | ---: | --- |\n")
+	for _, k := range r.Top {
+		ttl := "none"
+		if k.TTL >= 0 {
+			ttl = formatTTL(k.TTL)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %d | %s |\n", mdCell(k.Key), k.Type, k.Bytes, ttl)
+	}
+
+	b.WriteString("\n## Namespaces\n\n| Namespace | Keys | Bytes | Share | No TTL | Biggest key |\n| --- | ---: | ---: | ---: | ---: | --- |\n")
+	for _, p := range r.prefixes() {
+		fmt.Fprintf(&b, "| %s | %d | %d | %s | %d | %s |\n", mdCell(p.Prefix), p.Keys, p.Bytes, share(p.Bytes, r.Bytes), p.NoTTL, mdCell(p.Biggest))
+	}
+	return b.String()
+}
+
+func mdCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, s)
+}
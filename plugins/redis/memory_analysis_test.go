@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemReportTopKeys(t *testing.T) {
+	var r memReport
+	for i := 1; i <= bigKeysTop+50; i++ {
+		r.add(bigKey{Key: fmt.Sprintf("k%d", i), Bytes: int64(i), TTL: time.Minute})
+	}
+	if len(r.Top) != bigKeysTop {
+		t.Fatalf("len(Top) = %d, want %d", len(r.Top), bigKeysTop)
+	}
+	if r.Top[0].Bytes != bigKeysTop+50 || r.Top[bigKeysTop-1].Bytes != 51 {
+		t.Fatalf("Top spans %d..%d, want %d..51", r.Top[0].Bytes, r.Top[bigKeysTop-1].Bytes, bigKeysTop+50)
+	}
+	for i := 1; i < len(r.Top); i++ {
+		if r.Top[i].Bytes > r.Top[i-1].Bytes {
+			t.Fatalf("Top not sorted at %d: %d > %d", i, r.Top[i].Bytes, r.Top[i-1].Bytes)
+		}
+	}
+	if r.Sampled != bigKeysTop+50 {
+		t.Fatalf("Sampled = %d, want %d", r.Sampled, bigKeysTop+50)
+	}
+}
+
+func TestMemReportNamespaces(t *testing.T) {
+	var r memReport
+	r.add(bigKey{Key: "user:1", Bytes: 100, TTL: -1})
+	r.add(bigKey{Key: "user:2:profile", Bytes: 300, TTL: time.Hour})
+	r.add(bigKey{Key: "session:a", Bytes: 50, TTL: time.Minute})
+	r.add(bigKey{Key: "counter", Bytes: 10, TTL: -1})
+
+	if r.Bytes != 460 || r.NoTTL != 2 || r.NoTTLBytes != 110 {
+		t.Fatalf("totals = %d bytes, %d no-TTL (%d bytes), want 460, 2 (110)", r.Bytes, r.NoTTL, r.NoTTLBytes)
+	}
+	got := r.prefixes()
+	var names []string
+	for _, p := range got {
+		names = append(names, p.Prefix)
+	}
+	if want := "user:,session:,(no namespace)"; strings.Join(names, ",") != want {
+		t.Fatalf("prefixes = %s, want %s", strings.Join(names, ","), want)
+	}
+	user := got[0]
+	if user.Keys != 2 || user.Bytes != 400 || user.NoTTL != 1 || user.Biggest != "user:2:profile" {
+		t.Fatalf("user: = %+v", user)
+	}
+}
+
+func TestMemReportMarkdown(t *testing.T) {
+	r := memReport{Target: "localhost:6379", State: memDone, Started: time.Unix(0, 0)}
+	r.add(bigKey{Key: "a|b", Type: "string", Bytes: 64, TTL: -1})
+	md := memReportMarkdown(r)
+	for _, want := range []string{"# Redis memory report: localhost:6379 db0", `| a\|b | string | 64 | none |`, "Keys without TTL: 1"} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("markdown missing %q:\n%s", want, md)
+		}
+	}
+}
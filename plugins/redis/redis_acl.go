@@ -0,0 +1,201 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// aclLogLimit caps the ACL LOG entries read.
+const aclLogLimit = 128
+
+// ACLUser is one user of ACL LIST, with its rules grouped for the table.
+type ACLUser struct {
+	Name      string
+	Enabled   bool
+	NoPass    bool
+	Passwords int
+	Keys      []string // ~pattern, %R~pattern, %W~pattern
+	Channels  []string // &pattern
+	Commands  []string // +@category, -command, …
+	Selectors []string // (…) rule groups, Redis 7+
+	Rules     string   // everything after the name, as ACL LIST prints it
+}
+
+// parseACLUser parses one ACL LIST line: "user <name> <rule> <rule> …".
+func parseACLUser(line string) (ACLUser, error) {
+	rest, ok := strings.CutPrefix(line, "user ")
+	if !ok {
+		return ACLUser{}, fmt.Errorf("unexpected ACL LIST line %q", line)
+	}
+	name, rules, _ := strings.Cut(rest, " ")
+	u := ACLUser{Name: name, Rules: rules}
+	for _, rule := range aclRuleTokens(rules) {
+		switch {
+		case rule == "on":
+			u.Enabled = true
+		case rule == "off":
+			u.Enabled = false
+		case rule == "nopass":
+			u.NoPass = true
+		case strings.HasPrefix(rule, "#"):
+			u.Passwords++
+		case rule == "allkeys":
+			u.Keys = append(u.Keys, "~*")
+		case strings.HasPrefix(rule, "~"), strings.HasPrefix(rule, "%"):
+			u.Keys = append(u.Keys, rule)
+		case rule == "allchannels":
+			u.Channels = append(u.Channels, "&*")
+		case strings.HasPrefix(rule, "&"):
+			u.Channels = append(u.Channels, rule)
+		case rule == "allcommands":
+			u.Commands = append(u.Commands, "+@all")
+		case rule == "nocommands":
+			u.Commands = append(u.Commands, "-@all")
+		case strings.HasPrefix(rule, "+"), strings.HasPrefix(rule, "-"):
+			u.Commands = append(u.Commands, rule)
+		case strings.HasPrefix(rule, "("):
+			u.Selectors = append(u.Selectors, rule)
+		}
+	}
+	return u, nil
+}
+
+// aclRuleTokens splits rules on spaces, keeping "(…)" selectors whole.
+func aclRuleTokens(rules string) []string {
+	var (
+		out   []string
+		b     strings.Builder
+		depth int
+	)
+	for _, r := range rules {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case r == ' ' && depth == 0:
+			if b.Len() > 0 {
+				out = append(out, b.String())
+				b.Reset()
+			}
+			continue
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() > 0 {
+		out = append(out, b.String())
+	}
+	return out
+}
+
+// ACLUsers lists users with ACL LIST (Redis 6+).
+func (c *RedisClient) ACLUsers() ([]ACLUser, error) {
+	if !c.connected || c.client == nil {
+		return nil, errors.New("not connected to any Redis server")
+	}
+	lines, err := c.client.Do(c.ctx, "ACL", "LIST").StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("ACL LIST failed (ACLs need Redis 6+): %v", err)
+	}
+	users := make([]ACLUser, 0, len(lines))
+	for _, line := range lines {
+		u, err := parseACLUser(line)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+// ACLGetUser returns the ACL GETUSER reply for name.
+func (c *RedisClient) ACLGetUser(name string) (interface{}, error) {
+	if !c.connected || c.client == nil {
+		return nil, errors.New("not connected to any Redis server")
+	}
+	v, err := c.client.Do(c.ctx, "ACL", "GETUSER", name).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("no user %s", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ACL GETUSER failed: %v", err)
+	}
+	return v, nil
+}
+
+// ACLSetUser applies rules to a user, creating it if needed. In a cluster
+// ACLs are per node, so the rules are applied on every node.
+func (c *RedisClient) ACLSetUser(name string, rules []string) error {
+	args := []interface{}{"ACL", "SETUSER", name}
+	for _, r := range rules {
+		args = append(args, r)
+	}
+	return c.eachNode(func(ctx context.Context, n redis.UniversalClient) error {
+		if err := n.Do(ctx, args...).Err(); err != nil {
+			return fmt.Errorf("ACL SETUSER failed: %v", err)
+		}
+		return nil
+	})
+}
+
+// ACLDelUser deletes a user on every node.
+func (c *RedisClient) ACLDelUser(name string) error {
+	return c.eachNode(func(ctx context.Context, n redis.UniversalClient) error {
+		if err := n.Do(ctx, "ACL", "DELUSER", name).Err(); err != nil {
+			return fmt.Errorf("ACL DELUSER failed: %v", err)
+		}
+		return nil
+	})
+}
+
+// ACLGenPass asks the server for a random 256-bit password (ACL GENPASS).
+func (c *RedisClient) ACLGenPass() (string, error) {
+	if !c.connected || c.client == nil {
+		return "", errors.New("not connected to any Redis server")
+	}
+	pass, err := c.client.Do(c.ctx, "ACL", "GENPASS").Text()
+	if err != nil {
+		return "", fmt.Errorf("ACL GENPASS failed: %v", err)
+	}
+	return pass, nil
+}
+
+// ACLLog reads the most recent ACL denials, newest first.
+func (c *RedisClient) ACLLog() ([]*redis.ACLLogEntry, error) {
+	if !c.connected || c.client == nil {
+		return nil, errors.New("not connected to any Redis server")
+	}
+	entries, err := c.client.ACLLog(c.ctx, aclLogLimit).Result()
+	if err != nil {
+		return nil, fmt.Errorf("ACL LOG failed: %v", err)
+	}
+	return entries, nil
+}
+
+// ACLLogReset clears the ACL log on every node.
+func (c *RedisClient) ACLLogReset() error {
+	return c.eachNode(func(ctx context.Context, n redis.UniversalClient) error {
+		return n.ACLLogReset(ctx).Err()
+	})
+}
+
+// eachNode runs fn on the server, or on every node of a cluster, for
+// per-node settings such as ACLs and CONFIG.
+func (c *RedisClient) eachNode(fn func(context.Context, redis.UniversalClient) error) error {
+	if !c.connected || c.client == nil {
+		return errors.New("not connected to any Redis server")
+	}
+	if cluster, ok := c.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachShard(c.ctx, func(ctx context.Context, n *redis.Client) error {
+			if err := fn(ctx, n); err != nil {
+				return fmt.Errorf("%s: %w", n.Options().Addr, err)
+			}
+			return nil
+		})
+	}
+	return fn(c.ctx, c.client)
+}
@@ -0,0 +1,46 @@
+package redis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseACLUser(t *testing.T) {
+	line := "user app on #5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8 ~app:* %R~cache:* resetchannels &events:* -@all +@read +set (~audit:* +xadd)"
+	u, err := parseACLUser(line)
+	if err != nil {
+		t.Fatalf("parseACLUser: %v", err)
+	}
+	want := ACLUser{
+		Name:      "app",
+		Enabled:   true,
+		Passwords: 1,
+		Keys:      []string{"~app:*", "%R~cache:*"},
+		Channels:  []string{"&events:*"},
+		Commands:  []string{"-@all", "+@read", "+set"},
+		Selectors: []string{"(~audit:* +xadd)"},
+		Rules:     line[len("user app "):],
+	}
+	if !reflect.DeepEqual(u, want) {
+		t.Fatalf("parseACLUser = %+v, want %+v", u, want)
+	}
+
+	def, err := parseACLUser("user default off nopass sanitize-payload allkeys allchannels allcommands")
+	if err != nil {
+		t.Fatalf("parseACLUser(default): %v", err)
+	}
+	if def.Enabled || !def.NoPass || !reflect.DeepEqual(def.Keys, []string{"~*"}) || !reflect.DeepEqual(def.Commands, []string{"+@all"}) {
+		t.Fatalf("parseACLUser(default) = %+v", def)
+	}
+	if _, err := parseACLUser("app on"); err == nil {
+		t.Fatalf("parseACLUser accepted a line without the user prefix")
+	}
+}
+
+func TestACLRuleTokens(t *testing.T) {
+	got := aclRuleTokens("on  ~a:* (+get ~b:*) (%W~c:* +set)  +@read")
+	want := []string{"on", "~a:*", "(+get ~b:*)", "(%W~c:* +set)", "+@read"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("aclRuleTokens = %q, want %q", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package redis
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"strconv"
@@ -11,22 +12,42 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-// RedisConnection represents a connection to a Redis server
+// RedisConnection represents a connection to a Redis server, cluster or
+// sentinel-managed master.
 type RedisConnection struct {
 	Host     string
 	Port     string
 	Username string
 	Password string
 	Database int
+
+	Mode             string   // ModeStandalone, ModeCluster or ModeSentinel
+	Nodes            []string // cluster seed nodes or sentinel addresses
+	MasterName       string
+	SentinelPassword string
+	TLS              *tls.Config
+}
+
+// Label names the connection for headers: host:port, or the master name for
+// sentinel deployments.
+func (c RedisConnection) Label() string {
+	switch c.Mode {
+	case ModeSentinel:
+		return fmt.Sprintf("%s via %s", c.MasterName, strings.Join(c.Nodes, ","))
+	case ModeCluster:
+		return strings.Join(c.Nodes, ",")
+	}
+	return fmt.Sprintf("%s:%s", c.Host, c.Port)
 }
 
 // RedisClient is a client for interacting with Redis
 type RedisClient struct {
 	conn        *RedisConnection
-	client      *redis.Client
+	client      redis.UniversalClient
 	ctx         context.Context
 	connected   bool
 	lastRefresh time.Time
+	commands    map[string]*commandFlags // COMMAND INFO cache, see CommandFlags
 }
 
 // SlowLogEntry represents a Redis slowlog entry
@@ -116,58 +137,93 @@ func NewRedisClient() *RedisClient {
 	}
 }
 
-// Connect connects to a Redis server
-func (c *RedisClient) Connect(host, port, username, password string, db int) error {
-	if host == "" {
+// Connect connects to a Redis server, cluster or sentinel-managed master.
+func (c *RedisClient) Connect(conn RedisConnection) error {
+	if conn.Host == "" && len(conn.Nodes) == 0 {
 		return errors.New("host cannot be empty")
 	}
-
-	c.conn = &RedisConnection{
-		Host:     host,
-		Port:     port,
-		Username: username,
-		Password: password,
-		Database: db,
+	if len(conn.Nodes) == 0 {
+		conn.Nodes = []string{fmt.Sprintf("%s:%s", conn.Host, conn.Port)}
 	}
 
-	// Create a new Redis client with timeout
-	c.client = redis.NewClient(&redis.Options{
-		Addr:         fmt.Sprintf("%s:%s", host, port),
-		Username:     username,
-		Password:     password,
-		DB:           db,
-		ReadTimeout:  3 * time.Second,
-		WriteTimeout: 3 * time.Second,
-		DialTimeout:  3 * time.Second,
-	})
-
-	// Ping the Redis server to check the connection
-	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
-	defer cancel()
-
-	_, err := c.client.Ping(ctx).Result()
+	client, err := c.dial(conn)
 	if err != nil {
-		// Close the client to prevent resource leaks
-		c.client.Close()
-		c.client = nil
 		return fmt.Errorf("failed to connect to Redis: %v", err)
 	}
 
+	c.conn = &conn
+	c.client = client
 	c.connected = true
+	c.commands = nil
 	c.lastRefresh = time.Now()
 
 	return nil
 }
 
+// dial builds the client for conn's mode and pings it.
+func (c *RedisClient) dial(conn RedisConnection) (redis.UniversalClient, error) {
+	var client redis.UniversalClient
+	switch conn.Mode {
+	case ModeCluster:
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        conn.Nodes,
+			Username:     conn.Username,
+			Password:     conn.Password,
+			TLSConfig:    conn.TLS,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+			DialTimeout:  3 * time.Second,
+		})
+	case ModeSentinel:
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       conn.MasterName,
+			SentinelAddrs:    conn.Nodes,
+			SentinelPassword: conn.SentinelPassword,
+			Username:         conn.Username,
+			Password:         conn.Password,
+			DB:               conn.Database,
+			TLSConfig:        conn.TLS,
+			ReadTimeout:      3 * time.Second,
+			WriteTimeout:     3 * time.Second,
+			DialTimeout:      3 * time.Second,
+		})
+	default:
+		client = redis.NewClient(&redis.Options{
+			Addr:         conn.Nodes[0],
+			Username:     conn.Username,
+			Password:     conn.Password,
+			DB:           conn.Database,
+			TLSConfig:    conn.TLS,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+			DialTimeout:  3 * time.Second,
+		})
+	}
+
+	// Ping the Redis server to check the connection
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := client.Ping(ctx).Result(); err != nil {
+		// Close the client to prevent resource leaks
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
 // ConnectToInstance connects to a preconfigured Redis instance
 func (c *RedisClient) ConnectToInstance(instance RedisInstance) error {
-	return c.Connect(
-		instance.Host,
-		strconv.Itoa(instance.Port),
-		instance.Username,
-		instance.Password,
-		instance.Database,
-	)
+	return c.Connect(RedisConnection{
+		Host:       instance.Host,
+		Port:       strconv.Itoa(instance.Port),
+		Username:   instance.Username,
+		Password:   instance.Password,
+		Database:   instance.Database,
+		Mode:       instance.Mode,
+		Nodes:      instance.Nodes,
+		MasterName: instance.MasterName,
+	})
 }
 
 // GetInstancesFromConfig retrieves Redis instances from configuration
@@ -194,6 +250,7 @@ func (c *RedisClient) Disconnect() error {
 	c.conn = nil
 	c.client = nil
 	c.connected = false
+	c.commands = nil
 
 	return nil
 }
@@ -223,26 +280,20 @@ func (c *RedisClient) SelectDB(db int) error {
 	if !c.connected || c.client == nil {
 		return errors.New("not connected to any Redis server")
 	}
+	if c.conn.Mode == ModeCluster && db != 0 {
+		return errors.New("redis cluster only has database 0")
+	}
 
 	// Create a new client with the selected database
-	newClient := redis.NewClient(&redis.Options{
-		Addr:     c.client.Options().Addr,
-		Password: c.client.Options().Password,
-		DB:       db,
-	})
-
-	// Test the connection
-	_, err := newClient.Ping(c.ctx).Result()
+	conn := *c.conn
+	conn.Database = db
+	newClient, err := c.dial(conn)
 	if err != nil {
-		newClient.Close()
 		return fmt.Errorf("failed to select database %d: %v", db, err)
 	}
 
 	// Close the old client and update the current one
-	if c.client != nil {
-		c.client.Close()
-	}
-
+	c.client.Close()
 	c.client = newClient
 	c.conn.Database = db
 
@@ -255,10 +306,6 @@ func (c *RedisClient) GetKeys(pattern string) ([]string, error) {
 		return nil, errors.New("not connected to any Redis server")
 	}
 
-	// Use SCAN to get keys matching the pattern
-	var keys []string
-	var cursor uint64 = 0
-
 	// Get UI config to limit the number of keys
 	uiConfig, configErr := GetUIConfig()
 	maxKeys := 1000 // Default max keys
@@ -266,30 +313,11 @@ func (c *RedisClient) GetKeys(pattern string) ([]string, error) {
 		maxKeys = uiConfig.MaxKeysDisplay
 	}
 
-	for {
-		keys_batch, next_cursor, err := c.client.Scan(c.ctx, cursor, pattern, 100).Result()
-		if err != nil {
-			return nil, fmt.Errorf("error scanning keys: %v", err)
-		}
-
-		keys = append(keys, keys_batch...)
-		cursor = next_cursor
-
-		// Stop if we've reached the max keys or the cursor is back to 0
-		if cursor == 0 || len(keys) >= maxKeys {
-			break
-		}
-	}
-
-	// Truncate if we got more keys than the max
-	if len(keys) > maxKeys {
-		keys = keys[:maxKeys]
-	}
-
-	return keys, nil
+	return c.ScanAllKeys(pattern, maxKeys)
 }
 
-// ScanKeys retrieves a page of keys using Redis SCAN.
+// ScanKeys retrieves a page of keys using Redis SCAN. In cluster mode the
+// cursor belongs to a single node; use ScanAllKeys to cover every master.
 func (c *RedisClient) ScanKeys(pattern string, cursor uint64, count int64) ([]string, uint64, error) {
 	if !c.connected || c.client == nil {
 		return nil, 0, errors.New("not connected to any Redis server")
@@ -341,6 +369,11 @@ func (c *RedisClient) getKeySize(key, keyType string) string {
 		if err == nil {
 			return fmt.Sprintf("%d members", count)
 		}
+	case "stream":
+		count, err := c.client.XLen(c.ctx, key).Result()
+		if err == nil {
+			return fmt.Sprintf("%d entries", count)
+		}
 	}
 	return "0"
 }
@@ -430,6 +463,18 @@ func (c *RedisClient) getZSetContent(key string) (string, error) {
 	return b.String(), nil
 }
 
+func (c *RedisClient) getStreamContent(key string) (string, error) {
+	values, err := c.client.XRevRangeN(c.ctx, key, "+", "-", 100).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to get stream entries: %v", err)
+	}
+	var b strings.Builder
+	for i := len(values) - 1; i >= 0; i-- {
+		b.WriteString(fmt.Sprintf("%s %s\n", values[i].ID, streamFields(values[i].Values)))
+	}
+	return b.String(), nil
+}
+
 // GetKeyContent gets the content of a key
 func (c *RedisClient) GetKeyContent(key string) (string, error) {
 	if !c.connected || c.client == nil {
@@ -456,6 +501,8 @@ func (c *RedisClient) GetKeyContent(key string) (string, error) {
 		return c.getSetContent(key)
 	case "zset":
 		return c.getZSetContent(key)
+	case "stream":
+		return c.getStreamContent(key)
 	default:
 		return fmt.Sprintf("Unknown key type: %s", keyType), nil
 	}
@@ -489,9 +536,14 @@ func (c *RedisClient) FlushDB() error {
 		return errors.New("not connected to any Redis server")
 	}
 
-	_, err := c.client.FlushDB(c.ctx).Result()
+	masters, err := c.masters()
 	if err != nil {
-		return fmt.Errorf("failed to flush database: %v", err)
+		return err
+	}
+	for _, m := range masters {
+		if err := m.FlushDB(c.ctx).Err(); err != nil {
+			return fmt.Errorf("failed to flush database on %s: %v", m.Options().Addr, err)
+		}
 	}
 
 	return nil
@@ -564,11 +616,20 @@ func (c *RedisClient) GetInfoMap() (map[string]string, error) {
 		return nil, errors.New("not connected to any Redis server")
 	}
 
+	if c.conn.Mode == ModeCluster {
+		return c.clusterInfoMap()
+	}
+
 	info, err := c.GetInfoRaw()
 	if err != nil {
 		return nil, err
 	}
 
+	return parseInfo(info), nil
+}
+
+// parseInfo turns an INFO reply into a field map, dropping section headers.
+func parseInfo(info string) map[string]string {
 	infoMap := make(map[string]string)
 	lines := strings.Split(info, "\n")
 	for _, line := range lines {
@@ -583,7 +644,7 @@ func (c *RedisClient) GetInfoMap() (map[string]string, error) {
 		}
 	}
 
-	return infoMap, nil
+	return infoMap
 }
 
 // GetInfoSectionMap returns INFO data for a specific section (e.g. replication, persistence).
@@ -1098,3 +1159,26 @@ func (c *RedisClient) GetLatencyHistory() ([]LatencyEvent, error) {
 
 	return events, nil
 }
+
+// Run sends a raw command and returns its reply. Error replies and transport
+// failures come back as error values in the reply, nil replies as nil.
+func (c *RedisClient) Run(args []string) (interface{}, error) {
+	if !c.connected || c.client == nil {
+		return nil, errors.New("not connected to any Redis server")
+	}
+	if len(args) == 0 {
+		return nil, errors.New("empty command")
+	}
+	cmdArgs := make([]interface{}, len(args))
+	for i, a := range args {
+		cmdArgs[i] = a
+	}
+	reply, err := c.client.Do(c.ctx, cmdArgs...).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return err, nil
+	}
+	return reply, nil
+}
@@ -0,0 +1,413 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NodeInfo is one row of the nodes view: a cluster node, or the connected
+// server and its replicas outside cluster mode.
+type NodeInfo struct {
+	ID        string
+	Addr      string
+	Role      string // master or replica
+	Master    string // address of the master a replica follows
+	Slots     string
+	SlotCount int
+	Offset    int64 // replication offset, -1 when unknown
+	Link      string
+	Self      bool
+
+	firstSlot int
+}
+
+// summedInfoFields are the INFO counters that add up across cluster masters.
+var summedInfoFields = map[string]bool{
+	"connected_clients":          true,
+	"blocked_clients":            true,
+	"used_memory":                true,
+	"used_memory_peak":           true,
+	"instantaneous_ops_per_sec":  true,
+	"total_commands_processed":   true,
+	"total_connections_received": true,
+	"keyspace_hits":              true,
+	"keyspace_misses":            true,
+	"expired_keys":               true,
+	"evicted_keys":               true,
+}
+
+// masters returns a client per master: every cluster master, or the single
+// connected server.
+func (c *RedisClient) masters() ([]*redis.Client, error) {
+	if !c.connected || c.client == nil {
+		return nil, errors.New("not connected to any Redis server")
+	}
+	switch client := c.client.(type) {
+	case *redis.Client:
+		return []*redis.Client{client}, nil
+	case *redis.ClusterClient:
+		var (
+			mu  sync.Mutex
+			out []*redis.Client
+		)
+		err := client.ForEachMaster(c.ctx, func(_ context.Context, m *redis.Client) error {
+			mu.Lock()
+			out = append(out, m)
+			mu.Unlock()
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list cluster masters: %v", err)
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].Options().Addr < out[j].Options().Addr })
+		return out, nil
+	}
+	return nil, fmt.Errorf("unsupported redis client %T", c.client)
+}
+
+// ScanAllKeys runs SCAN on every master until limit keys are found or every
+// keyspace is exhausted.
+func (c *RedisClient) ScanAllKeys(pattern string, limit int) ([]string, error) {
+	masters, err := c.masters()
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 1000
+	}
+	var keys []string
+	for _, m := range masters {
+		var cursor uint64
+		for len(keys) < limit {
+			batch, next, err := m.Scan(c.ctx, cursor, pattern, 100).Result()
+			if err != nil {
+				return nil, fmt.Errorf("error scanning keys on %s: %v", m.Options().Addr, err)
+			}
+			keys = append(keys, batch...)
+			cursor = next
+			if cursor == 0 {
+				break
+			}
+		}
+	}
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys, nil
+}
+
+func (c *RedisClient) clusterInfoMap() (map[string]string, error) {
+	masters, err := c.masters()
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]map[string]string, 0, len(masters))
+	for _, m := range masters {
+		raw, err := m.Info(c.ctx).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get server info from %s: %v", m.Options().Addr, err)
+		}
+		infos = append(infos, parseInfo(raw))
+	}
+	return aggregateInfo(infos), nil
+}
+
+// aggregateInfo merges the INFO of several masters: counters and keyspace
+// entries are summed, everything else comes from the first master.
+func aggregateInfo(infos []map[string]string) map[string]string {
+	out := make(map[string]string)
+	if len(infos) == 0 {
+		return out
+	}
+	for k, v := range infos[0] {
+		out[k] = v
+	}
+	for _, info := range infos[1:] {
+		for k, v := range info {
+			cur, ok := out[k]
+			switch {
+			case !ok:
+				out[k] = v
+			case summedInfoFields[k]:
+				a, _ := strconv.ParseInt(cur, 10, 64)
+				b, _ := strconv.ParseInt(v, 10, 64)
+				out[k] = strconv.FormatInt(a+b, 10)
+			case strings.HasPrefix(k, "db") || strings.HasPrefix(k, "cmdstat_"):
+				out[k] = mergeInfoList(cur, v)
+			}
+		}
+	}
+	for _, k := range []string{"used_memory", "used_memory_peak"} {
+		if n, err := strconv.ParseInt(out[k], 10, 64); err == nil {
+			out[k+"_human"] = humanBytes(n)
+		}
+	}
+	out["cluster_masters"] = strconv.Itoa(len(infos))
+	return out
+}
+
+// mergeInfoList sums two "k=v,k=v" INFO values such as keyspace or
+// commandstats lines. avg_ttl keeps the larger value and usec_per_call is
+// recomputed from the summed totals.
+func mergeInfoList(a, b string) string {
+	keys, values := parseInfoList(a)
+	_, other := parseInfoList(b)
+	for _, k := range keys {
+		x, errA := strconv.ParseInt(values[k], 10, 64)
+		y, errB := strconv.ParseInt(other[k], 10, 64)
+		if errA != nil || errB != nil {
+			continue
+		}
+		if k == "avg_ttl" {
+			values[k] = strconv.FormatInt(max(x, y), 10)
+			continue
+		}
+		values[k] = strconv.FormatInt(x+y, 10)
+	}
+	if _, ok := values["usec_per_call"]; ok {
+		calls, _ := strconv.ParseFloat(values["calls"], 64)
+		usec, _ := strconv.ParseFloat(values["usec"], 64)
+		if calls > 0 {
+			values["usec_per_call"] = strconv.FormatFloat(usec/calls, 'f', 2, 64)
+		}
+	}
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+values[k])
+	}
+	return strings.Join(parts, ",")
+}
+
+func parseInfoList(v string) ([]string, map[string]string) {
+	var keys []string
+	values := make(map[string]string)
+	for _, part := range strings.Split(v, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		keys = append(keys, kv[0])
+		values[kv[0]] = kv[1]
+	}
+	return keys, values
+}
+
+// GetNodes lists the cluster nodes with their slots, or the connected server
+// and its replicas outside cluster mode.
+func (c *RedisClient) GetNodes() ([]NodeInfo, error) {
+	if !c.connected || c.client == nil {
+		return nil, errors.New("not connected to any Redis server")
+	}
+	if cluster, ok := c.client.(*redis.ClusterClient); ok {
+		return c.clusterNodes(cluster)
+	}
+
+	repl, err := c.GetInfoSectionMap("replication")
+	if err != nil {
+		return nil, err
+	}
+	self, err := c.selfAddr()
+	if err != nil {
+		return nil, err
+	}
+	node := NodeInfo{Addr: self, Role: "master", Offset: replOffset(repl), Self: true}
+	if repl["role"] == "slave" {
+		node.Role = "replica"
+		node.Master = repl["master_host"] + ":" + repl["master_port"]
+		node.Link = repl["master_link_status"]
+	}
+	return append([]NodeInfo{node}, parseReplicas(self, repl)...), nil
+}
+
+func (c *RedisClient) clusterNodes(cluster *redis.ClusterClient) ([]NodeInfo, error) {
+	raw, err := cluster.ClusterNodes(c.ctx).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster nodes: %v", err)
+	}
+	nodes := parseClusterNodes(raw)
+
+	var mu sync.Mutex
+	offsets := make(map[string]int64)
+	_ = cluster.ForEachShard(c.ctx, func(ctx context.Context, node *redis.Client) error {
+		info, err := node.Info(ctx, "replication").Result()
+		if err != nil {
+			return nil
+		}
+		mu.Lock()
+		offsets[node.Options().Addr] = replOffset(parseInfo(info))
+		mu.Unlock()
+		return nil
+	})
+	for i := range nodes {
+		if off, ok := offsets[nodes[i].Addr]; ok {
+			nodes[i].Offset = off
+		}
+	}
+	return nodes, nil
+}
+
+// selfAddr is the address of the server the data client talks to; sentinel
+// deployments ask the sentinels for the current master.
+func (c *RedisClient) selfAddr() (string, error) {
+	if c.conn.Mode != ModeSentinel {
+		return c.conn.Nodes[0], nil
+	}
+	var lastErr error
+	for _, addr := range c.conn.Nodes {
+		sentinel := redis.NewSentinelClient(&redis.Options{
+			Addr:      addr,
+			Password:  c.conn.SentinelPassword,
+			TLSConfig: c.conn.TLS,
+		})
+		hostPort, err := sentinel.GetMasterAddrByName(c.ctx, c.conn.MasterName).Result()
+		sentinel.Close()
+		if err == nil && len(hostPort) == 2 {
+			return hostPort[0] + ":" + hostPort[1], nil
+		}
+		lastErr = err
+	}
+	return "", fmt.Errorf("failed to resolve master %s: %v", c.conn.MasterName, lastErr)
+}
+
+// parseClusterNodes parses CLUSTER NODES. Masters are ordered by their first
+// slot, each followed by its replicas.
+func parseClusterNodes(raw string) []NodeInfo {
+	var nodes []NodeInfo
+	addrByID := make(map[string]string)
+	for _, line := range strings.Split(raw, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 8 {
+			continue
+		}
+		addr, _, _ := strings.Cut(fields[1], "@")
+		node := NodeInfo{
+			ID:        fields[0],
+			Addr:      addr,
+			Master:    fields[3],
+			Link:      fields[7],
+			Offset:    -1,
+			firstSlot: -1,
+		}
+		for _, flag := range strings.Split(fields[2], ",") {
+			switch flag {
+			case "myself":
+				node.Self = true
+			case "master":
+				node.Role = "master"
+			case "slave":
+				node.Role = "replica"
+			case "fail":
+				node.Link = "fail"
+			case "fail?":
+				node.Link = "pfail"
+			}
+		}
+		var slots []string
+		for _, slot := range fields[8:] {
+			if strings.HasPrefix(slot, "[") {
+				continue // migrating or importing
+			}
+			lo, hi, isRange := strings.Cut(slot, "-")
+			start, err := strconv.Atoi(lo)
+			if err != nil {
+				continue
+			}
+			end := start
+			if isRange {
+				if end, err = strconv.Atoi(hi); err != nil {
+					continue
+				}
+			}
+			if node.firstSlot < 0 || start < node.firstSlot {
+				node.firstSlot = start
+			}
+			node.SlotCount += end - start + 1
+			slots = append(slots, slot)
+		}
+		node.Slots = strings.Join(slots, ",")
+		addrByID[node.ID] = node.Addr
+		nodes = append(nodes, node)
+	}
+
+	firstSlot := make(map[string]int)
+	for i := range nodes {
+		if nodes[i].Role == "master" {
+			nodes[i].Master = ""
+			firstSlot[nodes[i].Addr] = nodes[i].firstSlot
+			continue
+		}
+		if addr, ok := addrByID[nodes[i].Master]; ok {
+			nodes[i].Master = addr
+		}
+	}
+	group := func(n NodeInfo) (int, string) {
+		if n.Role == "master" {
+			return n.firstSlot, n.Addr
+		}
+		if slot, ok := firstSlot[n.Master]; ok {
+			return slot, n.Master
+		}
+		return -1, n.Master
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		si, gi := group(nodes[i])
+		sj, gj := group(nodes[j])
+		if si != sj {
+			return si < sj
+		}
+		if gi != gj {
+			return gi < gj
+		}
+		if nodes[i].Role != nodes[j].Role {
+			return nodes[i].Role == "master"
+		}
+		return nodes[i].Addr < nodes[j].Addr
+	})
+	return nodes
+}
+
+// parseReplicas reads the slaveN lines of INFO replication, e.g.
+// "ip=10.0.0.2,port=6379,state=online,offset=1234,lag=0".
+func parseReplicas(master string, repl map[string]string) []NodeInfo {
+	var out []NodeInfo
+	for i := 0; ; i++ {
+		line, ok := repl[fmt.Sprintf("slave%d", i)]
+		if !ok {
+			break
+		}
+		_, values := parseInfoList(line)
+		offset, err := strconv.ParseInt(values["offset"], 10, 64)
+		if err != nil {
+			offset = -1
+		}
+		out = append(out, NodeInfo{
+			Addr:   values["ip"] + ":" + values["port"],
+			Role:   "replica",
+			Master: master,
+			Offset: offset,
+			Link:   values["state"],
+		})
+	}
+	return out
+}
+
+// replOffset picks the replication offset from INFO replication: the master
+// offset on masters, the processed offset on replicas.
+func replOffset(repl map[string]string) int64 {
+	field := "master_repl_offset"
+	if repl["role"] == "slave" {
+		field = "slave_repl_offset"
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(repl[field]), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return n
+}
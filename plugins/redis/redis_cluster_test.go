@@ -0,0 +1,123 @@
+package redis
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConnectionFromSettings(t *testing.T) {
+	conn, err := connectionFromSettings(map[string]string{
+		"host":        "sentinel-1",
+		"mode":        "Sentinel",
+		"master_name": "mymaster",
+		"database":    "2",
+		"tls":         "true",
+	})
+	if err != nil {
+		t.Fatalf("connectionFromSettings: %v", err)
+	}
+	if conn.Mode != ModeSentinel || conn.Nodes[0] != "sentinel-1:26379" || conn.Database != 2 || conn.TLS == nil {
+		t.Fatalf("conn = %+v, want sentinel on sentinel-1:26379 db 2 with tls", conn)
+	}
+
+	conn, err = connectionFromSettings(map[string]string{
+		"mode":     "cluster",
+		"nodes":    "a:7000, b:7001,",
+		"database": "3",
+	})
+	if err != nil {
+		t.Fatalf("connectionFromSettings: %v", err)
+	}
+	if len(conn.Nodes) != 2 || conn.Nodes[1] != "b:7001" || conn.Database != 0 || conn.TLS != nil {
+		t.Fatalf("conn = %+v, want two seeds, db 0, no tls", conn)
+	}
+
+	for _, bad := range []map[string]string{
+		{"host": "h", "mode": "ring"},
+		{"host": "h", "mode": "sentinel"},
+		{"host": "h", "tls_cert": "-----BEGIN CERTIFICATE-----"},
+		{"host": "h", "tls_ca": "-----BEGIN CERTIFICATE-----\nnope\n-----END CERTIFICATE-----"},
+		{"port": "6379"},
+	} {
+		if _, err := connectionFromSettings(bad); err == nil {
+			t.Fatalf("connectionFromSettings(%v) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestParseClusterNodes(t *testing.T) {
+	raw := strings.Join([]string{
+		"r2 10.0.0.5:7004@17004 slave m2 0 1 2 connected",
+		"m2 10.0.0.2:7001@17001 master - 0 1 2 connected 5461-10922",
+		"m1 10.0.0.1:7000@17000,node-1 myself,master - 0 0 1 connected 0-5460 [42->-m2]",
+		"r1 10.0.0.4:7003@17003 slave,fail m1 0 1 1 disconnected",
+	}, "\n")
+	nodes := parseClusterNodes(raw)
+	var order []string
+	for _, n := range nodes {
+		order = append(order, n.Addr)
+	}
+	want := "10.0.0.1:7000 10.0.0.4:7003 10.0.0.2:7001 10.0.0.5:7004"
+	if got := strings.Join(order, " "); got != want {
+		t.Fatalf("order = %s, want %s", got, want)
+	}
+	m1, r1 := nodes[0], nodes[1]
+	if !m1.Self || m1.Role != "master" || m1.Slots != "0-5460" || m1.SlotCount != 5461 || m1.Master != "" {
+		t.Fatalf("m1 = %+v", m1)
+	}
+	if r1.Role != "replica" || r1.Master != "10.0.0.1:7000" || r1.Link != "fail" || r1.Offset != -1 {
+		t.Fatalf("r1 = %+v", r1)
+	}
+}
+
+func TestParseReplicas(t *testing.T) {
+	repl := map[string]string{
+		"role":               "master",
+		"master_repl_offset": "900",
+		"slave0":             "ip=10.0.0.2,port=6379,state=online,offset=880,lag=0",
+		"slave1":             "ip=10.0.0.3,port=6379,state=wait_bgsave,offset=0,lag=1",
+	}
+	replicas := parseReplicas("10.0.0.1:6379", repl)
+	if len(replicas) != 2 || replicas[0].Addr != "10.0.0.2:6379" || replicas[0].Offset != 880 || replicas[1].Link != "wait_bgsave" {
+		t.Fatalf("replicas = %+v", replicas)
+	}
+	if got := replOffset(repl); got != 900 {
+		t.Fatalf("replOffset = %d, want 900", got)
+	}
+	if got := replOffset(map[string]string{"role": "slave", "slave_repl_offset": "12"}); got != 12 {
+		t.Fatalf("replica replOffset = %d, want 12", got)
+	}
+}
+
+func TestAggregateInfo(t *testing.T) {
+	got := aggregateInfo([]map[string]string{
+		{
+			"redis_version":    "7.2.4",
+			"used_memory":      "1048576",
+			"keyspace_hits":    "10",
+			"db0":              "keys=10,expires=2,avg_ttl=100",
+			"cmdstat_get":      "calls=10,usec=100,usec_per_call=10.00",
+			"connected_slaves": "1",
+		},
+		{
+			"redis_version": "7.2.4",
+			"used_memory":   "1048576",
+			"keyspace_hits": "5",
+			"db0":           "keys=5,expires=1,avg_ttl=300",
+			"cmdstat_get":   "calls=30,usec=100,usec_per_call=3.33",
+		},
+	})
+	for k, want := range map[string]string{
+		"used_memory":       "2097152",
+		"used_memory_human": "2.00M",
+		"keyspace_hits":     "15",
+		"db0":               "keys=15,expires=3,avg_ttl=300",
+		"cmdstat_get":       "calls=40,usec=200,usec_per_call=5.00",
+		"redis_version":     "7.2.4",
+		"cluster_masters":   "2",
+	} {
+		if got[k] != want {
+			t.Fatalf("%s = %q, want %q", k, got[k], want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package redis
+
+import (
+	"fmt"
+	"strings"
+)
+
+// commandFlags is what COMMAND INFO says about one command or subcommand.
+type commandFlags struct {
+	write     bool // "write", or "may_replicate" without "readonly"
+	admin     bool // "admin" command flag
+	dangerous bool // @dangerous ACL category
+}
+
+// CommandFlags returns the COMMAND INFO flags of name, or of its subcommand
+// sub when sub is not empty. ok is false when the server does not know the
+// command or could not be asked. Replies are cached until the next Connect,
+// one COMMAND INFO per command name, subcommands included.
+func (c *RedisClient) CommandFlags(name, sub string) (commandFlags, bool) {
+	if !c.connected || c.client == nil {
+		return commandFlags{}, false
+	}
+	name = strings.ToUpper(name)
+	if _, seen := c.commands[name]; !seen {
+		reply, err := c.client.Do(c.ctx, "COMMAND", "INFO", name).Result()
+		if err != nil {
+			// Older servers, ACL users without COMMAND and transport errors
+			// all leave the caller on its static lists; ask again next time.
+			return commandFlags{}, false
+		}
+		if c.commands == nil {
+			c.commands = map[string]*commandFlags{}
+		}
+		c.commands[name] = nil
+		if entries, ok := reply.([]interface{}); ok && len(entries) == 1 {
+			storeCommandInfo(c.commands, entries[0], "")
+		}
+	}
+	key := name
+	if sub != "" {
+		key += "|" + strings.ToUpper(sub)
+	}
+	if f := c.commands[key]; f != nil {
+		return *f, true
+	}
+	return commandFlags{}, false
+}
+
+// storeCommandInfo records one COMMAND INFO entry and its subcommands under
+// their upper-cased names ("CONFIG", "CONFIG|SET"). A nil entry is an
+// unknown command and stores nothing.
+func storeCommandInfo(into map[string]*commandFlags, entry interface{}, parent string) {
+	fields, ok := entry.([]interface{})
+	if !ok || len(fields) < 3 {
+		return
+	}
+	name := strings.ToUpper(fmt.Sprint(fields[0]))
+	if parent != "" && !strings.Contains(name, "|") {
+		name = parent + "|" + name
+	}
+	var f commandFlags
+	var mayReplicate, readOnly bool
+	for _, flag := range replyStrings(fields[2]) {
+		switch strings.ToLower(flag) {
+		case "write":
+			f.write = true
+		case "admin":
+			f.admin = true
+		case "may_replicate":
+			mayReplicate = true
+		case "readonly":
+			readOnly = true
+		}
+	}
+	// EVAL, EVALSHA and FCALL carry may_replicate rather than write: the
+	// server cannot tell what the script does, so neither can we.
+	if mayReplicate && !readOnly {
+		f.write = true
+	}
+	if len(fields) > 6 {
+		for _, cat := range replyStrings(fields[6]) {
+			if strings.EqualFold(cat, "@dangerous") {
+				f.dangerous = true
+			}
+		}
+	}
+	into[name] = &f
+	if len(fields) > 9 {
+		subs, _ := fields[9].([]interface{})
+		for _, sub := range subs {
+			storeCommandInfo(into, sub, name)
+		}
+	}
+}
+
+// replyStrings reads an array (or RESP3 set) of simple strings.
+func replyStrings(v interface{}) []string {
+	items, _ := v.([]interface{})
+	out := make([]string, 0, len(items))
+	for _, s := range items {
+		out = append(out, fmt.Sprint(s))
+	}
+	return out
+}
@@ -1,7 +1,11 @@
 package redis
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"os"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,9 +24,22 @@ import (
 //	Notes    → description / notes
 //
 //	Custom Attributes:
-//	  port     → Redis port (default: 6379)
-//	  database → Redis database index (default: 0)
+//	  port     → Redis port (default: 6379, 26379 for sentinel)
+//	  database → Redis database index (default: 0, always 0 in cluster mode)
 //	  tags     → comma-separated tags
+//	  mode     → standalone (default), cluster or sentinel
+//	  nodes    → comma-separated host:port seed nodes (cluster) or
+//	             sentinels (sentinel); defaults to URL:port
+//	  master_name       → sentinel master name (required for sentinel)
+//	  sentinel_password → password of the sentinels, if it differs
+//	  tls               → true to connect over TLS
+//	  tls_ca            → CA bundle, inline PEM or file path
+//	  tls_cert          → client certificate, inline PEM or file path
+//	  tls_key           → client key, inline PEM or file path
+//	  tls_server_name   → server name to verify instead of the host
+//	  tls_skip_verify   → true to skip certificate verification
+//
+// Any tls_ca, tls_cert or tls_key attribute implies tls.
 type RedisInstance struct {
 	Name        string
 	Description string
@@ -33,6 +50,134 @@ type RedisInstance struct {
 	Password    string
 	Database    int
 	Tags        []string
+	Mode        string
+	Nodes       []string
+	MasterName  string
+}
+
+// Connection modes of the mode attribute.
+const (
+	ModeStandalone = "standalone"
+	ModeCluster    = "cluster"
+	ModeSentinel   = "sentinel"
+)
+
+// connectionFromSettings builds the connection described by a KeePass entry
+// flattened into Configure settings (see the RedisInstance schema).
+func connectionFromSettings(settings map[string]string) (RedisConnection, error) {
+	conn := RedisConnection{
+		Host:             strings.TrimSpace(settings["host"]),
+		Port:             strings.TrimSpace(settings["port"]),
+		Username:         settings["username"],
+		Password:         settings["password"],
+		Mode:             strings.ToLower(strings.TrimSpace(settings["mode"])),
+		Nodes:            splitList(settings["nodes"]),
+		MasterName:       strings.TrimSpace(settings["master_name"]),
+		SentinelPassword: settings["sentinel_password"],
+	}
+	switch conn.Mode {
+	case "":
+		conn.Mode = ModeStandalone
+	case ModeStandalone, ModeCluster, ModeSentinel:
+	default:
+		return RedisConnection{}, fmt.Errorf("unknown mode %q (want standalone, cluster or sentinel)", conn.Mode)
+	}
+	if conn.Port == "" {
+		conn.Port = "6379"
+		if conn.Mode == ModeSentinel {
+			conn.Port = "26379"
+		}
+	}
+	if db := settings["database"]; db != "" && conn.Mode != ModeCluster {
+		if n, err := strconv.Atoi(db); err == nil {
+			conn.Database = n
+		}
+	}
+	if conn.Host == "" && len(conn.Nodes) == 0 {
+		return RedisConnection{}, errors.New("host is required")
+	}
+	if len(conn.Nodes) == 0 {
+		conn.Nodes = []string{conn.Host + ":" + conn.Port}
+	}
+	if conn.Mode == ModeSentinel && conn.MasterName == "" {
+		return RedisConnection{}, errors.New("master_name is required in sentinel mode")
+	}
+	tlsConf, err := tlsFromSettings(settings)
+	if err != nil {
+		return RedisConnection{}, err
+	}
+	conn.TLS = tlsConf
+	return conn, nil
+}
+
+// tlsFromSettings returns nil when the entry does not ask for TLS.
+func tlsFromSettings(settings map[string]string) (*tls.Config, error) {
+	ca, cert, key := settings["tls_ca"], settings["tls_cert"], settings["tls_key"]
+	if !isTrue(settings["tls"]) && ca == "" && cert == "" && key == "" {
+		return nil, nil
+	}
+	conf := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         strings.TrimSpace(settings["tls_server_name"]),
+		InsecureSkipVerify: isTrue(settings["tls_skip_verify"]),
+	}
+	if ca != "" {
+		pem, err := pemOrFile(ca)
+		if err != nil {
+			return nil, fmt.Errorf("tls_ca: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("tls_ca: no certificates found")
+		}
+		conf.RootCAs = pool
+	}
+	if (cert == "") != (key == "") {
+		return nil, errors.New("tls_cert and tls_key must be set together")
+	}
+	if cert != "" {
+		certPEM, err := pemOrFile(cert)
+		if err != nil {
+			return nil, fmt.Errorf("tls_cert: %w", err)
+		}
+		keyPEM, err := pemOrFile(key)
+		if err != nil {
+			return nil, fmt.Errorf("tls_key: %w", err)
+		}
+		pair, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("tls client certificate: %w", err)
+		}
+		conf.Certificates = []tls.Certificate{pair}
+	}
+	return conf, nil
+}
+
+// pemOrFile returns v itself when it holds inline PEM, else the file it names.
+func pemOrFile(v string) ([]byte, error) {
+	v = strings.TrimSpace(v)
+	if strings.HasPrefix(v, "-----BEGIN") {
+		return []byte(v), nil
+	}
+	return os.ReadFile(v)
+}
+
+func isTrue(v string) bool {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
+
+func splitList(v string) []string {
+	var out []string
+	for _, part := range strings.Split(v, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
 }
 
 // UIConfig holds hardcoded UI defaults for the Redis plugin.
@@ -135,13 +280,11 @@ func entryToRedisInstance(entry *pluginapi.SecretEntry, env string) RedisInstanc
 		}
 	}
 	if v, ok := ca["tags"]; ok {
-		for _, t := range strings.Split(v, ",") {
-			t = strings.TrimSpace(t)
-			if t != "" {
-				inst.Tags = append(inst.Tags, t)
-			}
-		}
+		inst.Tags = splitList(v)
 	}
+	inst.Mode = ca["mode"]
+	inst.Nodes = splitList(ca["nodes"])
+	inst.MasterName = ca["master_name"]
 
 	return inst
 }
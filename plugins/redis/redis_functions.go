@@ -0,0 +1,158 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Restore policies of FUNCTION RESTORE.
+const (
+	fnRestoreAppend  = "APPEND"
+	fnRestoreReplace = "REPLACE"
+	fnRestoreFlush   = "FLUSH"
+)
+
+// Functions lists the loaded libraries with their code (Redis 7+).
+func (c *RedisClient) Functions() ([]redis.Library, error) {
+	if !c.connected || c.client == nil {
+		return nil, errors.New("not connected to any Redis server")
+	}
+	libs, err := c.client.FunctionList(c.ctx, redis.FunctionListQuery{WithCode: true}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("FUNCTION LIST failed (functions need Redis 7+): %v", err)
+	}
+	return libs, nil
+}
+
+// eachMaster runs a write on the server, or on every master of a cluster.
+// Functions replicate, so unlike ACLs they are never sent to replicas.
+func (c *RedisClient) eachMaster(name string, args ...interface{}) error {
+	masters, err := c.masters()
+	if err != nil {
+		return err
+	}
+	for _, m := range masters {
+		if err := m.Do(c.ctx, args...).Err(); err != nil {
+			if len(masters) > 1 {
+				return fmt.Errorf("%s failed on %s: %v", name, m.Options().Addr, err)
+			}
+			return fmt.Errorf("%s failed: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// LoadFunction loads a library from its code; the library name comes from
+// the #!lua name=… shebang.
+func (c *RedisClient) LoadFunction(code string, replace bool) error {
+	args := []interface{}{"FUNCTION", "LOAD"}
+	if replace {
+		args = append(args, "REPLACE")
+	}
+	return c.eachMaster("FUNCTION LOAD", append(args, code)...)
+}
+
+// DeleteFunction deletes a library.
+func (c *RedisClient) DeleteFunction(library string) error {
+	return c.eachMaster("FUNCTION DELETE", "FUNCTION", "DELETE", library)
+}
+
+// FlushFunctions deletes every library.
+func (c *RedisClient) FlushFunctions() error {
+	return c.eachMaster("FUNCTION FLUSH", "FUNCTION", "FLUSH")
+}
+
+// DumpFunctions returns the FUNCTION DUMP payload of every library.
+func (c *RedisClient) DumpFunctions() (string, error) {
+	masters, err := c.masters()
+	if err != nil {
+		return "", err
+	}
+	dump, err := masters[0].FunctionDump(c.ctx).Result()
+	if err != nil {
+		return "", fmt.Errorf("FUNCTION DUMP failed: %v", err)
+	}
+	return dump, nil
+}
+
+// RestoreFunctions loads a FUNCTION DUMP payload with one of the restore
+// policies.
+func (c *RedisClient) RestoreFunctions(dump, policy string) error {
+	return c.eachMaster("FUNCTION RESTORE", "FUNCTION", "RESTORE", dump, policy)
+}
+
+// EvalScript runs a Lua script with EVAL, or with EVAL_RO (Redis 7+) when
+// readOnly is set so the server rejects any write.
+func (c *RedisClient) EvalScript(script string, keys, args []string, readOnly bool) (interface{}, error) {
+	if !c.connected || c.client == nil {
+		return nil, errors.New("not connected to any Redis server")
+	}
+	argv := make([]interface{}, len(args))
+	for i, a := range args {
+		argv[i] = a
+	}
+	eval := c.client.Eval
+	if readOnly {
+		eval = c.client.EvalRO
+	}
+	reply, err := eval(c.ctx, script, keys, argv...).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	return reply, err
+}
+
+// parseRestorePolicy reads a FUNCTION RESTORE policy; blank means APPEND.
+func parseRestorePolicy(v string) (string, error) {
+	switch p := strings.ToUpper(strings.TrimSpace(v)); p {
+	case "":
+		return fnRestoreAppend, nil
+	case fnRestoreAppend, fnRestoreReplace, fnRestoreFlush:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown restore policy %q (append, replace or flush)", v)
+}
+
+// libraryName reads the library name from the "#!lua name=<lib>" shebang
+// that FUNCTION LOAD requires on the first line.
+func libraryName(code string) (string, error) {
+	first, _, _ := strings.Cut(code, "\n")
+	first = strings.TrimSpace(first)
+	rest, ok := strings.CutPrefix(first, "#!")
+	if !ok {
+		return "", errors.New(`missing "#!<engine> name=<library>" first line`)
+	}
+	fields := strings.Fields(rest)
+	for i := 1; i < len(fields); i++ {
+		if name, ok := strings.CutPrefix(fields[i], "name="); ok && name != "" {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no name= in %q", first)
+}
+
+// readLocalFile reads a local script or dump file, expanding a leading ~.
+func readLocalFile(path string) (string, string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", "", errors.New("no file given")
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return "", "", fmt.Errorf("%s is empty", path)
+	}
+	return path, string(data), nil
+}
@@ -0,0 +1,60 @@
+package redis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLibraryName(t *testing.T) {
+	cases := []struct {
+		code, want string
+		ok         bool
+	}{
+		{"#!lua name=mylib\nredis.register_function('f', function() return 1 end)", "mylib", true},
+		{"  #!lua   name=spaced  \n", "spaced", true},
+		{"#!lua\nreturn 1", "", false},
+		{"#!\n", "", false},
+		{"return 1", "", false},
+	}
+	for _, c := range cases {
+		got, err := libraryName(c.code)
+		if got != c.want || (err == nil) != c.ok {
+			t.Fatalf("libraryName(%q) = %q, %v, want %q, ok=%v", c.code, got, err, c.want, c.ok)
+		}
+	}
+}
+
+func TestParseRestorePolicy(t *testing.T) {
+	for in, want := range map[string]string{"": fnRestoreAppend, "replace": fnRestoreReplace, " Flush ": fnRestoreFlush} {
+		got, err := parseRestorePolicy(in)
+		if err != nil || got != want {
+			t.Fatalf("parseRestorePolicy(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := parseRestorePolicy("merge"); err == nil {
+		t.Fatalf("parseRestorePolicy accepted merge")
+	}
+}
+
+func TestReadLocalFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "script.lua")
+	if err := os.WriteFile(path, []byte("return 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, code, err := readLocalFile(" " + path + " ")
+	if err != nil || got != path || code != "return 1\n" {
+		t.Fatalf("readLocalFile = %q, %q, %v", got, code, err)
+	}
+	empty := filepath.Join(dir, "empty.lua")
+	if err := os.WriteFile(empty, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := readLocalFile(empty); err == nil {
+		t.Fatalf("readLocalFile accepted an empty file")
+	}
+	if _, _, err := readLocalFile(""); err == nil {
+		t.Fatalf("readLocalFile accepted no path")
+	}
+}
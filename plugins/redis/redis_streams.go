@@ -0,0 +1,251 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// streamListLimit caps how many stream keys the streams view lists.
+	streamListLimit = 500
+	// streamPageSize is how many entries one XRANGE page reads.
+	streamPageSize = 200
+	// streamPendingLimit caps the pending entries listed for a group.
+	streamPendingLimit = 500
+)
+
+// StreamSummary is one row of the streams view.
+type StreamSummary struct {
+	Key     string
+	Length  int64
+	Groups  int64
+	FirstID string
+	LastID  string
+}
+
+// ListStreams finds stream keys with SCAN TYPE stream and reads XINFO STREAM
+// for each, sorted by key.
+func (c *RedisClient) ListStreams(ctx context.Context) ([]StreamSummary, bool, error) {
+	scan := &KeyScan{Pattern: "*", Type: "stream"}
+	keys, err := c.ScanNext(ctx, scan, streamListLimit, keyPageBudget)
+	if err != nil {
+		return nil, false, err
+	}
+	sort.Strings(keys)
+	out := make([]StreamSummary, 0, len(keys))
+	for _, key := range keys {
+		sum := StreamSummary{Key: key, FirstID: "-", LastID: "-"}
+		info, err := c.client.XInfoStream(c.ctx, key).Result()
+		if err == nil {
+			sum.Length, sum.Groups, sum.LastID = info.Length, info.Groups, info.LastGeneratedID
+			if info.FirstEntry.ID != "" {
+				sum.FirstID = info.FirstEntry.ID
+			}
+		}
+		out = append(out, sum)
+	}
+	return out, scan.Done(), nil
+}
+
+// StreamRange reads up to count entries from start (inclusive) onwards.
+func (c *RedisClient) StreamRange(key, start string, count int64) ([]redis.XMessage, error) {
+	if err := c.checkKey(key); err != nil {
+		return nil, err
+	}
+	msgs, err := c.client.XRangeN(c.ctx, key, start, "+", count).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stream %s: %v", key, err)
+	}
+	return msgs, nil
+}
+
+// StreamGroups lists the consumer groups of a stream (XINFO GROUPS).
+func (c *RedisClient) StreamGroups(key string) ([]redis.XInfoGroup, error) {
+	if err := c.checkKey(key); err != nil {
+		return nil, err
+	}
+	groups, err := c.client.XInfoGroups(c.ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups of %s: %v", key, err)
+	}
+	return groups, nil
+}
+
+// StreamPending lists a group's pending entries, oldest first (XPENDING).
+func (c *RedisClient) StreamPending(key, group string) ([]redis.XPendingExt, error) {
+	if err := c.checkKey(key); err != nil {
+		return nil, err
+	}
+	pending, err := c.client.XPendingExt(c.ctx, &redis.XPendingExtArgs{
+		Stream: key,
+		Group:  group,
+		Start:  "-",
+		End:    "+",
+		Count:  streamPendingLimit,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending entries of %s: %v", group, err)
+	}
+	return pending, nil
+}
+
+// StreamAck acknowledges ids for group and returns how many were pending.
+func (c *RedisClient) StreamAck(key, group string, ids []string) (int64, error) {
+	if err := c.checkKey(key); err != nil {
+		return 0, err
+	}
+	n, err := c.client.XAck(c.ctx, key, group, ids...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to ack: %v", err)
+	}
+	return n, nil
+}
+
+// StreamClaim moves ids idle for at least minIdle to consumer (XCLAIM) and
+// returns the ids claimed.
+func (c *RedisClient) StreamClaim(key, group, consumer string, minIdle time.Duration, ids []string) ([]string, error) {
+	if err := c.checkKey(key); err != nil {
+		return nil, err
+	}
+	claimed, err := c.client.XClaimJustID(c.ctx, &redis.XClaimArgs{
+		Stream:   key,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim: %v", err)
+	}
+	return claimed, nil
+}
+
+// StreamAutoClaim claims up to count entries idle for at least minIdle
+// (XAUTOCLAIM, Redis 6.2+).
+func (c *RedisClient) StreamAutoClaim(key, group, consumer string, minIdle time.Duration, count int64) ([]string, error) {
+	if err := c.checkKey(key); err != nil {
+		return nil, err
+	}
+	ids, _, err := c.client.XAutoClaimJustID(c.ctx, &redis.XAutoClaimArgs{
+		Stream:   key,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Start:    "0-0",
+		Count:    count,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to autoclaim: %v", err)
+	}
+	return ids, nil
+}
+
+// StreamTrim trims a stream to about maxLen entries (XTRIM MAXLEN ~) and
+// returns how many were removed. exact trims to exactly maxLen.
+func (c *RedisClient) StreamTrim(key string, maxLen int64, exact bool) (int64, error) {
+	if err := c.checkKey(key); err != nil {
+		return 0, err
+	}
+	cmd := c.client.XTrimMaxLenApprox(c.ctx, key, maxLen, 0)
+	if exact {
+		cmd = c.client.XTrimMaxLen(c.ctx, key, maxLen)
+	}
+	n, err := cmd.Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to trim %s: %v", key, err)
+	}
+	return n, nil
+}
+
+// StreamAdd appends an entry with an auto-generated ID and returns the ID.
+func (c *RedisClient) StreamAdd(key string, fields []string) (string, error) {
+	if err := c.checkKey(key); err != nil {
+		return "", err
+	}
+	id, err := c.client.XAdd(c.ctx, &redis.XAddArgs{Stream: key, ID: "*", Values: fields}).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to add to %s: %v", key, err)
+	}
+	return id, nil
+}
+
+// nextStreamID is the smallest ID after id, so XRANGE can resume without
+// the exclusive "(" syntax older servers lack.
+func nextStreamID(id string) (string, error) {
+	ms, seq, ok := strings.Cut(id, "-")
+	if !ok {
+		return "", fmt.Errorf("invalid stream id %q", id)
+	}
+	m, err := strconv.ParseUint(ms, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid stream id %q", id)
+	}
+	s, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid stream id %q", id)
+	}
+	if s == ^uint64(0) {
+		return fmt.Sprintf("%d-0", m+1), nil
+	}
+	return fmt.Sprintf("%d-%d", m, s+1), nil
+}
+
+// streamFields renders an entry's fields as field=value pairs, quoting
+// those with spaces the way the console parses them.
+func streamFields(values map[string]interface{}) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = quoteField(k) + "=" + quoteField(fmt.Sprint(values[k]))
+	}
+	return strings.Join(parts, " ")
+}
+
+func quoteField(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\"'=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// parseStreamFields reads "field=value ..." (console quoting) into XADD
+// arguments.
+func parseStreamFields(text string) ([]string, error) {
+	args, err := splitArgs(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, errors.New("at least one field=value is required")
+	}
+	out := make([]string, 0, 2*len(args))
+	for _, arg := range args {
+		field, value, ok := strings.Cut(arg, "=")
+		if !ok || field == "" {
+			return nil, fmt.Errorf("%q is not field=value", arg)
+		}
+		out = append(out, field, value)
+	}
+	return out, nil
+}
+
+// streamIDTime is the wall-clock time encoded in an entry ID.
+func streamIDTime(id string) time.Time {
+	ms, _, _ := strings.Cut(id, "-")
+	n, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(n)
+}
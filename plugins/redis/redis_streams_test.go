@@ -0,0 +1,80 @@
+package redis
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNextStreamID(t *testing.T) {
+	for id, want := range map[string]string{
+		"1700000000000-0":        "1700000000000-1",
+		"5-18446744073709551615": "6-0",
+		"0-41":                   "0-42",
+	} {
+		got, err := nextStreamID(id)
+		if err != nil || got != want {
+			t.Fatalf("nextStreamID(%q) = %q, %v, want %q", id, got, err, want)
+		}
+	}
+	if _, err := nextStreamID("abc"); err == nil {
+		t.Fatalf("nextStreamID accepted an invalid id")
+	}
+}
+
+func TestStreamFieldsRoundTrip(t *testing.T) {
+	values := map[string]interface{}{"job": "resize", "note": "two words", "empty": ""}
+	text := streamFields(values)
+	if want := `empty="" job=resize note="two words"`; text != want {
+		t.Fatalf("streamFields = %s, want %s", text, want)
+	}
+	fields, err := parseStreamFields(text)
+	if err != nil {
+		t.Fatalf("parseStreamFields: %v", err)
+	}
+	if want := []string{"empty", "", "job", "resize", "note", "two words"}; !reflect.DeepEqual(fields, want) {
+		t.Fatalf("fields = %q, want %q", fields, want)
+	}
+	for _, bad := range []string{"", "novalue", "=x"} {
+		if _, err := parseStreamFields(bad); err == nil {
+			t.Fatalf("parseStreamFields(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestStreamWritesFollowProtection(t *testing.T) {
+	s := NewService()
+	s.conn = RedisConnection{Host: "db", Port: "6379"}
+	payload := map[string]string{"key": "jobs", "fields": "job=resize"}
+
+	s.protected = protectBlock
+	for action := range streamWrites {
+		res := s.guardWriteLocked(action, streamWrites[action], payload)
+		if res == nil || res.OK || !strings.HasPrefix(res.Message, "blocked:") {
+			t.Fatalf("%s on a blocked target = %+v, want blocked", action, res)
+		}
+	}
+
+	s.protected = protectConfirm
+	for action := range streamWrites {
+		res := s.guardWriteLocked(action, streamWrites[action], payload)
+		if res == nil || res.Confirm == nil || res.Confirm.Action != action {
+			t.Fatalf("%s on a confirm target = %+v, want a confirmation", action, res)
+		}
+		next := res.Confirm.Payload
+		if next["confirmed"] != "true" || next["fields"] != "job=resize" {
+			t.Fatalf("%s confirm payload = %v, want the original payload with confirmed=true", action, next)
+		}
+		if res := s.guardWriteLocked(action, streamWrites[action], next); res != nil {
+			t.Fatalf("%s after confirming = %+v, want it to go ahead", action, res)
+		}
+	}
+	if _, ok := streamWrites["stream_claim"]; !ok {
+		t.Fatalf("stream_claim is not treated as a write")
+	}
+
+	s.protected = protectOff
+	if res := s.guardWriteLocked("stream_add", streamWrites["stream_add"], payload); res != nil {
+		t.Fatalf("stream_add on an unprotected target = %+v, want it to go ahead", res)
+	}
+}
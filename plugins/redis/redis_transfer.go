@@ -0,0 +1,368 @@
+package redis
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// transferLimit caps how many keys one dump, import or copy moves; these
+// are for moving a few keys around, not for migrating a database.
+const transferLimit = 10000
+
+// Import and copy policies for keys that already exist at the destination.
+const (
+	policySkip    = "skip"
+	policyReplace = "replace"
+)
+
+// dumpFile is the export format: DUMP payloads for a faithful RESTORE, plus
+// the value in readable JSON for review, hand edits and servers whose RDB
+// version cannot load the payload.
+type dumpFile struct {
+	Source  string      `json:"source"`
+	DB      int         `json:"db"`
+	Created time.Time   `json:"created"`
+	Keys    []keyRecord `json:"keys"`
+}
+
+// keyRecord is one exported key. TTL is in milliseconds, 0 for no expiry.
+// A record without Dump is written from Value.
+type keyRecord struct {
+	Key   string      `json:"key"`
+	Type  string      `json:"type"`
+	TTL   int64       `json:"ttl_ms"`
+	Dump  string      `json:"dump,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// zMember is a sorted set member in the readable format.
+type zMember struct {
+	Member string  `json:"member"`
+	Score  float64 `json:"score"`
+}
+
+// streamEntry is a stream entry in the readable format.
+type streamEntry struct {
+	ID     string            `json:"id"`
+	Fields map[string]string `json:"fields"`
+}
+
+// ScanAll collects keys matching pattern, up to limit. It reports whether
+// the keyspace was scanned to the end.
+func (c *RedisClient) ScanAll(ctx context.Context, pattern string, limit int) ([]string, bool, error) {
+	scan := &KeyScan{Pattern: pattern}
+	var keys []string
+	for !scan.Done() && len(keys) < limit {
+		page, err := c.ScanNext(ctx, scan, limit-len(keys), keyPageBudget)
+		if err != nil {
+			return nil, false, err
+		}
+		if ctx.Err() != nil {
+			return nil, false, errors.New("scan cancelled")
+		}
+		keys = append(keys, page...)
+	}
+	return keys, scan.Done(), nil
+}
+
+// DumpKey reads key with DUMP, PTTL and TYPE, and its readable value when
+// readable is set. A missing key returns a nil record.
+func (c *RedisClient) DumpKey(key string, readable bool) (*keyRecord, error) {
+	var dump *redis.StringCmd
+	var ttl *redis.DurationCmd
+	var typ *redis.StatusCmd
+	_, err := c.client.Pipelined(c.ctx, func(p redis.Pipeliner) error {
+		dump = p.Dump(c.ctx, key)
+		ttl = p.PTTL(c.ctx, key)
+		typ = p.Type(c.ctx, key)
+		return nil
+	})
+	if errors.Is(dump.Err(), redis.Nil) {
+		return nil, nil
+	}
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to dump %s: %v", key, err)
+	}
+	rec := &keyRecord{
+		Key:  key,
+		Type: typ.Val(),
+		Dump: base64.StdEncoding.EncodeToString([]byte(dump.Val())),
+	}
+	if d := ttl.Val(); d > 0 {
+		rec.TTL = d.Milliseconds()
+	}
+	if readable {
+		if rec.Value, err = c.readableValue(key, rec.Type); err != nil {
+			return nil, err
+		}
+	}
+	return rec, nil
+}
+
+// readableValue reads a whole value as plain JSON data.
+func (c *RedisClient) readableValue(key, keyType string) (interface{}, error) {
+	var (
+		v   interface{}
+		err error
+	)
+	switch keyType {
+	case "string":
+		v, err = c.client.Get(c.ctx, key).Result()
+	case "hash":
+		v, err = c.client.HGetAll(c.ctx, key).Result()
+	case "list":
+		v, err = c.client.LRange(c.ctx, key, 0, -1).Result()
+	case "set":
+		var members []string
+		members, err = c.client.SMembers(c.ctx, key).Result()
+		sort.Strings(members)
+		v = members
+	case "zset":
+		var zs []redis.Z
+		zs, err = c.client.ZRangeWithScores(c.ctx, key, 0, -1).Result()
+		out := make([]zMember, len(zs))
+		for i, z := range zs {
+			out[i] = zMember{Member: fmt.Sprint(z.Member), Score: z.Score}
+		}
+		v = out
+	case "stream":
+		var msgs []redis.XMessage
+		msgs, err = c.client.XRange(c.ctx, key, "-", "+").Result()
+		out := make([]streamEntry, len(msgs))
+		for i, m := range msgs {
+			fields := make(map[string]string, len(m.Values))
+			for f, val := range m.Values {
+				fields[f] = fmt.Sprint(val)
+			}
+			out[i] = streamEntry{ID: m.ID, Fields: fields}
+		}
+		v = out
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", key, err)
+	}
+	return v, nil
+}
+
+// ExistingKeys reports which of keys exist.
+func (c *RedisClient) ExistingKeys(keys []string) (map[string]bool, error) {
+	cmds := make([]*redis.IntCmd, len(keys))
+	_, err := c.client.Pipelined(c.ctx, func(p redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = p.Exists(c.ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check keys: %v", err)
+	}
+	out := make(map[string]bool, len(keys))
+	for i, key := range keys {
+		out[key] = cmds[i].Val() > 0
+	}
+	return out, nil
+}
+
+// RestoreKey writes rec with RESTORE, falling back to its readable value
+// when there is no payload or the server rejects it (an older RDB version).
+// replace overwrites an existing key.
+func (c *RedisClient) RestoreKey(rec keyRecord, replace bool) error {
+	ttl := time.Duration(rec.TTL) * time.Millisecond
+	if rec.Dump != "" {
+		payload, err := base64.StdEncoding.DecodeString(rec.Dump)
+		if err != nil {
+			return fmt.Errorf("%s: invalid dump payload: %v", rec.Key, err)
+		}
+		cmd := c.client.Restore
+		if replace {
+			cmd = c.client.RestoreReplace
+		}
+		err = cmd(c.ctx, rec.Key, ttl, string(payload)).Err()
+		if err == nil {
+			return nil
+		}
+		if rec.Value == nil || !strings.Contains(err.Error(), "payload version") {
+			return fmt.Errorf("%s: %v", rec.Key, err)
+		}
+	}
+	if err := rec.normalize(); err != nil {
+		return fmt.Errorf("%s: %v", rec.Key, err)
+	}
+	return c.writeReadable(rec, replace)
+}
+
+// normalize round-trips Value through JSON so values read from a server
+// look the same as values loaded from a file.
+func (rec *keyRecord) normalize() error {
+	data, err := json.Marshal(rec.Value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &rec.Value)
+}
+
+// writeReadable writes rec.Value in one MULTI, deleting the old key first.
+func (c *RedisClient) writeReadable(rec keyRecord, replace bool) error {
+	if !replace {
+		n, err := c.client.Exists(c.ctx, rec.Key).Result()
+		if err != nil {
+			return fmt.Errorf("%s: %v", rec.Key, err)
+		}
+		if n > 0 {
+			return fmt.Errorf("%s: key already exists", rec.Key)
+		}
+	}
+	write, err := readableWriter(rec)
+	if err != nil {
+		return fmt.Errorf("%s: %v", rec.Key, err)
+	}
+	_, err = c.client.TxPipelined(c.ctx, func(p redis.Pipeliner) error {
+		p.Del(c.ctx, rec.Key)
+		write(p)
+		if rec.TTL > 0 {
+			p.PExpire(c.ctx, rec.Key, time.Duration(rec.TTL)*time.Millisecond)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %v", rec.Key, err)
+	}
+	return nil
+}
+
+// readableWriter checks rec.Value, as decoded from JSON, against rec.Type
+// and returns the commands that write it.
+func readableWriter(rec keyRecord) (func(redis.Pipeliner), error) {
+	ctx, key := context.Background(), rec.Key
+	switch rec.Type {
+	case "string":
+		s, ok := rec.Value.(string)
+		if !ok {
+			return nil, errors.New("string value must be a JSON string")
+		}
+		return func(p redis.Pipeliner) { p.Set(ctx, key, s, 0) }, nil
+	case "hash":
+		m, ok := rec.Value.(map[string]interface{})
+		if !ok || len(m) == 0 {
+			return nil, errors.New("hash value must be a non-empty JSON object")
+		}
+		args := make([]interface{}, 0, 2*len(m))
+		for f, v := range m {
+			args = append(args, f, jsonScalar(v))
+		}
+		return func(p redis.Pipeliner) { p.HSet(ctx, key, args...) }, nil
+	case "list", "set":
+		items, err := stringList(rec.Value)
+		if err != nil {
+			return nil, err
+		}
+		if rec.Type == "list" {
+			return func(p redis.Pipeliner) { p.RPush(ctx, key, items...) }, nil
+		}
+		return func(p redis.Pipeliner) { p.SAdd(ctx, key, items...) }, nil
+	case "zset":
+		list, ok := rec.Value.([]interface{})
+		if !ok || len(list) == 0 {
+			return nil, errors.New("zset value must be a non-empty list of {member, score}")
+		}
+		zs := make([]redis.Z, 0, len(list))
+		for _, item := range list {
+			m, ok := item.(map[string]interface{})
+			score, okScore := m["score"].(float64)
+			if !ok || !okScore {
+				return nil, errors.New("zset value must be a non-empty list of {member, score}")
+			}
+			zs = append(zs, redis.Z{Member: jsonScalar(m["member"]), Score: score})
+		}
+		return func(p redis.Pipeliner) { p.ZAdd(ctx, key, zs...) }, nil
+	case "stream":
+		list, ok := rec.Value.([]interface{})
+		if !ok || len(list) == 0 {
+			return nil, errors.New("stream value must be a non-empty list of {id, fields}")
+		}
+		adds := make([]*redis.XAddArgs, 0, len(list))
+		for _, item := range list {
+			m, _ := item.(map[string]interface{})
+			id, _ := m["id"].(string)
+			fields, ok := m["fields"].(map[string]interface{})
+			if id == "" || !ok {
+				return nil, errors.New("stream value must be a non-empty list of {id, fields}")
+			}
+			values := make([]interface{}, 0, 2*len(fields))
+			for f, v := range fields {
+				values = append(values, f, jsonScalar(v))
+			}
+			adds = append(adds, &redis.XAddArgs{Stream: key, ID: id, Values: values})
+		}
+		return func(p redis.Pipeliner) {
+			for _, a := range adds {
+				p.XAdd(ctx, a)
+			}
+		}, nil
+	}
+	return nil, fmt.Errorf("no readable value for type %q", rec.Type)
+}
+
+func stringList(v interface{}) ([]interface{}, error) {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, errors.New("value must be a non-empty JSON list")
+	}
+	out := make([]interface{}, len(list))
+	for i, item := range list {
+		out[i] = jsonScalar(item)
+	}
+	return out, nil
+}
+
+// jsonScalar turns a decoded JSON scalar back into the string Redis stores.
+func jsonScalar(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// readDumpFile loads an export written by writeDumpFile. Hand-written files
+// may leave out dump payloads.
+func readDumpFile(path string) (*dumpFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f dumpFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("%s is not a key dump: %v", path, err)
+	}
+	for i, rec := range f.Keys {
+		if rec.Key == "" || (rec.Dump == "" && rec.Value == nil) {
+			return nil, fmt.Errorf("%s: key %d has no name, dump or value", path, i+1)
+		}
+	}
+	return &f, nil
+}
+
+func writeDumpFile(path string, f *dumpFile) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,95 @@
+package redis
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"omo/pkg/pluginrpc"
+)
+
+func TestDumpFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	want := &dumpFile{
+		Source:  "localhost:6379",
+		Created: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Keys: []keyRecord{
+			{Key: "user:1", Type: "hash", TTL: 60000, Dump: "AAEC", Value: map[string]interface{}{"name": "ada"}},
+			{Key: "tags", Type: "set", Value: []interface{}{"a", "b"}},
+		},
+	}
+	if err := writeDumpFile(path, want); err != nil {
+		t.Fatalf("writeDumpFile: %v", err)
+	}
+	got, err := readDumpFile(path)
+	if err != nil {
+		t.Fatalf("readDumpFile: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("readDumpFile = %+v, want %+v", got, want)
+	}
+
+	bad := &dumpFile{Keys: []keyRecord{{Key: "empty", Type: "string"}}}
+	if err := writeDumpFile(path, bad); err != nil {
+		t.Fatalf("writeDumpFile: %v", err)
+	}
+	if _, err := readDumpFile(path); err == nil {
+		t.Fatalf("readDumpFile accepted a key without dump or value")
+	}
+}
+
+func TestReadableWriter(t *testing.T) {
+	valid := []keyRecord{
+		{Key: "s", Type: "string", Value: "hello"},
+		{Key: "h", Type: "hash", Value: map[string]interface{}{"n": 1.5}},
+		{Key: "l", Type: "list", Value: []interface{}{"a", 2.0}},
+		{Key: "z", Type: "zset", Value: []zMember{{Member: "m", Score: 3}}},
+		{Key: "x", Type: "stream", Value: []streamEntry{{ID: "1-0", Fields: map[string]string{"f": "v"}}}},
+	}
+	for _, rec := range valid {
+		if err := rec.normalize(); err != nil {
+			t.Fatalf("normalize(%s): %v", rec.Key, err)
+		}
+		if _, err := readableWriter(rec); err != nil {
+			t.Fatalf("readableWriter(%s) = %v, want ok", rec.Key, err)
+		}
+	}
+	invalid := []keyRecord{
+		{Key: "s", Type: "string", Value: []interface{}{"x"}},
+		{Key: "h", Type: "hash", Value: map[string]interface{}{}},
+		{Key: "z", Type: "zset", Value: []interface{}{map[string]interface{}{"member": "m"}}},
+		{Key: "x", Type: "stream", Value: []interface{}{map[string]interface{}{"fields": map[string]interface{}{}}}},
+		{Key: "m", Type: "module", Value: "x"},
+	}
+	for _, rec := range invalid {
+		if _, err := readableWriter(rec); err == nil {
+			t.Fatalf("readableWriter(%s %v) succeeded, want error", rec.Type, rec.Value)
+		}
+	}
+	if got := jsonScalar(1e21); got != "1000000000000000000000" {
+		t.Fatalf("jsonScalar(1e21) = %s", got)
+	}
+}
+
+func TestTransferKeysSelection(t *testing.T) {
+	s := NewService()
+	payload := map[string]string{
+		"key":                   "a",
+		pluginrpc.PayloadMarked: pluginrpc.EncodeMarkedRows([]map[string]string{{"key": "b"}, {"key": "-"}, {"key": "c"}}),
+	}
+	keys, _, err := s.transferKeysLocked(payload)
+	if err != nil || !reflect.DeepEqual(keys, []string{"b", "c"}) {
+		t.Fatalf("marked keys = %v, %v, want [b c]", keys, err)
+	}
+	keys, _, err = s.transferKeysLocked(map[string]string{"key": "a"})
+	if err != nil || !reflect.DeepEqual(keys, []string{"a"}) {
+		t.Fatalf("selected key = %v, %v, want [a]", keys, err)
+	}
+	if _, _, err := s.transferKeysLocked(map[string]string{"key": "-"}); err == nil {
+		t.Fatalf("placeholder row was accepted as a key")
+	}
+	if _, err := transferPolicy(map[string]string{"policy": "merge"}); err == nil {
+		t.Fatalf("transferPolicy accepted merge")
+	}
+}
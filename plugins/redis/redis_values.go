@@ -0,0 +1,362 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// valueRowLimit caps how many elements the value view reads from one key.
+const valueRowLimit = 1000
+
+// KeyValue is a typed snapshot of one key for the value view.
+type KeyValue struct {
+	Key    string
+	Type   string
+	TTL    time.Duration
+	Length int64
+	// Rows are field/value (hash), index/value (list), member (set) or
+	// member/score (zset), capped at valueRowLimit.
+	Rows [][]string
+	// String holds the whole value of a string key.
+	String string
+}
+
+// Truncated reports whether Rows holds fewer elements than the key.
+func (v *KeyValue) Truncated() bool {
+	return v.Type != "string" && int64(len(v.Rows)) < v.Length
+}
+
+func (c *RedisClient) checkKey(key string) error {
+	if !c.connected || c.client == nil {
+		return errors.New("not connected to any Redis server")
+	}
+	if key == "" {
+		return errors.New("key cannot be empty")
+	}
+	return nil
+}
+
+// ReadValue loads key's type, TTL and elements. A missing key has type "none".
+func (c *RedisClient) ReadValue(key string) (*KeyValue, error) {
+	if err := c.checkKey(key); err != nil {
+		return nil, err
+	}
+	keyType, err := c.client.Type(c.ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key type: %v", err)
+	}
+	v := &KeyValue{Key: key, Type: keyType}
+	if keyType == "none" {
+		return v, nil
+	}
+	if v.TTL, err = c.client.TTL(c.ctx, key).Result(); err != nil {
+		return nil, fmt.Errorf("failed to get key TTL: %v", err)
+	}
+
+	switch keyType {
+	case "string":
+		if v.String, err = c.client.Get(c.ctx, key).Result(); err != nil {
+			return nil, fmt.Errorf("failed to get string value: %v", err)
+		}
+		v.Length = int64(len(v.String))
+	case "hash":
+		v.Length, err = c.client.HLen(c.ctx, key).Result()
+		if err == nil {
+			v.Rows, err = c.scanPairs(func(cursor uint64) *redis.ScanCmd {
+				return c.client.HScan(c.ctx, key, cursor, "*", 200)
+			})
+		}
+	case "list":
+		v.Length, err = c.client.LLen(c.ctx, key).Result()
+		if err == nil {
+			var items []string
+			items, err = c.client.LRange(c.ctx, key, 0, valueRowLimit-1).Result()
+			for i, item := range items {
+				v.Rows = append(v.Rows, []string{strconv.Itoa(i), item})
+			}
+		}
+	case "set":
+		v.Length, err = c.client.SCard(c.ctx, key).Result()
+		if err == nil {
+			v.Rows, err = c.scanMembers(key)
+		}
+	case "zset":
+		v.Length, err = c.client.ZCard(c.ctx, key).Result()
+		if err == nil {
+			var members []redis.Z
+			members, err = c.client.ZRangeWithScores(c.ctx, key, 0, valueRowLimit-1).Result()
+			for _, z := range members {
+				v.Rows = append(v.Rows, []string{fmt.Sprint(z.Member), formatScore(z.Score)})
+			}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s value: %v", keyType, err)
+	}
+	return v, nil
+}
+
+// scanPairs runs a field/value SCAN (HSCAN) up to valueRowLimit pairs,
+// sorted by field.
+func (c *RedisClient) scanPairs(scan func(cursor uint64) *redis.ScanCmd) ([][]string, error) {
+	var (
+		rows   [][]string
+		cursor uint64
+	)
+	for len(rows) < valueRowLimit {
+		batch, next, err := scan(cursor).Result()
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i+1 < len(batch) && len(rows) < valueRowLimit; i += 2 {
+			rows = append(rows, []string{batch[i], batch[i+1]})
+		}
+		if cursor = next; cursor == 0 {
+			break
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i][0] < rows[j][0] })
+	return rows, nil
+}
+
+func (c *RedisClient) scanMembers(key string) ([][]string, error) {
+	var (
+		rows   [][]string
+		cursor uint64
+	)
+	for len(rows) < valueRowLimit {
+		batch, next, err := c.client.SScan(c.ctx, key, cursor, "*", 200).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range batch {
+			if len(rows) == valueRowLimit {
+				break
+			}
+			rows = append(rows, []string{m})
+		}
+		if cursor = next; cursor == 0 {
+			break
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i][0] < rows[j][0] })
+	return rows, nil
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
+
+// ValueField reads one editable element: the whole string, a hash field
+// or a list index.
+func (c *RedisClient) ValueField(key, keyType, field string) (string, error) {
+	if err := c.checkKey(key); err != nil {
+		return "", err
+	}
+	var (
+		value string
+		err   error
+	)
+	switch keyType {
+	case "string":
+		value, err = c.client.Get(c.ctx, key).Result()
+	case "hash":
+		value, err = c.client.HGet(c.ctx, key, field).Result()
+	case "list":
+		var index int64
+		if index, err = strconv.ParseInt(field, 10, 64); err != nil {
+			return "", fmt.Errorf("invalid list index %q", field)
+		}
+		value, err = c.client.LIndex(c.ctx, key, index).Result()
+	default:
+		return "", fmt.Errorf("%s values are not edited as text", keyType)
+	}
+	if errors.Is(err, redis.Nil) {
+		return "", fmt.Errorf("%s no longer exists", elementName(keyType, field))
+	}
+	return value, err
+}
+
+// WriteValue replaces what ValueField read. Strings keep their TTL.
+func (c *RedisClient) WriteValue(key, keyType, field, value string) error {
+	if err := c.checkKey(key); err != nil {
+		return err
+	}
+	var err error
+	switch keyType {
+	case "string":
+		err = c.client.SetArgs(c.ctx, key, value, redis.SetArgs{KeepTTL: true}).Err()
+	case "hash":
+		err = c.client.HSet(c.ctx, key, field, value).Err()
+	case "list":
+		var index int64
+		if index, err = strconv.ParseInt(field, 10, 64); err != nil {
+			return fmt.Errorf("invalid list index %q", field)
+		}
+		err = c.client.LSet(c.ctx, key, index, value).Err()
+	default:
+		return fmt.Errorf("%s values are not edited as text", keyType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %v", elementName(keyType, field), err)
+	}
+	return nil
+}
+
+// HashFieldExists reports whether field is set in the hash at key.
+func (c *RedisClient) HashFieldExists(key, field string) (bool, error) {
+	if err := c.checkKey(key); err != nil {
+		return false, err
+	}
+	return c.client.HExists(c.ctx, key, field).Result()
+}
+
+// RemoveElements deletes hash fields, set members or zset members and
+// returns how many existed.
+func (c *RedisClient) RemoveElements(key, keyType string, elements []string) (int64, error) {
+	if err := c.checkKey(key); err != nil {
+		return 0, err
+	}
+	args := make([]interface{}, len(elements))
+	for i, e := range elements {
+		args[i] = e
+	}
+	var cmd *redis.IntCmd
+	switch keyType {
+	case "hash":
+		cmd = c.client.HDel(c.ctx, key, elements...)
+	case "set":
+		cmd = c.client.SRem(c.ctx, key, args...)
+	case "zset":
+		cmd = c.client.ZRem(c.ctx, key, args...)
+	default:
+		return 0, fmt.Errorf("cannot remove elements from a %s", keyType)
+	}
+	n, err := cmd.Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove from %s: %v", key, err)
+	}
+	return n, nil
+}
+
+// PushList adds value at the head or tail of a list and returns its length.
+func (c *RedisClient) PushList(key, value string, head bool) (int64, error) {
+	if err := c.checkKey(key); err != nil {
+		return 0, err
+	}
+	cmd := c.client.RPush(c.ctx, key, value)
+	if head {
+		cmd = c.client.LPush(c.ctx, key, value)
+	}
+	n, err := cmd.Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to push to %s: %v", key, err)
+	}
+	return n, nil
+}
+
+// PopList removes and returns the head or tail element of a list.
+func (c *RedisClient) PopList(key string, head bool) (string, error) {
+	if err := c.checkKey(key); err != nil {
+		return "", err
+	}
+	cmd := c.client.RPop(c.ctx, key)
+	if head {
+		cmd = c.client.LPop(c.ctx, key)
+	}
+	value, err := cmd.Result()
+	if errors.Is(err, redis.Nil) {
+		return "", fmt.Errorf("%s is empty", key)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to pop from %s: %v", key, err)
+	}
+	return value, nil
+}
+
+// AddSetMember adds member to the set at key; false means it was already there.
+func (c *RedisClient) AddSetMember(key, member string) (bool, error) {
+	if err := c.checkKey(key); err != nil {
+		return false, err
+	}
+	n, err := c.client.SAdd(c.ctx, key, member).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to add to %s: %v", key, err)
+	}
+	return n == 1, nil
+}
+
+// SetScore sets member's score in the zset at key. With existing it only
+// updates members already present (ZADD XX).
+func (c *RedisClient) SetScore(key, member string, score float64, existing bool) error {
+	if err := c.checkKey(key); err != nil {
+		return err
+	}
+	z := redis.Z{Score: score, Member: member}
+	var err error
+	if existing {
+		var n int64
+		n, err = c.client.ZAddArgs(c.ctx, key, redis.ZAddArgs{XX: true, Ch: true, Members: []redis.Z{z}}).Result()
+		if err == nil && n == 0 {
+			if _, missing := c.client.ZScore(c.ctx, key, member).Result(); errors.Is(missing, redis.Nil) {
+				return fmt.Errorf("%s is not in %s", member, key)
+			}
+		}
+	} else {
+		err = c.client.ZAdd(c.ctx, key, z).Err()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set score in %s: %v", key, err)
+	}
+	return nil
+}
+
+// SetTTL expires key after ttl; zero or less removes the expiry.
+func (c *RedisClient) SetTTL(key string, ttl time.Duration) error {
+	if err := c.checkKey(key); err != nil {
+		return err
+	}
+	var (
+		ok  bool
+		err error
+	)
+	if ttl <= 0 {
+		_, err = c.client.Persist(c.ctx, key).Result()
+		ok = true
+	} else {
+		ok, err = c.client.Expire(c.ctx, key, ttl).Result()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set TTL on %s: %v", key, err)
+	}
+	if !ok {
+		return fmt.Errorf("%s no longer exists", key)
+	}
+	return nil
+}
+
+// elementName describes what ValueField/WriteValue address, for messages.
+func elementName(keyType, field string) string {
+	switch keyType {
+	case "hash":
+		return fmt.Sprintf("field %q", field)
+	case "list":
+		return "index " + field
+	}
+	return "value"
+}
+
+// valuePreview flattens a value onto one table line.
+func valuePreview(value string, max int) string {
+	value = strings.NewReplacer("\r", "", "\n", "⏎", "\t", " ").Replace(value)
+	if r := []rune(value); len(r) > max {
+		return string(r[:max]) + "…"
+	}
+	return value
+}
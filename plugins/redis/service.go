@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -17,21 +18,47 @@ import (
 type Service struct {
 	mu          sync.Mutex
 	client      *RedisClient
-	host        string
-	port        string
-	username    string
-	password    string
-	database    int
+	conn        RedisConnection
 	name        string
+	connAttrs   map[string]string // target settings a saved ACL credential inherits
 	currentView string
+	protected   string
+	console     []consoleEntry
+
+	// Keys view search: a resumable scan and the rows it found so far.
+	keyPattern string
+	keyType    string
+	scan       *KeyScan
+	keyRows    [][]string
+	keyTotal   int64
+	tree       *keyTree
+
+	// Value view: the key opened with open_key and its type.
+	valueKey  string
+	valueType string
+
+	// Streams: the open stream, its XRANGE paging and the open group.
+	streamKey   string
+	streamGroup string
+	streamNext  string
+	streamRead  int
+	streamDone  bool
+
+	// memJob is the latest memory analysis, running or finished.
+	memJob *memJob
+
+	// watch is the keyspace notification watch, running or stopped.
+	watch *keyWatch
+
+	// scanMu guards scanCancel, which cancel_scan calls without s.mu.
+	scanMu     sync.Mutex
+	scanCancel context.CancelFunc
 }
 
 // NewService creates a redis RPC service.
 func NewService() *Service {
 	return &Service{
 		client:      NewRedisClient(),
-		port:        "6379",
-		database:    0,
 		currentView: viewKeys,
 	}
 }
@@ -58,24 +85,28 @@ func (s *Service) Configure(req pluginrpc.ConfigureRequest) error {
 	if req.Settings == nil {
 		return fmt.Errorf("missing settings")
 	}
-	s.host = req.Settings["host"]
-	s.port = req.Settings["port"]
-	if s.port == "" {
-		s.port = "6379"
+	conn, err := connectionFromSettings(req.Settings)
+	if err != nil {
+		return err
 	}
-	s.username = req.Settings["username"]
-	s.password = req.Settings["password"]
+	s.conn = conn
 	s.name = req.Settings["name"]
-	if db := req.Settings["database"]; db != "" {
-		if n, err := strconv.Atoi(db); err == nil {
-			s.database = n
-		}
-	}
-	if s.host == "" {
-		return fmt.Errorf("host is required")
+	s.connAttrs = map[string]string{}
+	for _, k := range credentialAttrs {
+		s.connAttrs[k] = req.Settings[k]
 	}
+	s.protected = parseProtected(req.Settings["protected"])
+	s.console = nil
+	s.keyPattern, s.keyType = "*", ""
+	s.scan, s.keyRows, s.tree = nil, nil, nil
+	s.valueKey, s.valueType = "", ""
+	s.streamKey, s.streamGroup = "", ""
+	s.stopMemJobLocked()
+	s.memJob = nil
+	_ = s.stopWatchLocked()
+	s.watch = nil
 
-	pluginrpc.RPCLog("Service.Configure host=%s port=%s user=%s db=%d", s.host, s.port, s.username, s.database)
+	pluginrpc.RPCLog("Service.Configure mode=%s addr=%s user=%s db=%d tls=%v", s.conn.Mode, s.conn.Label(), s.conn.Username, s.conn.Database, s.conn.TLS != nil)
 
 	if s.client != nil && s.client.IsConnected() {
 		_ = s.client.Disconnect()
@@ -96,7 +127,7 @@ func (s *Service) GetView(req pluginrpc.ViewRequest) (pluginrpc.ViewData, error)
 	if viewID == "" {
 		viewID = viewKeys
 	}
-	pluginrpc.RPCLog("Service.GetView begin view=%s host=%s connected=%v", viewID, s.host, s.client != nil && s.client.IsConnected())
+	pluginrpc.RPCLog("Service.GetView begin view=%s addr=%s connected=%v", viewID, s.conn.Label(), s.client != nil && s.client.IsConnected())
 	start := time.Now()
 	view, err := s.buildViewLocked(viewID)
 	if err != nil {
@@ -108,6 +139,9 @@ func (s *Service) GetView(req pluginrpc.ViewRequest) (pluginrpc.ViewData, error)
 }
 
 func (s *Service) DoAction(req pluginrpc.ActionRequest) (pluginrpc.ActionResult, error) {
+	if req.Action == "cancel_scan" {
+		return s.cancelScanAction(), nil
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -241,6 +275,71 @@ func (s *Service) DoAction(req pluginrpc.ActionRequest) (pluginrpc.ActionResult,
 		view, _ := s.buildViewLocked(viewPubSub)
 		return pluginrpc.ActionResult{OK: true, Message: fmt.Sprintf("published to %s", channel), Next: &view}, nil
 
+	case "load_more_keys":
+		return s.loadMoreKeysLocked(req.Payload), nil
+
+	case "search_keys":
+		if err := s.ensureConnectedLocked(); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}, nil
+		}
+		viewID := viewKeys
+		if s.currentView == viewKeyTree {
+			viewID = viewKeyTree
+		}
+		return s.searchKeysLocked(viewID, req.Payload["pattern"], req.Payload["type"]), nil
+
+	case "open_prefix":
+		return s.openPrefixLocked(req.Payload["key"]), nil
+
+	case "open_key":
+		return s.openKeyLocked(req.Payload["key"]), nil
+
+	case "value_edit", "value_save", "value_add_field", "value_remove", "value_push", "value_pop",
+		"value_add_member", "value_add_scored", "value_score", "value_ttl":
+		return s.valueActionLocked(action, req.Payload), nil
+
+	case "open_stream", "open_stream_groups", "open_group", "load_more_entries",
+		"stream_add", "stream_trim", "stream_ack", "stream_claim", "stream_autoclaim":
+		return s.streamActionLocked(action, req.Payload), nil
+
+	case "memory_analyze", "memory_cancel", "memory_export":
+		return s.memoryActionLocked(action, req.Payload), nil
+
+	case "dump_keys":
+		if err := s.ensureConnectedLocked(); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}, nil
+		}
+		return s.dumpKeysLocked(req.Payload), nil
+
+	case "restore_keys":
+		if err := s.ensureConnectedLocked(); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}, nil
+		}
+		return s.restoreKeysLocked(req.Payload), nil
+
+	case "copy_keys":
+		if err := s.ensureConnectedLocked(); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}, nil
+		}
+		return s.copyKeysLocked(req.Payload), nil
+
+	case "acl_user", "acl_create", "acl_edit", "acl_save", "acl_toggle", "acl_passwd", "acl_delete", "acl_log_reset":
+		return s.aclActionLocked(action, req.Payload), nil
+
+	case "watch_start", "watch_stop", "watch_pause", "watch_filter", "watch_clear":
+		return s.watchActionLocked(action, req.Payload), nil
+
+	case "fn_library", "fn_load", "fn_delete", "fn_flush", "fn_dump", "fn_restore", "fn_eval":
+		return s.functionsActionLocked(action, req.Payload), nil
+
+	case "run_command":
+		return s.runCommandLocked(req.Payload), nil
+
+	case "console_clear":
+		s.console = nil
+		view, _ := s.buildViewLocked(viewConsole)
+		return pluginrpc.ActionResult{OK: true, Message: "console cleared", Next: &view}, nil
+
 	case "subscribe":
 		channel := req.Payload["channel"]
 		if channel == "" {
@@ -270,6 +369,8 @@ func (s *Service) DoAction(req pluginrpc.ActionRequest) (pluginrpc.ActionResult,
 func (s *Service) Stop() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.stopMemJobLocked()
+	_ = s.stopWatchLocked()
 	if s.client != nil && s.client.IsConnected() {
 		return s.client.Disconnect()
 	}
@@ -280,58 +381,26 @@ func (s *Service) ensureConnectedLocked() error {
 	if s.client != nil && s.client.IsConnected() {
 		return nil
 	}
-	if s.host == "" {
+	if len(s.conn.Nodes) == 0 {
 		return fmt.Errorf("not configured (host did not call Configure)")
 	}
-	pluginrpc.RPCLog("ensureConnected: dialing %s:%s user=%s …", s.host, s.port, s.username)
+	pluginrpc.RPCLog("ensureConnected: dialing %s %s user=%s …", s.conn.Mode, s.conn.Label(), s.conn.Username)
 	start := time.Now()
-	err := s.client.Connect(s.host, s.port, s.username, s.password, s.database)
+	err := s.client.Connect(s.conn)
 	pluginrpc.RPCLog("ensureConnected: dial done err=%v dur=%s", err, time.Since(start))
 	return err
 }
 
 func (s *Service) reconnectDBLocked(db int) error {
-	s.database = db
+	if s.conn.Mode == ModeCluster && db != 0 {
+		return fmt.Errorf("redis cluster only has database 0")
+	}
+	s.stopMemJobLocked()
+	_ = s.stopWatchLocked()
+	s.watch = nil
+	s.conn.Database = db
 	if s.client != nil && s.client.IsConnected() {
 		_ = s.client.Disconnect()
 	}
 	return s.ensureConnectedLocked()
 }
-
-func (s *Service) loadKeysLocked(limit int) ([][]string, error) {
-	var (
-		cursor uint64
-		done   bool
-		keys   []string
-	)
-	for len(keys) < limit && !done {
-		batch, next, err := s.client.ScanKeys("*", cursor, int64(limit))
-		if err != nil {
-			return nil, err
-		}
-		cursor = next
-		if next == 0 {
-			done = true
-		}
-		keys = append(keys, batch...)
-	}
-	if len(keys) > limit {
-		keys = keys[:limit]
-	}
-
-	rows := make([][]string, 0, len(keys))
-	for _, key := range keys {
-		info, err := s.client.GetKeyInfo(key)
-		if err != nil {
-			rows = append(rows, []string{key, "?", "?", "?"})
-			continue
-		}
-		rows = append(rows, []string{
-			key,
-			info["type"],
-			info["ttl"],
-			info["size"],
-		})
-	}
-	return rows, nil
-}
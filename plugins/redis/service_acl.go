@@ -0,0 +1,330 @@
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"omo/pkg/pluginrpc"
+)
+
+// credentialAttrs are the target settings a saved ACL user credential
+// inherits, so the new KeePass entry connects the same way.
+var credentialAttrs = []string{
+	"port", "database", "mode", "nodes", "master_name",
+	"tls", "tls_ca", "tls_server_name", "tls_skip_verify", "protected",
+}
+
+func aclActions() []pluginrpc.KeyBinding {
+	return []pluginrpc.KeyBinding{
+		{Key: "N", Label: "New User", Action: "acl_create"},
+		{Key: "E", Label: "Edit Rules", Action: "acl_edit"},
+		{Key: "S", Label: "Enable/Disable", Action: "acl_toggle"},
+		{Key: "U", Label: "New Password", Action: "acl_passwd"},
+		{Key: "D", Label: "Delete User", Action: "acl_delete"},
+		{Key: "L", Label: "ACL Log", Action: "goto_acl_log"},
+	}
+}
+
+func aclLogActions() []pluginrpc.KeyBinding {
+	return []pluginrpc.KeyBinding{
+		{Key: "D", Label: "Reset Log", Action: "acl_log_reset"},
+		{Key: "H", Label: "Users", Action: "goto_acl"},
+	}
+}
+
+func (s *Service) viewACLLocked() (pluginrpc.ViewData, error) {
+	users, err := s.client.ACLUsers()
+	if err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	rows := make([][]string, 0, len(users))
+	for _, u := range users {
+		rows = append(rows, aclUserRow(u))
+	}
+	rows = pluginrpc.EnsureRows(rows, []string{"-", "-", "-", "-", "-", "No users"})
+	self := s.conn.Username
+	if self == "" {
+		self = "default"
+	}
+	extra := fmt.Sprintf("Users: %d\nLogged in as: %s", len(users), self)
+	view := ui.Connected(viewACL, "Redis ACL Users", s.baseInfo(extra),
+		[]string{"User", "Status", "Password", "Keys", "Channels", "Commands"}, rows, "User", aclActions()...)
+	return pluginrpc.WithDetail(view, "acl_user"), nil
+}
+
+func aclUserRow(u ACLUser) []string {
+	status := pluginrpc.Tint(pluginrpc.ColorOK, "on")
+	if !u.Enabled {
+		status = pluginrpc.Tint(pluginrpc.ColorError, "off")
+	}
+	pass := "none"
+	switch {
+	case u.NoPass:
+		pass = pluginrpc.Tint(pluginrpc.ColorError, "nopass")
+	case u.Passwords == 1:
+		pass = "1 password"
+	case u.Passwords > 1:
+		pass = fmt.Sprintf("%d passwords", u.Passwords)
+	}
+	commands := strings.Join(u.Commands, " ")
+	if len(u.Selectors) > 0 {
+		commands += fmt.Sprintf(" (+%d selectors)", len(u.Selectors))
+	}
+	return []string{u.Name, status, pass, dashValue(strings.Join(u.Keys, " ")), dashValue(strings.Join(u.Channels, " ")), dashValue(strings.TrimSpace(commands))}
+}
+
+func (s *Service) viewACLLogLocked() (pluginrpc.ViewData, error) {
+	entries, err := s.client.ACLLog()
+	if err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		client := "-"
+		if e.ClientInfo != nil {
+			client = e.ClientInfo.Addr
+		}
+		age := time.Duration(e.AgeSeconds * float64(time.Second)).Round(time.Second)
+		rows = append(rows, []string{
+			age.String() + " ago", e.Username, e.Reason, e.Context, e.Object, strconv.FormatInt(e.Count, 10), client,
+		})
+	}
+	rows = pluginrpc.EnsureRows(rows, []string{"-", "-", "-", "-", "-", "-", "No ACL denials logged"})
+	return ui.Connected(viewACLLog, "Redis ACL Log", s.baseInfo(fmt.Sprintf("Denials: %d", len(entries))),
+		[]string{"When", "User", "Reason", "Context", "Object", "Count", "Client"}, rows, "When", aclLogActions()...), nil
+}
+
+// aclUserDetail shows a user's rules grouped, plus the raw ACL GETUSER reply.
+func (s *Service) aclUserDetailLocked(name string) pluginrpc.ActionResult {
+	u, err := s.aclUserLocked(name)
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	raw, err := s.client.ACLGetUser(name)
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	row := aclUserRow(u)
+	list := func(v []string) string { return dashValue(strings.Join(v, "\n")) }
+	detail := pluginrpc.Detail{
+		Title: "ACL user " + name,
+		Sections: []pluginrpc.DetailSection{
+			{Title: "User", Fields: pluginrpc.Fields("Name", u.Name, "Status", row[1], "Password", row[2])},
+			{Title: "Key patterns", Text: list(u.Keys)},
+			{Title: "Channels", Text: list(u.Channels)},
+			{Title: "Commands", Text: list(u.Commands)},
+			{Title: "Selectors", Text: list(u.Selectors)},
+			{Title: "ACL GETUSER", Code: &pluginrpc.DetailCode{Body: formatReply(raw)}},
+		},
+	}
+	return pluginrpc.ActionResult{OK: true, ModalTitle: "ACL user: " + name, Detail: &detail}
+}
+
+// aclUserLocked finds one user in ACL LIST.
+func (s *Service) aclUserLocked(name string) (ACLUser, error) {
+	if name == "" || name == "-" {
+		return ACLUser{}, fmt.Errorf("no user selected")
+	}
+	users, err := s.client.ACLUsers()
+	if err != nil {
+		return ACLUser{}, err
+	}
+	for _, u := range users {
+		if u.Name == name {
+			return u, nil
+		}
+	}
+	return ACLUser{}, fmt.Errorf("no user %s", name)
+}
+
+// aclCredential is a new password ready to be saved as a KeePass entry
+// that connects like the current target.
+func (s *Service) aclCredential(user, password string) *pluginrpc.IssuedCredential {
+	attrs := map[string]string{}
+	for _, k := range credentialAttrs {
+		attrs[k] = s.connAttrs[k]
+	}
+	return &pluginrpc.IssuedCredential{
+		Name:       user,
+		UserName:   user,
+		Password:   password,
+		Attributes: attrs,
+	}
+}
+
+// aclActionLocked runs the acl_* actions. Writes are refused on a blocked
+// target and always confirmed by the plugin, since it knows who is affected.
+func (s *Service) aclActionLocked(action string, payload map[string]string) pluginrpc.ActionResult {
+	if err := s.ensureConnectedLocked(); err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	name := strings.TrimSpace(payload["key"])
+	if action == "acl_user" {
+		return s.aclUserDetailLocked(name)
+	}
+	if s.protected == protectBlock {
+		return pluginrpc.ActionResult{OK: false, Message: "blocked: ACL changes are disabled on this protected target"}
+	}
+	confirm := func(title, body string) pluginrpc.ActionResult {
+		if s.protected != protectOff {
+			body += "\n\nThis target is protected."
+		}
+		next := map[string]string{"key": name, "confirmed": "true"}
+		return pluginrpc.ActionResult{OK: true, Confirm: &pluginrpc.Confirm{Title: title, Body: body, Action: action, Payload: next}}
+	}
+	done := func(msg string) pluginrpc.ActionResult {
+		view, err := s.buildViewLocked(viewACL)
+		if err != nil {
+			return pluginrpc.ActionResult{OK: true, Message: msg}
+		}
+		return pluginrpc.ActionResult{OK: true, Message: msg, Next: &view}
+	}
+	selfNote := ""
+	if name == s.conn.Username || (name == "default" && s.conn.Username == "") {
+		selfNote = "\n\nThis is the user omo is logged in as."
+	}
+
+	switch action {
+	case "acl_create":
+		return s.aclCreateLocked(payload)
+
+	case "acl_edit":
+		u, err := s.aclUserLocked(name)
+		if err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		return pluginrpc.ActionResult{OK: true, Edit: &pluginrpc.Edit{
+			Title:   "ACL rules of " + name,
+			Content: strings.Join(aclRuleTokens(u.Rules), "\n") + "\n",
+			Suffix:  ".acl",
+			Action:  "acl_save",
+			Payload: map[string]string{"key": name},
+		}}
+
+	case "acl_save":
+		rules := aclRuleTokens(strings.Join(strings.Fields(payload["value"]), " "))
+		if len(rules) == 0 {
+			return pluginrpc.ActionResult{OK: false, Message: "no rules given; delete the user instead"}
+		}
+		// reset first so removed rules go away; #hash rules keep passwords.
+		if err := s.client.ACLSetUser(name, append([]string{"reset"}, rules...)); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		return done("updated ACL rules of " + name)
+
+	case "acl_toggle":
+		u, err := s.aclUserLocked(name)
+		if err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		rule, verb := "on", "enabled"
+		if u.Enabled {
+			rule, verb = "off", "disabled"
+		}
+		if payload["confirmed"] != "true" {
+			if u.Enabled {
+				return confirm("Confirm Disable", fmt.Sprintf("Disable user %s? New connections can no longer authenticate as it.%s", name, selfNote))
+			}
+			return confirm("Confirm Enable", fmt.Sprintf("Enable user %s?", name))
+		}
+		if err := s.client.ACLSetUser(name, []string{rule}); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		return done(verb + " user " + name)
+
+	case "acl_passwd":
+		if _, err := s.aclUserLocked(name); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		if payload["confirmed"] != "true" {
+			return confirm("Confirm New Password",
+				fmt.Sprintf("Replace every password of %s with a new generated one? Clients using the old password will fail to authenticate.%s", name, selfNote))
+		}
+		pass, err := s.client.ACLGenPass()
+		if err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		if err := s.client.ACLSetUser(name, []string{"resetpass", ">" + pass}); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		// No Next: repainting would take focus from the password modal.
+		res := pluginrpc.ActionResult{OK: true, Message: "new password set for " + name}
+		res.ModalTitle = "Password for " + name
+		res.ModalBody = fmt.Sprintf("User: %s\nPassword: %s\n\nIt is not shown again; save it to KeePass next.", name, pass)
+		res.Credential = s.aclCredential(name, pass)
+		return res
+
+	case "acl_delete":
+		if name == "default" {
+			return pluginrpc.ActionResult{OK: false, Message: "the default user cannot be deleted; disable it instead"}
+		}
+		if _, err := s.aclUserLocked(name); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		if payload["confirmed"] != "true" {
+			return confirm("Confirm Delete", fmt.Sprintf("Delete user %s? Its connections are closed.%s", name, selfNote))
+		}
+		if err := s.client.ACLDelUser(name); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		return done("deleted user " + name)
+
+	case "acl_log_reset":
+		if payload["confirmed"] != "true" {
+			return confirm("Confirm Reset", "Clear the ACL log?")
+		}
+		if err := s.client.ACLLogReset(); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		view, err := s.buildViewLocked(viewACLLog)
+		if err != nil {
+			return pluginrpc.ActionResult{OK: true, Message: "ACL log cleared"}
+		}
+		return pluginrpc.ActionResult{OK: true, Message: "ACL log cleared", Next: &view}
+	}
+	return pluginrpc.ActionResult{OK: false, Message: "unknown action: " + action}
+}
+
+// aclCreateLocked creates a user with the given rules and, unless the rules
+// set their own password or nopass, a generated password to save.
+func (s *Service) aclCreateLocked(payload map[string]string) pluginrpc.ActionResult {
+	name := strings.TrimSpace(payload["name"])
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return pluginrpc.ActionResult{OK: false, Message: "user name required, without spaces"}
+	}
+	if _, err := s.aclUserLocked(name); err == nil {
+		return pluginrpc.ActionResult{OK: false, Message: "user " + name + " already exists; use E to edit it"}
+	}
+	rules := aclRuleTokens(payload["rules"])
+	ownPass := false
+	for _, r := range rules {
+		if r == "nopass" || strings.HasPrefix(r, ">") || strings.HasPrefix(r, "#") {
+			ownPass = true
+		}
+	}
+	pass := ""
+	if !ownPass {
+		var err error
+		if pass, err = s.client.ACLGenPass(); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		rules = append(rules, ">"+pass)
+	}
+	if err := s.client.ACLSetUser(name, rules); err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	res := pluginrpc.ActionResult{OK: true, Message: "created user " + name}
+	if pass == "" {
+		if view, err := s.buildViewLocked(viewACL); err == nil {
+			res.Next = &view
+		}
+	} else {
+		// No Next: repainting would take focus from the password modal.
+		res.ModalTitle = "Password for " + name
+		res.ModalBody = fmt.Sprintf("User: %s\nPassword: %s\n\nIt is not shown again; save it to KeePass next.", name, pass)
+		res.Credential = s.aclCredential(name, pass)
+	}
+	return res
+}
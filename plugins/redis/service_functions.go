@@ -0,0 +1,270 @@
+package redis
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/pluginrpc"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func functionsActions() []pluginrpc.KeyBinding {
+	return []pluginrpc.KeyBinding{
+		{Key: "N", Label: "Load Library", Action: "fn_load"},
+		{Key: "D", Label: "Delete Library", Action: "fn_delete"},
+		{Key: "F", Label: "Flush All", Action: "fn_flush"},
+		{Key: "U", Label: "Dump", Action: "fn_dump"},
+		{Key: "I", Label: "Restore", Action: "fn_restore"},
+		{Key: "L", Label: "Run Script", Action: "fn_eval"},
+	}
+}
+
+func (s *Service) viewFunctionsLocked() (pluginrpc.ViewData, error) {
+	libs, err := s.client.Functions()
+	if err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	var rows [][]string
+	count := 0
+	for _, lib := range libs {
+		if len(lib.Functions) == 0 {
+			rows = append(rows, []string{lib.Name, lib.Engine, "-", "-", "-"})
+		}
+		for _, fn := range lib.Functions {
+			rows = append(rows, []string{lib.Name, lib.Engine, fn.Name, dashValue(strings.Join(fn.Flags, ",")), dashValue(fn.Description)})
+			count++
+		}
+	}
+	rows = pluginrpc.EnsureRows(rows, []string{"-", "-", "-", "-", "No libraries loaded"})
+	extra := fmt.Sprintf("Libraries: %d\nFunctions: %d", len(libs), count)
+	view := ui.Connected(viewFunctions, "Redis Functions", s.baseInfo(extra),
+		[]string{"Library", "Engine", "Function", "Flags", "Description"}, rows, "Library", functionsActions()...)
+	return pluginrpc.WithDetail(view, "fn_library"), nil
+}
+
+// libraryLocked finds one loaded library.
+func (s *Service) libraryLocked(name string) (redis.Library, error) {
+	if name == "" || name == "-" {
+		return redis.Library{}, fmt.Errorf("no library selected")
+	}
+	libs, err := s.client.Functions()
+	if err != nil {
+		return redis.Library{}, err
+	}
+	for _, lib := range libs {
+		if lib.Name == name {
+			return lib, nil
+		}
+	}
+	return redis.Library{}, fmt.Errorf("no library %s", name)
+}
+
+func (s *Service) libraryDetailLocked(name string) pluginrpc.ActionResult {
+	lib, err := s.libraryLocked(name)
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	var fns strings.Builder
+	for _, fn := range lib.Functions {
+		fmt.Fprintf(&fns, "%s", fn.Name)
+		if len(fn.Flags) > 0 {
+			fmt.Fprintf(&fns, " [%s]", strings.Join(fn.Flags, ","))
+		}
+		if fn.Description != "" {
+			fmt.Fprintf(&fns, " — %s", fn.Description)
+		}
+		fns.WriteString("\n")
+	}
+	detail := pluginrpc.Detail{
+		Title: "Library " + lib.Name,
+		Sections: []pluginrpc.DetailSection{
+			{Title: "Library", Fields: pluginrpc.Fields("Name", lib.Name, "Engine", lib.Engine, "Functions", fmt.Sprint(len(lib.Functions)))},
+			{Title: "Functions", Text: dashValue(strings.TrimSpace(fns.String()))},
+			{Title: "Code", Code: &pluginrpc.DetailCode{Lang: "lua", Body: lib.Code}},
+		},
+	}
+	return pluginrpc.ActionResult{OK: true, ModalTitle: "Library: " + lib.Name, Detail: &detail}
+}
+
+// functionsActionLocked runs the fn_* actions. Library changes are refused
+// on a blocked target and always confirmed, like FUNCTION in the console.
+func (s *Service) functionsActionLocked(action string, payload map[string]string) pluginrpc.ActionResult {
+	if err := s.ensureConnectedLocked(); err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	name := strings.TrimSpace(payload["key"])
+	switch action {
+	case "fn_library":
+		return s.libraryDetailLocked(name)
+	case "fn_dump":
+		return s.dumpFunctionsLocked()
+	case "fn_eval":
+		return s.evalScriptLocked(payload)
+	}
+
+	if s.protected == protectBlock {
+		return pluginrpc.ActionResult{OK: false, Message: "blocked: function changes are disabled on this protected target"}
+	}
+	confirm := func(title, body string, next map[string]string) pluginrpc.ActionResult {
+		if s.protected != protectOff {
+			body += "\n\nThis target is protected."
+		}
+		next["confirmed"] = "true"
+		return pluginrpc.ActionResult{OK: true, Confirm: &pluginrpc.Confirm{Title: title, Body: body, Action: action, Payload: next}}
+	}
+	done := func(msg string) pluginrpc.ActionResult {
+		view, err := s.buildViewLocked(viewFunctions)
+		if err != nil {
+			return pluginrpc.ActionResult{OK: true, Message: msg}
+		}
+		return pluginrpc.ActionResult{OK: true, Message: msg, Next: &view}
+	}
+
+	switch action {
+	case "fn_load":
+		path, code, err := readLocalFile(payload["file"])
+		if err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		lib, err := libraryName(code)
+		if err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: filepath.Base(path) + ": " + err.Error()}
+		}
+		replace := strings.HasPrefix(strings.ToLower(strings.TrimSpace(payload["replace"])), "y")
+		if payload["confirmed"] != "true" {
+			what := "new library"
+			if old, err := s.libraryLocked(lib); err == nil {
+				if !replace {
+					return pluginrpc.ActionResult{OK: false, Message: "library " + lib + " is already loaded; load it again with replace"}
+				}
+				what = fmt.Sprintf("replaces the loaded library with %d functions", len(old.Functions))
+			}
+			return confirm("Confirm Load", fmt.Sprintf("Load library %s from %s into %s?\n\n%s",
+				lib, filepath.Base(path), s.conn.Label(), what),
+				map[string]string{"file": path, "replace": payload["replace"]})
+		}
+		if err := s.client.LoadFunction(code, replace); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		return done("loaded library " + lib)
+
+	case "fn_delete":
+		lib, err := s.libraryLocked(name)
+		if err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		if payload["confirmed"] != "true" {
+			return confirm("Confirm Delete", fmt.Sprintf("Delete library %s and its %d functions? Callers of FCALL will fail.", lib.Name, len(lib.Functions)),
+				map[string]string{"key": name})
+		}
+		if err := s.client.DeleteFunction(lib.Name); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		return done("deleted library " + lib.Name)
+
+	case "fn_flush":
+		if payload["confirmed"] != "true" {
+			libs, err := s.client.Functions()
+			if err != nil {
+				return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+			}
+			if len(libs) == 0 {
+				return pluginrpc.ActionResult{OK: false, Message: "no libraries loaded"}
+			}
+			return confirm("Confirm Flush", fmt.Sprintf("Delete all %d libraries on %s?", len(libs), s.conn.Label()), map[string]string{})
+		}
+		if err := s.client.FlushFunctions(); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		return done("deleted all libraries")
+
+	case "fn_restore":
+		policy, err := parseRestorePolicy(payload["policy"])
+		if err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		path, dump, err := readLocalFile(payload["file"])
+		if err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		if payload["confirmed"] != "true" {
+			body := fmt.Sprintf("Restore libraries from %s into %s with %s?", filepath.Base(path), s.conn.Label(), policy)
+			switch policy {
+			case fnRestoreAppend:
+				body += "\n\nIt fails if a library already exists."
+			case fnRestoreReplace:
+				body += "\n\nLibraries with the same name are replaced."
+			case fnRestoreFlush:
+				body += "\n\nAll loaded libraries are deleted first."
+			}
+			return confirm("Confirm Restore", body, map[string]string{"file": path, "policy": policy})
+		}
+		if err := s.client.RestoreFunctions(dump, policy); err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		return done("restored libraries from " + filepath.Base(path))
+	}
+	return pluginrpc.ActionResult{OK: false, Message: "unknown action: " + action}
+}
+
+// dumpFunctionsLocked writes FUNCTION DUMP to the exports directory.
+func (s *Service) dumpFunctionsLocked() pluginrpc.ActionResult {
+	dump, err := s.client.DumpFunctions()
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	dir := pluginapi.PluginExportsDir("redis")
+	path := filepath.Join(dir, fmt.Sprintf("functions-%s-%s.dump", sanitizeFileName(s.name), time.Now().Format("20060102-150405")))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	if err := os.WriteFile(path, []byte(dump), 0o600); err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	return pluginrpc.ActionResult{OK: true, Message: "dumped libraries to " + path}
+}
+
+// evalScriptLocked runs a local Lua file with EVAL and shows the reply. A
+// blocked target runs it with EVAL_RO; a confirm target asks first.
+func (s *Service) evalScriptLocked(payload map[string]string) pluginrpc.ActionResult {
+	path, script, err := readLocalFile(payload["file"])
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	keys, err := splitArgs(payload["keys"])
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: "keys: " + err.Error()}
+	}
+	args, err := splitArgs(payload["args"])
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: "args: " + err.Error()}
+	}
+	readOnly := s.protected == protectBlock
+	if s.protected == protectConfirm && payload["confirmed"] != "true" {
+		body := fmt.Sprintf("Run %s on %s?\n\nKEYS: %s\nARGV: %s\n\nThis target is protected.",
+			filepath.Base(path), s.conn.Label(), dashValue(strings.Join(keys, " ")), dashValue(strings.Join(args, " ")))
+		return pluginrpc.ActionResult{OK: true, Confirm: &pluginrpc.Confirm{
+			Title: "Confirm Script", Body: body, Action: "fn_eval",
+			Payload: map[string]string{"file": path, "keys": payload["keys"], "args": payload["args"], "confirmed": "true"},
+		}}
+	}
+
+	start := time.Now()
+	reply, err := s.client.EvalScript(script, keys, args, readOnly)
+	took := time.Since(start)
+	body := fmt.Sprintf("KEYS: %s\nARGV: %s\n", dashValue(strings.Join(keys, " ")), dashValue(strings.Join(args, " ")))
+	if readOnly {
+		body += "Mode: EVAL_RO (target is protected)\n"
+	}
+	if err != nil {
+		body += "\n(error) " + err.Error()
+	} else {
+		body += fmt.Sprintf("Took: %s\n\n%s", took.Round(time.Microsecond), formatReply(reply))
+	}
+	return pluginrpc.ActionResult{OK: err == nil, ModalTitle: "Script: " + filepath.Base(path), ModalBody: body}
+}
@@ -0,0 +1,348 @@
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"omo/pkg/pluginrpc"
+)
+
+func streamsActions() []pluginrpc.KeyBinding {
+	return []pluginrpc.KeyBinding{
+		{Key: "E", Label: "Entries", Action: "open_stream"},
+		{Key: "H", Label: "Groups", Action: "open_stream_groups"},
+		{Key: "N", Label: "Add Entry", Action: "stream_add"},
+		{Key: "M", Label: "Trim", Action: "stream_trim"},
+	}
+}
+
+func streamEntriesActions() []pluginrpc.KeyBinding {
+	return []pluginrpc.KeyBinding{
+		{Key: "H", Label: "Groups", Action: "open_stream_groups"},
+		{Key: "N", Label: "Add Entry", Action: "stream_add"},
+		{Key: "M", Label: "Trim", Action: "stream_trim"},
+	}
+}
+
+func streamGroupsActions() []pluginrpc.KeyBinding {
+	return []pluginrpc.KeyBinding{
+		{Key: "E", Label: "Pending", Action: "open_group"},
+	}
+}
+
+func streamPendingActions() []pluginrpc.KeyBinding {
+	return []pluginrpc.KeyBinding{
+		{Key: "Y", Label: "Ack", Action: "stream_ack", Batch: true},
+		{Key: "C", Label: "Claim", Action: "stream_claim"},
+		{Key: "L", Label: "Autoclaim", Action: "stream_autoclaim"},
+	}
+}
+
+func (s *Service) viewStreamsLocked() (pluginrpc.ViewData, error) {
+	ctx := s.beginScanPage()
+	streams, done, err := s.client.ListStreams(ctx)
+	s.endScanPage()
+	if err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	rows := make([][]string, 0, len(streams))
+	for _, st := range streams {
+		rows = append(rows, []string{st.Key, strconv.FormatInt(st.Length, 10), strconv.FormatInt(st.Groups, 10), st.FirstID, st.LastID})
+	}
+	rows = pluginrpc.EnsureRows(rows, []string{"-", "-", "-", "-", "No streams found"})
+	extra := fmt.Sprintf("Streams: %d", len(streams))
+	if !done {
+		extra += fmt.Sprintf(" (first %d found)", len(streams))
+	}
+	return ui.Connected(viewStreams, "Redis Streams", s.baseInfo(extra),
+		[]string{"Stream", "Length", "Groups", "First ID", "Last ID"}, rows, "Stream", streamsActions()...), nil
+}
+
+// streamInfoLocked is the info panel line for the open stream.
+func (s *Service) streamInfoLocked(extra string) string {
+	info := "Stream: " + s.streamKey
+	if s.streamGroup != "" && s.currentView == viewStreamPending {
+		info += "\nGroup: " + s.streamGroup
+	}
+	if extra != "" {
+		info += "\n" + extra
+	}
+	return s.baseInfo(info)
+}
+
+// streamPageLocked reads the next XRANGE page of the open stream.
+func (s *Service) streamPageLocked() ([][]string, error) {
+	msgs, err := s.client.StreamRange(s.streamKey, s.streamNext, streamPageSize)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([][]string, len(msgs))
+	for i, m := range msgs {
+		at := "-"
+		if t := streamIDTime(m.ID); !t.IsZero() {
+			at = t.Format("2006-01-02 15:04:05")
+		}
+		rows[i] = []string{m.ID, at, valuePreview(streamFields(m.Values), 200)}
+	}
+	s.streamDone = len(msgs) < streamPageSize
+	if len(msgs) > 0 {
+		if s.streamNext, err = nextStreamID(msgs[len(msgs)-1].ID); err != nil {
+			return nil, err
+		}
+	}
+	s.streamRead += len(msgs)
+	return rows, nil
+}
+
+func (s *Service) streamEntriesView(rows [][]string) pluginrpc.ViewData {
+	extra := fmt.Sprintf("Entries read: %d", s.streamRead)
+	if s.streamDone {
+		extra += " (all)"
+	}
+	view := ui.Connected(viewStreamEntries, "Stream "+s.streamKey, s.streamInfoLocked(extra),
+		[]string{"ID", "Time", "Fields"}, rows, "ID", streamEntriesActions()...)
+	view.LoadMoreAction = "load_more_entries"
+	view.More = !s.streamDone
+	return view
+}
+
+func (s *Service) viewStreamEntriesLocked() (pluginrpc.ViewData, error) {
+	if s.streamKey == "" {
+		return s.buildViewLocked(viewStreams)
+	}
+	s.streamNext, s.streamRead = "-", 0
+	rows, err := s.streamPageLocked()
+	if err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	view := s.streamEntriesView(pluginrpc.EnsureRows(rows, []string{"-", "-", "Stream is empty"}))
+	return view, nil
+}
+
+// loadMoreEntriesLocked serves PgDn on the entries view; offset 0 restarts.
+func (s *Service) loadMoreEntriesLocked(payload map[string]string) pluginrpc.ActionResult {
+	if s.streamKey == "" {
+		return pluginrpc.ActionResult{OK: false, Message: "open a stream first"}
+	}
+	if err := s.ensureConnectedLocked(); err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	if payload["offset"] == "0" {
+		s.streamNext, s.streamRead = "-", 0
+	}
+	rows, err := s.streamPageLocked()
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	view := s.streamEntriesView(rows)
+	return pluginrpc.ActionResult{OK: true, Message: fmt.Sprintf("read %d entries", len(rows)), Next: &view}
+}
+
+func (s *Service) viewStreamGroupsLocked() (pluginrpc.ViewData, error) {
+	if s.streamKey == "" {
+		return s.buildViewLocked(viewStreams)
+	}
+	groups, err := s.client.StreamGroups(s.streamKey)
+	if err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	rows := make([][]string, 0, len(groups))
+	for _, g := range groups {
+		rows = append(rows, []string{
+			g.Name,
+			strconv.FormatInt(g.Consumers, 10),
+			strconv.FormatInt(g.Pending, 10),
+			strconv.FormatInt(g.Lag, 10),
+			g.LastDeliveredID,
+			strconv.FormatInt(g.EntriesRead, 10),
+		})
+	}
+	rows = pluginrpc.EnsureRows(rows, []string{"-", "-", "-", "-", "-", "No consumer groups"})
+	return ui.Connected(viewStreamGroups, "Groups of "+s.streamKey, s.streamInfoLocked(fmt.Sprintf("Groups: %d", len(groups))),
+		[]string{"Group", "Consumers", "Pending", "Lag", "Last Delivered", "Entries Read"}, rows, "Group", streamGroupsActions()...), nil
+}
+
+func (s *Service) viewStreamPendingLocked() (pluginrpc.ViewData, error) {
+	if s.streamKey == "" || s.streamGroup == "" {
+		return s.buildViewLocked(viewStreamGroups)
+	}
+	pending, err := s.client.StreamPending(s.streamKey, s.streamGroup)
+	if err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	rows := make([][]string, 0, len(pending))
+	for _, p := range pending {
+		rows = append(rows, []string{p.ID, p.Consumer, p.Idle.Round(time.Second).String(), strconv.FormatInt(p.RetryCount, 10)})
+	}
+	rows = pluginrpc.EnsureRows(rows, []string{"-", "-", "-", "Nothing pending"})
+	extra := fmt.Sprintf("Pending: %d", len(pending))
+	if len(pending) == streamPendingLimit {
+		extra += fmt.Sprintf(" (oldest %d)", streamPendingLimit)
+	}
+	return ui.Connected(viewStreamPending, "Pending in "+s.streamGroup, s.streamInfoLocked(extra),
+		[]string{"ID", "Consumer", "Idle", "Deliveries"}, rows, "ID", streamPendingActions()...), nil
+}
+
+// streamTargetLocked is the stream an action applies to: the selected row
+// on the streams view, the open stream elsewhere.
+func (s *Service) streamTargetLocked(payload map[string]string) string {
+	if s.currentView == viewStreams {
+		if key := payload["key"]; key != "-" {
+			return key
+		}
+		return ""
+	}
+	return s.streamKey
+}
+
+// streamResultLocked repaints the current stream view after a write.
+func (s *Service) streamResultLocked(msg string) pluginrpc.ActionResult {
+	return s.streamResultAtLocked(s.currentView, msg)
+}
+
+// pendingIDs is the marked pending entry IDs, or the selected one.
+func pendingIDs(payload map[string]string) []string {
+	var ids []string
+	for _, row := range pluginrpc.MarkedRows(payload) {
+		ids = append(ids, row["key"])
+	}
+	if len(ids) == 0 && payload["key"] != "" && payload["key"] != "-" {
+		ids = []string{payload["key"]}
+	}
+	return ids
+}
+
+// parseIdle reads a minimum idle time in milliseconds.
+func parseIdle(raw string) (time.Duration, error) {
+	ms, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+	if err != nil || ms < 0 {
+		return 0, fmt.Errorf("min idle must be milliseconds >= 0")
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// streamWrites are the stream actions that change a stream or its groups,
+// with the question a protected target asks first.
+var streamWrites = map[string]string{
+	"stream_add":       "Add an entry (XADD)",
+	"stream_trim":      "Trim the stream (XTRIM)",
+	"stream_ack":       "Acknowledge pending entries (XACK)",
+	"stream_claim":     "Claim pending entries (XCLAIM)",
+	"stream_autoclaim": "Claim idle pending entries (XAUTOCLAIM)",
+}
+
+// streamActionLocked runs the stream actions.
+func (s *Service) streamActionLocked(action string, payload map[string]string) pluginrpc.ActionResult {
+	if err := s.ensureConnectedLocked(); err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	fail := func(err error) pluginrpc.ActionResult {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	if what, ok := streamWrites[action]; ok {
+		if res := s.guardWriteLocked(action, what, payload); res != nil {
+			return *res
+		}
+	}
+
+	switch action {
+	case "open_stream", "open_stream_groups":
+		key := s.streamTargetLocked(payload)
+		if key == "" {
+			return pluginrpc.ActionResult{OK: false, Message: "no stream selected"}
+		}
+		s.streamKey, s.streamGroup = key, ""
+		viewID := viewStreamEntries
+		if action == "open_stream_groups" {
+			viewID = viewStreamGroups
+		}
+		return s.streamResultAtLocked(viewID, "opened "+key)
+
+	case "open_group":
+		group := payload["key"]
+		if group == "" || group == "-" {
+			return pluginrpc.ActionResult{OK: false, Message: "no group selected"}
+		}
+		s.streamGroup = group
+		return s.streamResultAtLocked(viewStreamPending, "opened "+group)
+
+	case "load_more_entries":
+		return s.loadMoreEntriesLocked(payload)
+
+	case "stream_add":
+		key := s.streamTargetLocked(payload)
+		if key == "" {
+			return pluginrpc.ActionResult{OK: false, Message: "no stream selected"}
+		}
+		fields, err := parseStreamFields(payload["fields"])
+		if err != nil {
+			return fail(err)
+		}
+		id, err := s.client.StreamAdd(key, fields)
+		if err != nil {
+			return fail(err)
+		}
+		return s.streamResultLocked(fmt.Sprintf("added %s to %s", id, key))
+
+	case "stream_trim":
+		key := s.streamTargetLocked(payload)
+		if key == "" {
+			return pluginrpc.ActionResult{OK: false, Message: "no stream selected"}
+		}
+		maxLen, err := strconv.ParseInt(strings.TrimSpace(payload["maxlen"]), 10, 64)
+		if err != nil || maxLen < 0 {
+			return pluginrpc.ActionResult{OK: false, Message: "max length must be a number >= 0"}
+		}
+		n, err := s.client.StreamTrim(key, maxLen, payload["exact"] == "true")
+		if err != nil {
+			return fail(err)
+		}
+		return s.streamResultLocked(fmt.Sprintf("trimmed %d entries from %s", n, key))
+
+	case "stream_ack":
+		ids := pendingIDs(payload)
+		if len(ids) == 0 || s.streamGroup == "" {
+			return pluginrpc.ActionResult{OK: false, Message: "no pending entry selected"}
+		}
+		n, err := s.client.StreamAck(s.streamKey, s.streamGroup, ids)
+		if err != nil {
+			return fail(err)
+		}
+		return s.streamResultLocked(fmt.Sprintf("acked %d of %d", n, len(ids)))
+
+	case "stream_claim", "stream_autoclaim":
+		consumer := strings.TrimSpace(payload["consumer"])
+		if consumer == "" || s.streamGroup == "" {
+			return pluginrpc.ActionResult{OK: false, Message: "consumer required"}
+		}
+		idle, err := parseIdle(payload["idle"])
+		if err != nil {
+			return fail(err)
+		}
+		var claimed []string
+		if action == "stream_claim" {
+			ids := pendingIDs(payload)
+			if len(ids) == 0 {
+				return pluginrpc.ActionResult{OK: false, Message: "no pending entry selected"}
+			}
+			claimed, err = s.client.StreamClaim(s.streamKey, s.streamGroup, consumer, idle, ids)
+		} else {
+			claimed, err = s.client.StreamAutoClaim(s.streamKey, s.streamGroup, consumer, idle, streamPendingLimit)
+		}
+		if err != nil {
+			return fail(err)
+		}
+		return s.streamResultLocked(fmt.Sprintf("claimed %d for %s", len(claimed), consumer))
+	}
+	return pluginrpc.ActionResult{OK: false, Message: "unknown action: " + action}
+}
+
+func (s *Service) streamResultAtLocked(viewID, msg string) pluginrpc.ActionResult {
+	view, err := s.buildViewLocked(viewID)
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	return pluginrpc.ActionResult{OK: true, Message: msg, Next: &view}
+}
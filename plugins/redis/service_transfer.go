@@ -0,0 +1,290 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"omo/pkg/pluginapi"
+	"omo/pkg/pluginrpc"
+)
+
+// destPrefix marks the copy destination's target settings in a payload.
+const destPrefix = "dest."
+
+// transferKeysLocked is what a dump or copy works on: keys matching the
+// pattern when one is given, else the marked rows, else the selected key.
+func (s *Service) transferKeysLocked(payload map[string]string) ([]string, string, error) {
+	if pattern := strings.TrimSpace(payload["pattern"]); pattern != "" {
+		ctx := s.beginScanPage()
+		keys, done, err := s.client.ScanAll(ctx, pattern, transferLimit)
+		s.endScanPage()
+		if err != nil {
+			return nil, "", err
+		}
+		what := fmt.Sprintf("keys matching %s", pattern)
+		if !done {
+			what = fmt.Sprintf("the first %d keys matching %s", len(keys), pattern)
+		}
+		return keys, what, nil
+	}
+	var keys []string
+	if marked := pluginrpc.MarkedRows(payload); len(marked) > 0 {
+		for _, row := range marked {
+			keys = append(keys, row["key"])
+		}
+	} else {
+		keys = []string{payload["key"]}
+	}
+	out := keys[:0]
+	for _, key := range keys {
+		if key != "" && key != "-" {
+			out = append(out, key)
+		}
+	}
+	if len(out) == 0 {
+		return nil, "", errors.New("no key selected")
+	}
+	return out, "selected keys", nil
+}
+
+func transferPolicy(payload map[string]string) (string, error) {
+	switch p := strings.ToLower(strings.TrimSpace(payload["policy"])); p {
+	case "", policySkip:
+		return policySkip, nil
+	case policyReplace:
+		return policyReplace, nil
+	default:
+		return "", fmt.Errorf("unknown policy %q (want skip or replace)", p)
+	}
+}
+
+// transferStats counts what an import or copy did.
+type transferStats struct {
+	done, skipped, failed int
+	firstErr              error
+}
+
+func (t *transferStats) fail(err error) {
+	t.failed++
+	if t.firstErr == nil {
+		t.firstErr = err
+	}
+}
+
+func (t transferStats) result(verb string) pluginrpc.ActionResult {
+	msg := fmt.Sprintf("%s %d keys, skipped %d", verb, t.done, t.skipped)
+	if t.failed == 0 {
+		return pluginrpc.ActionResult{OK: true, Message: msg}
+	}
+	msg += fmt.Sprintf(", failed %d (%v)", t.failed, t.firstErr)
+	return pluginrpc.ActionResult{OK: t.done > 0, Message: msg}
+}
+
+// existingNote describes what happens to keys that already exist.
+func existingNote(n int, where, policy string) string {
+	if n == 0 {
+		return "None of them exist on " + where + "."
+	}
+	verb := "skipped"
+	if policy == policyReplace {
+		verb = "replaced"
+	}
+	return fmt.Sprintf("%d already exist on %s and will be %s.", n, where, verb)
+}
+
+func countExisting(existing map[string]bool) int {
+	n := 0
+	for _, ok := range existing {
+		if ok {
+			n++
+		}
+	}
+	return n
+}
+
+// dumpKeysLocked exports keys with DUMP, their TTL and readable values to
+// a JSON file in the plugin exports folder.
+func (s *Service) dumpKeysLocked(payload map[string]string) pluginrpc.ActionResult {
+	keys, what, err := s.transferKeysLocked(payload)
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	f := &dumpFile{Source: s.conn.Label(), DB: s.conn.Database, Created: time.Now()}
+	for _, key := range keys {
+		rec, err := s.client.DumpKey(key, true)
+		if err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+		}
+		if rec != nil {
+			f.Keys = append(f.Keys, *rec)
+		}
+	}
+	if len(f.Keys) == 0 {
+		return pluginrpc.ActionResult{OK: false, Message: "no keys to dump"}
+	}
+	dir := pluginapi.PluginExportsDir("redis")
+	path := filepath.Join(dir, fmt.Sprintf("keys-%s-%s.json", sanitizeFileName(s.name), f.Created.Format("20060102-150405")))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	if err := writeDumpFile(path, f); err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	return pluginrpc.ActionResult{OK: true, Message: fmt.Sprintf("dumped %d %s to %s", len(f.Keys), what, path)}
+}
+
+// restoreKeysLocked imports a dump file into this target after a
+// confirmation that counts the keys it would overwrite or skip.
+func (s *Service) restoreKeysLocked(payload map[string]string) pluginrpc.ActionResult {
+	if s.protected == protectBlock {
+		return pluginrpc.ActionResult{OK: false, Message: "blocked: this target is protected"}
+	}
+	policy, err := transferPolicy(payload)
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	path := strings.TrimSpace(payload["file"])
+	if path == "" {
+		return pluginrpc.ActionResult{OK: false, Message: "no file given"}
+	}
+	f, err := readDumpFile(path)
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	keys := make([]string, len(f.Keys))
+	for i, rec := range f.Keys {
+		keys[i] = rec.Key
+	}
+	existing, err := s.client.ExistingKeys(keys)
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+
+	if payload["confirmed"] != "true" {
+		body := fmt.Sprintf("Import %d keys from %s into %s db%d?\n\n%s",
+			len(keys), filepath.Base(path), s.conn.Label(), s.conn.Database,
+			existingNote(countExisting(existing), "this target", policy))
+		if s.protected != protectOff {
+			body += "\n\nThis target is protected."
+		}
+		return pluginrpc.ActionResult{
+			OK: true,
+			Confirm: &pluginrpc.Confirm{
+				Title:   "Confirm Import",
+				Body:    body,
+				Action:  "restore_keys",
+				Payload: map[string]string{"file": path, "policy": policy, "confirmed": "true"},
+			},
+		}
+	}
+
+	var stats transferStats
+	for _, rec := range f.Keys {
+		if existing[rec.Key] && policy == policySkip {
+			stats.skipped++
+			continue
+		}
+		if err := s.client.RestoreKey(rec, policy == policyReplace); err != nil {
+			stats.fail(err)
+			continue
+		}
+		stats.done++
+	}
+	return stats.result("imported")
+}
+
+// copyKeysLocked copies keys to another configured target, whose settings
+// come in the payload under destPrefix. The first run is a dry run that
+// counts the keys and asks before copying.
+func (s *Service) copyKeysLocked(payload map[string]string) pluginrpc.ActionResult {
+	policy, err := transferPolicy(payload)
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	settings := map[string]string{}
+	for k, v := range payload {
+		if name, ok := strings.CutPrefix(k, destPrefix); ok {
+			settings[name] = v
+		}
+	}
+	destConn, err := connectionFromSettings(settings)
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: "destination: " + err.Error()}
+	}
+	if destConn.Label() == s.conn.Label() && destConn.Database == s.conn.Database {
+		return pluginrpc.ActionResult{OK: false, Message: "source and destination are the same database"}
+	}
+	destProtected := parseProtected(settings["protected"])
+	if destProtected == protectBlock {
+		return pluginrpc.ActionResult{OK: false, Message: "blocked: the destination is protected"}
+	}
+	destName := settings["name"]
+	if destName == "" {
+		destName = destConn.Label()
+	}
+
+	keys, what, err := s.transferKeysLocked(payload)
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	dest := NewRedisClient()
+	if err := dest.Connect(destConn); err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: "destination: " + err.Error()}
+	}
+	defer dest.Disconnect()
+	existing, err := dest.ExistingKeys(keys)
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: "destination: " + err.Error()}
+	}
+
+	if payload["confirmed"] != "true" {
+		body := fmt.Sprintf("Dry run: %d %s.\nCopy them from %s db%d to %s db%d?\n\n%s",
+			len(keys), what, s.conn.Label(), s.conn.Database, destName, destConn.Database,
+			existingNote(countExisting(existing), destName, policy))
+		if destProtected != protectOff {
+			body += "\n\nThe destination is protected."
+		}
+		next := maps.Clone(payload)
+		next["confirmed"] = "true"
+		return pluginrpc.ActionResult{
+			OK:      true,
+			Confirm: &pluginrpc.Confirm{Title: "Confirm Copy", Body: body, Action: "copy_keys", Payload: next},
+		}
+	}
+
+	var stats transferStats
+	for _, key := range keys {
+		if existing[key] && policy == policySkip {
+			stats.skipped++
+			continue
+		}
+		rec, err := s.client.DumpKey(key, false)
+		if err == nil && rec == nil {
+			stats.skipped++ // deleted since the dry run
+			continue
+		}
+		if err == nil {
+			err = dest.RestoreKey(*rec, policy == policyReplace)
+			if err != nil && strings.Contains(err.Error(), "payload version") {
+				// The destination runs an older RDB version; copy the value.
+				if rec, err = s.client.DumpKey(key, true); err == nil && rec != nil {
+					rec.Dump = ""
+					err = dest.RestoreKey(*rec, policy == policyReplace)
+				}
+			}
+		}
+		if err != nil {
+			stats.fail(err)
+			continue
+		}
+		stats.done++
+	}
+	res := stats.result("copied")
+	res.Message += " to " + destName
+	return res
+}
@@ -0,0 +1,397 @@
+package redis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"omo/pkg/pluginrpc"
+)
+
+// valueActions are the value view bindings for one key type. Enter edits
+// the selected element where the type has text to edit.
+func valueActions(keyType string) []pluginrpc.KeyBinding {
+	ttl := pluginrpc.KeyBinding{Key: "L", Label: "TTL", Action: "value_ttl"}
+	switch keyType {
+	case "string":
+		return []pluginrpc.KeyBinding{
+			{Key: "E", Label: "Edit", Action: "value_edit"},
+			ttl,
+		}
+	case "hash":
+		return []pluginrpc.KeyBinding{
+			{Key: "E", Label: "Edit Field", Action: "value_edit"},
+			{Key: "N", Label: "Add Field", Action: "value_add_field"},
+			{Key: "D", Label: "Del Field", Action: "value_remove", Batch: true},
+			ttl,
+		}
+	case "list":
+		return []pluginrpc.KeyBinding{
+			{Key: "E", Label: "Set Item", Action: "value_edit"},
+			{Key: "N", Label: "Push", Action: "value_push"},
+			{Key: "U", Label: "Pop", Action: "value_pop"},
+			ttl,
+		}
+	case "set":
+		return []pluginrpc.KeyBinding{
+			{Key: "N", Label: "Add Member", Action: "value_add_member"},
+			{Key: "D", Label: "Del Member", Action: "value_remove", Batch: true},
+			ttl,
+		}
+	case "zset":
+		return []pluginrpc.KeyBinding{
+			{Key: "E", Label: "Set Score", Action: "value_score"},
+			{Key: "N", Label: "Add Member", Action: "value_add_scored"},
+			{Key: "D", Label: "Del Member", Action: "value_remove", Batch: true},
+			ttl,
+		}
+	}
+	return []pluginrpc.KeyBinding{ttl}
+}
+
+// valueHelp lists every value binding once, for "?".
+func valueHelp() []pluginrpc.KeyBinding {
+	seen := map[string]bool{}
+	var out []pluginrpc.KeyBinding
+	for _, t := range []string{"string", "hash", "list", "set", "zset"} {
+		for _, b := range valueActions(t) {
+			if id := b.Key + b.Label; !seen[id] {
+				seen[id] = true
+				out = append(out, b)
+			}
+		}
+	}
+	return out
+}
+
+func (s *Service) viewValueLocked() (pluginrpc.ViewData, error) {
+	if s.valueKey == "" {
+		return s.buildViewLocked(viewKeys)
+	}
+	v, err := s.client.ReadValue(s.valueKey)
+	if err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	s.valueType = v.Type
+	title := "Redis " + s.valueKey
+	if v.Type == "none" {
+		info := s.baseInfo("Key: " + s.valueKey + "\nStatus: key no longer exists")
+		return ui.Connected(viewValue, title, info, []string{"Value"}, [][]string{{"Key no longer exists"}}, "", valueActions(v.Type)...), nil
+	}
+
+	extra := fmt.Sprintf("Key: %s\nType: %s\nTTL: %s\nLength: %d", s.valueKey, v.Type, formatTTL(v.TTL), v.Length)
+	if v.Truncated() {
+		extra += fmt.Sprintf("\nShowing: first %d", len(v.Rows))
+	}
+	var headers []string
+	rows := v.Rows
+	switch v.Type {
+	case "string":
+		headers = []string{"Field", "Value"}
+		rows = [][]string{{"value", valuePreview(v.String, 200)}}
+		if _, ok := prettyJSON(v.String); ok {
+			extra += "\nFormat: JSON"
+		}
+	case "hash":
+		headers = []string{"Field", "Value"}
+		rows = previewColumn(rows, 1)
+	case "list":
+		headers = []string{"Index", "Value"}
+		rows = previewColumn(rows, 1)
+	case "set":
+		headers = []string{"Member"}
+	case "zset":
+		headers = []string{"Member", "Score"}
+	default:
+		content, err := s.client.GetKeyContent(s.valueKey)
+		if err != nil {
+			return pluginrpc.ViewData{}, err
+		}
+		headers = []string{"Value"}
+		rows = nil
+		for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+			rows = append(rows, []string{line})
+		}
+	}
+	rows = pluginrpc.EnsureRows(rows, pluginrpc.DashRow(len(headers), "(empty)"))
+	return ui.Connected(viewValue, title, s.baseInfo(extra), headers, rows, headers[0], valueActions(v.Type)...), nil
+}
+
+func previewColumn(rows [][]string, col int) [][]string {
+	out := make([][]string, len(rows))
+	for i, row := range rows {
+		out[i] = append([]string(nil), row...)
+		out[i][col] = valuePreview(row[col], 200)
+	}
+	return out
+}
+
+// prettyJSON indents s when it is a JSON object or array.
+func prettyJSON(s string) (string, bool) {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return "", false
+	}
+	var b bytes.Buffer
+	if err := json.Indent(&b, []byte(trimmed), "", "  "); err != nil {
+		return "", false
+	}
+	return b.String(), true
+}
+
+// compactJSON undoes prettyJSON on save; ok is false for invalid JSON.
+func compactJSON(s string) (string, bool) {
+	var b bytes.Buffer
+	if err := json.Compact(&b, []byte(s)); err != nil {
+		return "", false
+	}
+	return b.String(), true
+}
+
+func (s *Service) openKeyLocked(key string) pluginrpc.ActionResult {
+	if key == "" || key == "-" {
+		return pluginrpc.ActionResult{OK: false, Message: "no key selected"}
+	}
+	if err := s.ensureConnectedLocked(); err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	s.valueKey = key
+	view, err := s.buildViewLocked(viewValue)
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	return pluginrpc.ActionResult{OK: true, Message: "opened " + key, Next: &view}
+}
+
+// valueResultLocked repaints the value view after a write.
+func (s *Service) valueResultLocked(msg string) pluginrpc.ActionResult {
+	view, err := s.buildViewLocked(viewValue)
+	if err != nil {
+		return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+	}
+	return pluginrpc.ActionResult{OK: true, Message: msg, Next: &view}
+}
+
+func valueFail(err error) pluginrpc.ActionResult {
+	return pluginrpc.ActionResult{OK: false, Message: err.Error()}
+}
+
+// valueWrites are the value actions that change the key, with the question
+// a protected target asks first. value_add_field asks after its own
+// overwrite check.
+var valueWrites = map[string]string{
+	"value_save":       "Save the edited value of %s",
+	"value_remove":     "Remove an element from %s",
+	"value_push":       "Push to %s",
+	"value_pop":        "Pop from %s",
+	"value_add_member": "Add a member to %s",
+	"value_add_scored": "Add a member to %s",
+	"value_score":      "Change a score in %s",
+	"value_ttl":        "Change the TTL of %s",
+}
+
+// valueActionLocked runs the value_* actions against s.valueKey.
+func (s *Service) valueActionLocked(action string, payload map[string]string) pluginrpc.ActionResult {
+	if s.valueKey == "" {
+		return pluginrpc.ActionResult{OK: false, Message: "open a key first"}
+	}
+	if err := s.ensureConnectedLocked(); err != nil {
+		return valueFail(err)
+	}
+	key, field := s.valueKey, payload["key"]
+	if what, ok := valueWrites[action]; ok {
+		if res := s.guardWriteLocked(action, fmt.Sprintf(what, key), payload); res != nil {
+			return *res
+		}
+	}
+
+	switch action {
+	case "value_edit":
+		if s.protected == protectBlock {
+			return pluginrpc.ActionResult{OK: false, Message: "blocked: writes are disabled on this protected target"}
+		}
+		return s.editValueLocked(field)
+
+	case "value_save":
+		return s.saveValueLocked(payload)
+
+	case "value_add_field":
+		field, value := payload["field"], payload["value"]
+		if field == "" {
+			return pluginrpc.ActionResult{OK: false, Message: "field required"}
+		}
+		if payload["confirmed"] != "true" {
+			exists, err := s.client.HashFieldExists(key, field)
+			if err != nil {
+				return valueFail(err)
+			}
+			if exists {
+				body := fmt.Sprintf("%q already exists in %s. Overwrite it?", field, key)
+				if s.protected != protectOff {
+					body += "\n\nThis target is protected."
+				}
+				return pluginrpc.ActionResult{OK: true, Confirm: &pluginrpc.Confirm{
+					Title:   "Overwrite Field",
+					Body:    body,
+					Action:  action,
+					Payload: map[string]string{"field": field, "value": value, "confirmed": "true"},
+				}}
+			}
+		}
+		if res := s.guardWriteLocked(action, fmt.Sprintf("Set field %s of %s", field, key), payload); res != nil {
+			return *res
+		}
+		if err := s.client.WriteValue(key, "hash", field, value); err != nil {
+			return valueFail(err)
+		}
+		return s.valueResultLocked(fmt.Sprintf("set field %s", field))
+
+	case "value_remove":
+		if field == "" || field == "-" {
+			return pluginrpc.ActionResult{OK: false, Message: "nothing selected"}
+		}
+		n, err := s.client.RemoveElements(key, s.valueType, []string{field})
+		if err != nil {
+			return valueFail(err)
+		}
+		if n == 0 {
+			return s.valueResultLocked(fmt.Sprintf("%s was already gone", field))
+		}
+		return s.valueResultLocked(fmt.Sprintf("removed %s", field))
+
+	case "value_push":
+		head := strings.EqualFold(strings.TrimSpace(payload["end"]), "head")
+		n, err := s.client.PushList(key, payload["value"], head)
+		if err != nil {
+			return valueFail(err)
+		}
+		return s.valueResultLocked(fmt.Sprintf("pushed to %s of %s (%d items)", listEnd(head), key, n))
+
+	case "value_pop":
+		head := strings.EqualFold(strings.TrimSpace(payload["end"]), "head")
+		value, err := s.client.PopList(key, head)
+		if err != nil {
+			return valueFail(err)
+		}
+		return s.valueResultLocked(fmt.Sprintf("popped %q from %s", valuePreview(value, 60), listEnd(head)))
+
+	case "value_add_member":
+		member := payload["member"]
+		if member == "" {
+			return pluginrpc.ActionResult{OK: false, Message: "member required"}
+		}
+		added, err := s.client.AddSetMember(key, member)
+		if err != nil {
+			return valueFail(err)
+		}
+		if !added {
+			return s.valueResultLocked(fmt.Sprintf("%s is already a member", member))
+		}
+		return s.valueResultLocked(fmt.Sprintf("added %s", member))
+
+	case "value_add_scored", "value_score":
+		member := payload["member"]
+		if member == "" {
+			member = field
+		}
+		score, err := strconv.ParseFloat(strings.TrimSpace(payload["score"]), 64)
+		if member == "" || err != nil {
+			return pluginrpc.ActionResult{OK: false, Message: "member and numeric score required"}
+		}
+		if err := s.client.SetScore(key, member, score, action == "value_score"); err != nil {
+			return valueFail(err)
+		}
+		return s.valueResultLocked(fmt.Sprintf("%s scored %s", member, formatScore(score)))
+
+	case "value_ttl":
+		raw := strings.TrimSpace(payload["ttl"])
+		var ttl time.Duration
+		if raw != "" && raw != "-1" {
+			secs, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil || secs <= 0 {
+				return pluginrpc.ActionResult{OK: false, Message: "TTL must be seconds > 0, or blank to persist"}
+			}
+			ttl = time.Duration(secs) * time.Second
+		}
+		if err := s.client.SetTTL(key, ttl); err != nil {
+			return valueFail(err)
+		}
+		if ttl == 0 {
+			return s.valueResultLocked("removed TTL from " + key)
+		}
+		return s.valueResultLocked(fmt.Sprintf("%s expires in %s", key, ttl))
+	}
+	return pluginrpc.ActionResult{OK: false, Message: "unknown action: " + action}
+}
+
+// editValueLocked hands the selected element to the host editor; JSON is
+// pretty-printed there and compacted again on save.
+func (s *Service) editValueLocked(field string) pluginrpc.ActionResult {
+	switch s.valueType {
+	case "string":
+		field = ""
+	case "hash", "list":
+		if field == "" || field == "-" {
+			return pluginrpc.ActionResult{OK: false, Message: "nothing selected"}
+		}
+	case "zset":
+		return pluginrpc.ActionResult{OK: false, Message: "press E on a member to set its score"}
+	default:
+		return pluginrpc.ActionResult{OK: false, Message: s.valueType + " values are not edited as text"}
+	}
+	value, err := s.client.ValueField(s.valueKey, s.valueType, field)
+	if err != nil {
+		return valueFail(err)
+	}
+	title := s.valueKey
+	if field != "" {
+		title += " " + elementName(s.valueType, field)
+	}
+	edit := &pluginrpc.Edit{
+		Title:   title,
+		Content: value,
+		Suffix:  ".txt",
+		Action:  "value_save",
+		Payload: map[string]string{"target": s.valueKey, "type": s.valueType, "field": field},
+	}
+	if pretty, ok := prettyJSON(value); ok {
+		edit.Content, edit.Suffix = pretty, ".json"
+		edit.Payload["json"] = "true"
+	}
+	return pluginrpc.ActionResult{OK: true, Edit: edit}
+}
+
+func (s *Service) saveValueLocked(payload map[string]string) pluginrpc.ActionResult {
+	target, keyType, field, value := payload["target"], payload["type"], payload["field"], payload["value"]
+	if payload["json"] == "true" {
+		compact, ok := compactJSON(value)
+		if !ok {
+			next := map[string]string{}
+			for k, v := range payload {
+				next[k] = v
+			}
+			delete(next, "json")
+			return pluginrpc.ActionResult{OK: true, Confirm: &pluginrpc.Confirm{
+				Title:   "Invalid JSON",
+				Body:    "The edited value is not valid JSON. Save it as plain text anyway?",
+				Action:  "value_save",
+				Payload: next,
+			}}
+		}
+		value = compact
+	}
+	if err := s.client.WriteValue(target, keyType, field, value); err != nil {
+		return valueFail(err)
+	}
+	return s.valueResultLocked(fmt.Sprintf("saved %s", strings.TrimSpace(target+" "+field)))
+}
+
+func listEnd(head bool) string {
+	if head {
+		return "head"
+	}
+	return "tail"
+}
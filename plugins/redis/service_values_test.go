@@ -0,0 +1,45 @@
+package redis
+
+import "testing"
+
+func TestPrettyJSONRoundTrip(t *testing.T) {
+	pretty, ok := prettyJSON(`{"a":1,"b":[true,null]}`)
+	if !ok {
+		t.Fatalf("prettyJSON rejected an object")
+	}
+	want := "{\n  \"a\": 1,\n  \"b\": [\n    true,\n    null\n  ]\n}"
+	if pretty != want {
+		t.Fatalf("prettyJSON = %q, want %q", pretty, want)
+	}
+	if compact, ok := compactJSON(pretty + "\n"); !ok || compact != `{"a":1,"b":[true,null]}` {
+		t.Fatalf("compactJSON = %q, %v", compact, ok)
+	}
+	for _, plain := range []string{"42", `"str"`, "{broken", "hello"} {
+		if _, ok := prettyJSON(plain); ok {
+			t.Fatalf("prettyJSON(%q) ok, want plain text", plain)
+		}
+	}
+}
+
+func TestValuePreview(t *testing.T) {
+	if got, want := valuePreview("a\nb\tc", 10), "a⏎b c"; got != want {
+		t.Fatalf("valuePreview = %q, want %q", got, want)
+	}
+	if got, want := valuePreview("héllo wörld", 5), "héllo…"; got != want {
+		t.Fatalf("valuePreview = %q, want %q", got, want)
+	}
+}
+
+func TestValueHelpIsUnique(t *testing.T) {
+	seen := map[string]bool{}
+	for _, b := range valueHelp() {
+		id := b.Key + b.Label
+		if seen[id] {
+			t.Fatalf("duplicate help binding %s %s", b.Key, b.Label)
+		}
+		seen[id] = true
+	}
+	if len(valueActions("stream")) != 1 {
+		t.Fatalf("unknown types should only offer TTL")
+	}
+}
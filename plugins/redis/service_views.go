@@ -3,6 +3,7 @@ package redis
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,16 +32,37 @@ func moreViewBindings() []pluginrpc.KeyBinding {
 		{Key: "W", Label: "Databases", Action: "goto_databases"},
 		{Key: "X", Label: "Cmd Stats", Action: "goto_commandstats"},
 		{Key: "Z", Label: "Latency", Action: "goto_latency"},
+		{Key: "O", Label: "Nodes", Action: "goto_nodes"},
+		{Key: "K", Label: "Console", Action: "goto_console"},
+		{Key: "P", Label: "Key Tree", Action: "goto_keytree"},
+		{Key: "G", Label: "Streams", Action: "goto_streams"},
+		{Key: "B", Label: "Big Keys", Action: "goto_bigkeys"},
+		{Key: "Q", Label: "ACL", Action: "goto_acl"},
+		{Key: "J", Label: "Watch", Action: "goto_watch"},
+		{Key: "T", Label: "Functions", Action: "goto_functions"},
 	}
 }
 
 func keysActions() []pluginrpc.KeyBinding {
 	return []pluginrpc.KeyBinding{
-		{Key: "D", Label: "Del Key", Action: "delete"},
+		{Key: "D", Label: "Del Key", Action: "delete", Batch: true},
 		{Key: "F", Label: "Flush DB", Action: "flush"},
 		{Key: "N", Label: "New Key", Action: "create_key"},
 		{Key: "E", Label: "View Key", Action: "view_key"},
+		{Key: "V", Label: "Open Key", Action: "open_key"},
 		{Key: "S", Label: "DB Select", Action: "select_db"},
+		{Key: "M", Label: "Search", Action: "search_keys"},
+		{Key: "C", Label: "Cancel Scan", Action: "cancel_scan"},
+		{Key: "U", Label: "Dump Keys", Action: "dump_keys"},
+		{Key: "I", Label: "Import Keys", Action: "restore_keys"},
+		{Key: "Y", Label: "Copy To", Action: "copy_keys"},
+	}
+}
+
+func keyTreeActions() []pluginrpc.KeyBinding {
+	return []pluginrpc.KeyBinding{
+		{Key: "M", Label: "Search", Action: "search_keys"},
+		{Key: "C", Label: "Cancel Scan", Action: "cancel_scan"},
 	}
 }
 
@@ -57,6 +79,13 @@ func pubsubActions() []pluginrpc.KeyBinding {
 	}
 }
 
+func consoleActions() []pluginrpc.KeyBinding {
+	return []pluginrpc.KeyBinding{
+		{Key: ":", Label: "Command", Action: "run_command"},
+		{Key: "L", Label: "Clear", Action: "console_clear"},
+	}
+}
+
 func databasesActions() []pluginrpc.KeyBinding {
 	return []pluginrpc.KeyBinding{
 		{Key: "S", Label: "Switch DB", Action: "select_db"},
@@ -66,9 +95,21 @@ func databasesActions() []pluginrpc.KeyBinding {
 func helpSections() []pluginrpc.HelpSection {
 	return pluginrpc.HelpNav(viewNavBindings(), moreViewBindings(),
 		pluginrpc.HelpSection{Title: "Keys", Bindings: keysActions()},
+		pluginrpc.HelpSection{Title: "Key Tree", Bindings: keyTreeActions()},
+		pluginrpc.HelpSection{Title: "Key Value", Bindings: valueHelp()},
+		pluginrpc.HelpSection{Title: "Streams", Bindings: streamsActions()},
+		pluginrpc.HelpSection{Title: "Stream Groups", Bindings: streamGroupsActions()},
+		pluginrpc.HelpSection{Title: "Pending Entries", Bindings: streamPendingActions()},
 		pluginrpc.HelpSection{Title: "Memory", Bindings: memoryActions()},
+		pluginrpc.HelpSection{Title: "Big Keys", Bindings: bigKeysActions()},
+		pluginrpc.HelpSection{Title: "Memory by Namespace", Bindings: hotspotsActions()},
+		pluginrpc.HelpSection{Title: "ACL Users", Bindings: aclActions()},
+		pluginrpc.HelpSection{Title: "ACL Log", Bindings: aclLogActions()},
+		pluginrpc.HelpSection{Title: "Keyspace Watch", Bindings: watchActions()},
+		pluginrpc.HelpSection{Title: "Functions", Bindings: functionsActions()},
 		pluginrpc.HelpSection{Title: "PubSub", Bindings: pubsubActions()},
 		pluginrpc.HelpSection{Title: "Databases", Bindings: databasesActions()},
+		pluginrpc.HelpSection{Title: "Console", Bindings: consoleActions()},
 	)
 }
 
@@ -79,8 +120,8 @@ var ui = pluginrpc.ViewUI{
 }
 
 func (s *Service) baseInfo(extra string) string {
-	msg := fmt.Sprintf("[green]Redis Manager[white]\nServer: %s:%s\nDB: %d\nStatus: Connected\nView: %s",
-		s.host, s.port, s.database, s.currentView)
+	msg := fmt.Sprintf("[green]Redis Manager[white]\nServer: %s\nMode: %s\nDB: %d\nStatus: Connected\nView: %s",
+		s.conn.Label(), s.conn.Mode, s.conn.Database, s.currentView)
 	return pluginrpc.FormatInfo(msg, extra)
 }
 
@@ -96,12 +137,35 @@ func (s *Service) viewDashboardLocked() (pluginrpc.ViewData, error) {
 	if uptime != "-" {
 		uptime += "d"
 	}
-	return pluginrpc.Widget("Redis", "connected", fmt.Sprintf("%s:%s", s.host, s.port), [][2]string{
+	widget := pluginrpc.Widget("Redis", "connected", s.conn.Label(), [][2]string{
 		{"Version", dashValue(info["redis_version"])},
 		{"Uptime", uptime},
 		{"Clients", dashValue(info["connected_clients"])},
 		{"Memory", dashValue(info["used_memory_human"])},
-	}), nil
+		{"Ops/s", dashValue(info["instantaneous_ops_per_sec"])},
+	})
+	return pluginrpc.WithMetrics(widget, infoMetrics(info)...), nil
+}
+
+// dashboardMetrics are the INFO fields the dashboard keeps history for,
+// named after their widget rows.
+var dashboardMetrics = []struct{ name, unit, field string }{
+	{"Clients", "", "connected_clients"},
+	{"Memory", "B", "used_memory"},
+	{"Ops/s", "", "instantaneous_ops_per_sec"},
+}
+
+// infoMetrics reads dashboardMetrics from INFO; missing fields are left out.
+func infoMetrics(info map[string]string) []pluginrpc.Metric {
+	var out []pluginrpc.Metric
+	for _, m := range dashboardMetrics {
+		v, err := strconv.ParseFloat(strings.TrimSpace(info[m.field]), 64)
+		if err != nil {
+			continue
+		}
+		out = append(out, pluginrpc.Metric{Name: m.name, Value: v, Unit: m.unit})
+	}
+	return out
 }
 
 func dashValue(value string) string {
@@ -148,20 +212,39 @@ func (s *Service) buildViewLocked(viewID string) (pluginrpc.ViewData, error) {
 		return s.viewCommandStatsLocked()
 	case viewLatency:
 		return s.viewLatencyLocked()
+	case viewNodes:
+		return s.viewNodesLocked()
+	case viewConsole:
+		return s.viewConsoleLocked()
+	case viewKeyTree:
+		return s.viewKeyTreeLocked()
+	case viewValue:
+		return s.viewValueLocked()
+	case viewStreams:
+		return s.viewStreamsLocked()
+	case viewStreamEntries:
+		return s.viewStreamEntriesLocked()
+	case viewStreamGroups:
+		return s.viewStreamGroupsLocked()
+	case viewStreamPending:
+		return s.viewStreamPendingLocked()
+	case viewBigKeys:
+		return s.viewBigKeysLocked()
+	case viewHotspots:
+		return s.viewHotspotsLocked()
+	case viewACL:
+		return s.viewACLLocked()
+	case viewACLLog:
+		return s.viewACLLogLocked()
+	case viewWatch:
+		return s.viewWatchLocked()
+	case viewFunctions:
+		return s.viewFunctionsLocked()
 	default:
 		return s.viewKeysLocked()
 	}
 }
 
-func (s *Service) viewKeysLocked() (pluginrpc.ViewData, error) {
-	rows, err := s.loadKeysLocked(500)
-	if err != nil {
-		return pluginrpc.ViewData{}, err
-	}
-	rows = pluginrpc.EnsureRows(rows, []string{"-", "-", "-", "No keys found"})
-	return ui.Connected(viewKeys, "Redis Keys", s.baseInfo(fmt.Sprintf("Keys loaded: %d", len(rows))), []string{"Key", "Type", "TTL", "Size"}, rows, "Key", keysActions()...), nil
-}
-
 func (s *Service) viewInfoLocked() (pluginrpc.ViewData, error) {
 	infoMap, err := s.client.GetInfoMap()
 	if err != nil {
@@ -171,6 +254,7 @@ func (s *Service) viewInfoLocked() (pluginrpc.ViewData, error) {
 		"redis_version", "redis_mode", "os", "tcp_port",
 		"uptime_in_seconds", "uptime_in_days", "connected_clients",
 		"used_memory_human", "used_memory_peak_human", "role",
+		"cluster_masters",
 	}
 	rows := make([][]string, 0, len(fields))
 	for _, field := range fields {
@@ -336,7 +420,7 @@ func (s *Service) viewDatabasesLocked() (pluginrpc.ViewData, error) {
 			ttlStr = fmt.Sprintf("%ds", db.AvgTTL)
 		}
 		dbName := fmt.Sprintf("db%d", db.ID)
-		if db.ID == s.database {
+		if db.ID == s.conn.Database {
 			dbName = fmt.Sprintf("db%d *", db.ID)
 		}
 		rows = append(rows, []string{
@@ -388,6 +472,47 @@ func (s *Service) viewLatencyLocked() (pluginrpc.ViewData, error) {
 	return ui.Connected(viewLatency, "Redis Latency", s.baseInfo(""), []string{"Event", "Timestamp", "Latency (ms)"}, rows, "Event"), nil
 }
 
+func (s *Service) viewNodesLocked() (pluginrpc.ViewData, error) {
+	nodes, err := s.client.GetNodes()
+	if err != nil {
+		return pluginrpc.ViewData{}, err
+	}
+	rows := make([][]string, 0, len(nodes))
+	masters := 0
+	for _, n := range nodes {
+		if n.Role == "master" {
+			masters++
+		}
+		rows = append(rows, nodeRow(n))
+	}
+	rows = pluginrpc.EnsureRows(rows, []string{"-", "-", "-", "-", "-", "-", "No nodes"})
+	extra := fmt.Sprintf("Masters: %d  Nodes: %d", masters, len(nodes))
+	return ui.Connected(viewNodes, "Redis Nodes", s.baseInfo(extra), []string{"Addr", "Role", "Slots", "Master", "Repl Offset", "Link", "ID"}, rows, "Addr"), nil
+}
+
+func nodeRow(n NodeInfo) []string {
+	addr := n.Addr
+	if n.Self {
+		addr += " *"
+	}
+	slots := "-"
+	if n.SlotCount > 0 {
+		slots = fmt.Sprintf("%s (%d)", n.Slots, n.SlotCount)
+	}
+	offset := "-"
+	if n.Offset >= 0 {
+		offset = strconv.FormatInt(n.Offset, 10)
+	}
+	link := n.Link
+	switch link {
+	case "fail", "pfail", "down", "disconnected":
+		link = pluginrpc.Tint(pluginrpc.ColorError, link)
+	case "connected", "online", "up":
+		link = pluginrpc.Tint(pluginrpc.ColorOK, link)
+	}
+	return []string{addr, n.Role, slots, dashValue(n.Master), offset, dashValue(link), dashValue(pluginrpc.Truncate(n.ID, 12))}
+}
+
 func (s *Service) peekPubSubLocked(channel string) (string, error) {
 	sub, err := s.client.SubscribeToChannel(channel)
 	if err != nil {
@@ -19,6 +19,7 @@ type S3Client struct {
 	region      string
 	access      string
 	secret      string
+	token       string
 	endpoint    string
 	regionCache map[string]string
 }
@@ -29,7 +30,8 @@ func NewS3Client() *S3Client {
 }
 
 // Connect establishes an S3 session using profile and/or static credentials.
-func (c *S3Client) Connect(profile, region, accessKey, secretKey, endpoint string) error {
+// sessionToken is only set for temporary (STS / SSO) credentials.
+func (c *S3Client) Connect(profile, region, accessKey, secretKey, sessionToken, endpoint string) error {
 	if region == "" {
 		region = "us-east-1"
 	}
@@ -43,7 +45,7 @@ func (c *S3Client) Connect(profile, region, accessKey, secretKey, endpoint strin
 		opts.Profile = profile
 	}
 	if accessKey != "" && secretKey != "" {
-		opts.Config.Credentials = credentials.NewStaticCredentials(accessKey, secretKey, "")
+		opts.Config.Credentials = credentials.NewStaticCredentials(accessKey, secretKey, sessionToken)
 	}
 	if endpoint != "" {
 		opts.Config.Endpoint = aws.String(endpoint)
@@ -58,6 +60,7 @@ func (c *S3Client) Connect(profile, region, accessKey, secretKey, endpoint strin
 	c.region = region
 	c.access = accessKey
 	c.secret = secretKey
+	c.token = sessionToken
 	c.endpoint = endpoint
 	c.regionCache = map[string]string{}
 	return nil
@@ -73,11 +76,11 @@ func (c *S3Client) Disconnect() {
 }
 
 func (c *S3Client) createClientForRegion(region string) *s3.S3 {
-	return CreateS3ClientForRegion(c.profile, region, c.access, c.secret, c.endpoint)
+	return CreateS3ClientForRegion(c.profile, region, c.access, c.secret, c.token, c.endpoint)
 }
 
 // CreateS3ClientForRegion creates a new S3 client for a specific region.
-func CreateS3ClientForRegion(profile, region, accessKey, secretKey, endpoint string) *s3.S3 {
+func CreateS3ClientForRegion(profile, region, accessKey, secretKey, sessionToken, endpoint string) *s3.S3 {
 	if region == "" {
 		region = "us-east-1"
 	}
@@ -91,7 +94,7 @@ func CreateS3ClientForRegion(profile, region, accessKey, secretKey, endpoint str
 		opts.Profile = profile
 	}
 	if accessKey != "" && secretKey != "" {
-		opts.Config.Credentials = credentials.NewStaticCredentials(accessKey, secretKey, "")
+		opts.Config.Credentials = credentials.NewStaticCredentials(accessKey, secretKey, sessionToken)
 	}
 	if endpoint != "" {
 		opts.Config.Endpoint = aws.String(endpoint)
@@ -124,7 +127,7 @@ func (c *S3Client) BucketRegion(bucketName string) (string, error) {
 	if r, ok := c.regionCache[bucketName]; ok {
 		return r, nil
 	}
-	usEastClient := CreateS3ClientForRegion(c.profile, "us-east-1", c.access, c.secret, c.endpoint)
+	usEastClient := CreateS3ClientForRegion(c.profile, "us-east-1", c.access, c.secret, c.token, c.endpoint)
 	if usEastClient == nil {
 		return c.region, fmt.Errorf("failed to create us-east-1 client for region lookup")
 	}
@@ -19,6 +19,7 @@ import (
 //	Notes    → description / notes
 //
 //	Custom Attributes:
+//	  profile  → AWS shared-config profile (defaults to Title)
 //	  region   → AWS region (e.g. "us-east-1")
 //	  role_arn → IAM role ARN for assume-role
 //	  tags     → comma-separated tags
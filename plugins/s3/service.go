@@ -24,6 +24,7 @@ type Service struct {
 	region        string
 	accessKey     string
 	secretKey     string
+	sessionToken  string
 	endpoint      string
 	currentBucket string
 	currentPrefix string
@@ -61,9 +62,13 @@ func (s *Service) Configure(req pluginrpc.ConfigureRequest) error {
 	if req.Settings == nil {
 		return fmt.Errorf("missing settings")
 	}
-	s.profile = req.Settings["name"]
+	s.profile = req.Settings["profile"]
+	if s.profile == "" {
+		s.profile = req.Settings["name"]
+	}
 	s.accessKey = req.Settings["username"]
 	s.secretKey = req.Settings["password"]
+	s.sessionToken = req.Settings["session_token"]
 	s.endpoint = req.Settings["host"]
 	if s.endpoint == "" {
 		s.endpoint = req.Settings["url"]
@@ -563,5 +568,5 @@ func (s *Service) ensureConnectedLocked() error {
 	if s.profile == "" && s.accessKey == "" {
 		return fmt.Errorf("not configured")
 	}
-	return s.client.Connect(s.profile, s.region, s.accessKey, s.secretKey, s.endpoint)
+	return s.client.Connect(s.profile, s.region, s.accessKey, s.secretKey, s.sessionToken, s.endpoint)
 }